@@ -1,6 +1,6 @@
 module github.com/devfile/library/v2
 
-go 1.15
+go 1.16
 
 require (
 	github.com/devfile/api/v2 v2.2.0
@@ -31,12 +31,16 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
 	// DevfileSourceVolumeMount is the default directory to mount the volume in the container
 	DevfileSourceVolumeMount = "/projects"
 
+	// DevfileProjectsVolumeName is the default name of the volume holding the projects
+	DevfileProjectsVolumeName = "devfile-projects"
+
 	// EnvProjectsRoot is the env defined for project mount in a component container when component's mountSources=true
 	EnvProjectsRoot = "PROJECTS_ROOT"
 
@@ -77,7 +81,38 @@ func GetContainers(devfileObj parser.DevfileObj, options common.DevfileOptions)
 		return nil, err
 	}
 
-	// filter out containers for preStart and postStop events
+	return filterOutEventContainers(devfileObj, allContainers)
+}
+
+// ProjectsVolumeParams is a struct that contains the required data to mount the projects volume in the containers
+type ProjectsVolumeParams struct {
+	// VolumeName is the name of the projects volume, defaults to DevfileProjectsVolumeName
+	VolumeName string
+	// MountPath is the projects root of the containers without sourceMapping, defaults to DevfileSourceVolumeMount
+	MountPath string
+}
+
+// GetDevfileContainerComponents returns the containers of GetContainers with the projects volume mounted at
+// the $PROJECTS_ROOT of every container mounting the sources, i.e. the sourceMapping of the component or the
+// mount path of the params. Containers with the SkipProjectsVolumeMountAttribute attribute are not mounted.
+func GetDevfileContainerComponents(devfileObj parser.DevfileObj, projectsVolumeParams ProjectsVolumeParams, options common.DevfileOptions) ([]corev1.Container, error) {
+	if projectsVolumeParams.VolumeName == "" {
+		projectsVolumeParams.VolumeName = DevfileProjectsVolumeName
+	}
+	if projectsVolumeParams.MountPath == "" {
+		projectsVolumeParams.MountPath = DevfileSourceVolumeMount
+	}
+
+	allContainers, err := getAllContainersWithProjectsVolume(devfileObj, &projectsVolumeParams, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterOutEventContainers(devfileObj, allContainers)
+}
+
+// filterOutEventContainers filters out the containers of the preStart and postStop events
+func filterOutEventContainers(devfileObj parser.DevfileObj, allContainers []corev1.Container) ([]corev1.Container, error) {
 	preStartEvents := devfileObj.Data.GetEvents().PreStart
 	postStopEvents := devfileObj.Data.GetEvents().PostStop
 	if len(preStartEvents) > 0 || len(postStopEvents) > 0 {
@@ -207,6 +242,73 @@ func GetDeployment(devfileObj parser.DevfileObj, deployParams DeploymentParams)
 	return deployment, nil
 }
 
+// DevfileDeploymentParams is a struct that contains the required data to create a deployment object from the
+// container and volume components of a devfile
+type DevfileDeploymentParams struct {
+	ObjectMeta        metav1.ObjectMeta
+	PodSelectorLabels map[string]string
+	Replicas          *int32
+
+	// VolumeNameToPVCName is a map of the devfile volume name to the name of the PersistentVolumeClaim of the volume.
+	// The devfile volume name is used as the PersistentVolumeClaim name if the volume is not in the map.
+	VolumeNameToPVCName map[string]string
+}
+
+// GetDeploymentFromDevfile gets a deployment object with a container for every container component of the devfile and
+// a volume for every volume component mounted in the containers. An ephemeral volume is an emptyDir volume, the other
+// volumes reference their PersistentVolumeClaim. The init containers of the preStart events are added to the deployment.
+func GetDeploymentFromDevfile(devfileObj parser.DevfileObj, deployParams DevfileDeploymentParams, options common.DevfileOptions) (*appsv1.Deployment, error) {
+	containers, err := GetContainers(devfileObj, options)
+	if err != nil {
+		return nil, err
+	}
+	initContainers, err := GetInitContainers(devfileObj)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeOptions := options
+	volumeOptions.ComponentOptions = common.ComponentOptions{
+		ComponentType: v1.VolumeComponentType,
+	}
+	volumeComponents, err := devfileObj.Data.GetComponents(volumeOptions)
+	if err != nil {
+		return nil, err
+	}
+	var volumes []corev1.Volume
+	// the volumes are added one at a time to keep the order of the volumes and the volume mounts of the devfile
+	for _, volumeComp := range volumeComponents {
+		pvcName, ok := deployParams.VolumeNameToPVCName[volumeComp.Name]
+		if !ok {
+			pvcName = volumeComp.Name
+		}
+		volumeParams := VolumeParams{
+			Containers: containers,
+			VolumeNameToVolumeInfo: map[string]VolumeInfo{
+				volumeComp.Name: {
+					PVCName:    pvcName,
+					VolumeName: volumeComp.Name,
+				},
+			},
+		}
+		componentVolumes, err := GetVolumesAndVolumeMounts(devfileObj, volumeParams, options)
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, componentVolumes...)
+	}
+
+	return GetDeployment(devfileObj, DeploymentParams{
+		TypeMeta:          GetTypeMeta(deploymentKind, deploymentAPIVersion),
+		ObjectMeta:        deployParams.ObjectMeta,
+		InitContainers:    initContainers,
+		Containers:        containers,
+		Volumes:           volumes,
+		PodSelectorLabels: deployParams.PodSelectorLabels,
+		Replicas:          deployParams.Replicas,
+	})
+}
+
 // PVCParams is a struct to create PVC
 type PVCParams struct {
 	TypeMeta   metav1.TypeMeta
@@ -227,6 +329,70 @@ func GetPVC(pvcParams PVCParams) *corev1.PersistentVolumeClaim {
 	return pvc
 }
 
+// DefaultVolumeSize is the size of the PersistentVolumeClaim of a volume component without size
+const DefaultVolumeSize = "1Gi"
+
+// DevfilePVCParams is a struct that contains the required data to create the PersistentVolumeClaims of the volume
+// components of a devfile
+type DevfilePVCParams struct {
+	// ObjectMeta is the object meta of the PersistentVolumeClaims, their name is set from VolumeNameToPVCName
+	ObjectMeta metav1.ObjectMeta
+
+	// VolumeNameToPVCName is a map of the devfile volume name to the name of the PersistentVolumeClaim of the volume.
+	// The devfile volume name is used as the PersistentVolumeClaim name if the volume is not in the map.
+	VolumeNameToPVCName map[string]string
+
+	// StorageClassName is the storage class of the PersistentVolumeClaims, the default storage class is used if it is not set
+	StorageClassName *string
+
+	// AccessMode is the access mode of the PersistentVolumeClaims, ReadWriteOnce is used if it is not set
+	AccessMode corev1.PersistentVolumeAccessMode
+}
+
+// GetPVCsFromDevfile gets a PersistentVolumeClaim for every volume component of the devfile which is not ephemeral,
+// requesting the size of the volume or DefaultVolumeSize. The ephemeral volumes are emptyDir volumes of the pod instead.
+func GetPVCsFromDevfile(devfileObj parser.DevfileObj, pvcParams DevfilePVCParams, options common.DevfileOptions) ([]corev1.PersistentVolumeClaim, error) {
+	options.ComponentOptions = common.ComponentOptions{
+		ComponentType: v1.VolumeComponentType,
+	}
+	volumeComponents, err := devfileObj.Data.GetComponents(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var pvcs []corev1.PersistentVolumeClaim
+	for _, volumeComp := range volumeComponents {
+		if volumeComp.Volume.Ephemeral != nil && *volumeComp.Volume.Ephemeral {
+			continue
+		}
+		size := volumeComp.Volume.Size
+		if size == "" {
+			size = DefaultVolumeSize
+		}
+		quantity, err := resource.ParseQuantity(size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size %s of volume %s, it should be a Kubernetes quantity such as 1Gi: %v", size, volumeComp.Name, err)
+		}
+
+		objectMeta := pvcParams.ObjectMeta
+		objectMeta.Name = volumeComp.Name
+		if pvcName, ok := pvcParams.VolumeNameToPVCName[volumeComp.Name]; ok {
+			objectMeta.Name = pvcName
+		}
+		pvc := GetPVC(PVCParams{
+			TypeMeta:   GetTypeMeta("PersistentVolumeClaim", "v1"),
+			ObjectMeta: objectMeta,
+			Quantity:   quantity,
+		})
+		pvc.Spec.StorageClassName = pvcParams.StorageClassName
+		if pvcParams.AccessMode != "" {
+			pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{pvcParams.AccessMode}
+		}
+		pvcs = append(pvcs, *pvc)
+	}
+	return pvcs, nil
+}
+
 // ServiceParams is a struct that contains the required data to create a service object
 type ServiceParams struct {
 	TypeMeta       metav1.TypeMeta
@@ -312,6 +478,118 @@ func GetRoute(endpoint v1.Endpoint, routeParams RouteParams) *routev1.Route {
 	return route
 }
 
+// EndpointRouting is the kind of the objects routing the external traffic to the public endpoints
+type EndpointRouting string
+
+const (
+	// NoEndpointRouting does not route the external traffic to the public endpoints, they are only exposed by the service
+	NoEndpointRouting EndpointRouting = ""
+	// IngressEndpointRouting routes the external traffic to the public endpoints with networking v1 ingresses
+	IngressEndpointRouting EndpointRouting = "Ingress"
+	// RouteEndpointRouting routes the external traffic to the public endpoints with OpenShift routes
+	RouteEndpointRouting EndpointRouting = "Route"
+)
+
+// EndpointsParams is a struct that contains the required data to create the objects exposing the endpoints of a devfile
+type EndpointsParams struct {
+	// ObjectMeta is the object meta of the service, the ingresses and routes are named <service name>-<endpoint name>
+	ObjectMeta     metav1.ObjectMeta
+	SelectorLabels map[string]string
+	// Routing is the kind of the objects routing the external traffic to the public endpoints
+	Routing EndpointRouting
+	// IngressDomain is the domain of the ingress hosts, the host of an ingress is <ingress name>.<ingress domain>
+	IngressDomain string
+	// TLSSecretName is the name of the TLS secret of the ingresses of the secure endpoints, the default certificate
+	// of the ingress controller is used if it is not set
+	TLSSecretName string
+}
+
+// EndpointsObjects holds the objects exposing the endpoints of a devfile
+type EndpointsObjects struct {
+	Service   *corev1.Service
+	Ingresses []networkingv1.Ingress
+	Routes    []routev1.Route
+}
+
+// GetEndpointsObjects gets a service exposing the endpoints of the container components whose exposure is not none, and
+// the ingresses or routes of the public endpoints depending on the routing of the params. The ingresses and routes of
+// the secure endpoints, i.e. with secure set or an https or wss protocol, terminate TLS.
+func GetEndpointsObjects(devfileObj parser.DevfileObj, endpointsParams EndpointsParams, options common.DevfileOptions) (*EndpointsObjects, error) {
+	service, err := GetService(devfileObj, ServiceParams{
+		TypeMeta:       GetTypeMeta("Service", "v1"),
+		ObjectMeta:     endpointsParams.ObjectMeta,
+		SelectorLabels: endpointsParams.SelectorLabels,
+	}, options)
+	if err != nil {
+		return nil, err
+	}
+	objects := &EndpointsObjects{Service: service}
+	if endpointsParams.Routing == NoEndpointRouting {
+		return objects, nil
+	}
+
+	options.ComponentOptions = common.ComponentOptions{
+		ComponentType: v1.ContainerComponentType,
+	}
+	containerComponents, err := devfileObj.Data.GetComponents(options)
+	if err != nil {
+		return nil, err
+	}
+	for _, comp := range containerComponents {
+		for _, endpoint := range comp.Container.Endpoints {
+			if endpoint.Exposure != v1.PublicEndpointExposure && endpoint.Exposure != "" {
+				continue
+			}
+			name := fmt.Sprintf("%s-%s", endpointsParams.ObjectMeta.Name, endpoint.Name)
+			objectMeta := GetObjectMeta(name, endpointsParams.ObjectMeta.Namespace, endpointsParams.ObjectMeta.Labels, nil)
+			secure := (endpoint.Secure != nil && *endpoint.Secure) ||
+				endpoint.Protocol == v1.HTTPSEndpointProtocol || endpoint.Protocol == v1.WSSEndpointProtocol
+
+			switch endpointsParams.Routing {
+			case IngressEndpointRouting:
+				ingressSpecParams := IngressSpecParams{
+					ServiceName: service.Name,
+					PortNumber:  intstr.FromInt(endpoint.TargetPort),
+					Path:        endpoint.Path,
+				}
+				if endpointsParams.IngressDomain != "" {
+					ingressSpecParams.IngressDomain = fmt.Sprintf("%s.%s", name, endpointsParams.IngressDomain)
+				}
+				if secure {
+					ingressSpecParams.TLSSecretName = endpointsParams.TLSSecretName
+				}
+				ingress := GetNetworkingV1Ingress(endpoint, IngressParams{
+					TypeMeta:          GetTypeMeta("Ingress", "networking.k8s.io/v1"),
+					ObjectMeta:        objectMeta,
+					IngressSpecParams: ingressSpecParams,
+				})
+				if secure && len(ingress.Spec.TLS) == 0 {
+					ingress.Spec.TLS = []networkingv1.IngressTLS{{}}
+					if ingressSpecParams.IngressDomain != "" {
+						ingress.Spec.TLS[0].Hosts = []string{ingressSpecParams.IngressDomain}
+					}
+				}
+				objects.Ingresses = append(objects.Ingresses, *ingress)
+			case RouteEndpointRouting:
+				route := GetRoute(endpoint, RouteParams{
+					TypeMeta:   GetTypeMeta("Route", "route.openshift.io/v1"),
+					ObjectMeta: objectMeta,
+					RouteSpecParams: RouteSpecParams{
+						ServiceName: service.Name,
+						PortNumber:  intstr.FromInt(endpoint.TargetPort),
+						Path:        endpoint.Path,
+						Secure:      secure,
+					},
+				})
+				objects.Routes = append(objects.Routes, *route)
+			default:
+				return nil, fmt.Errorf("unknown endpoint routing %s, it should be one of %s, %s", endpointsParams.Routing, IngressEndpointRouting, RouteEndpointRouting)
+			}
+		}
+	}
+	return objects, nil
+}
+
 // GetOwnerReference generates an ownerReference  from the deployment which can then be set as
 // owner for various Kubernetes objects and ensure that when the owner object is deleted from the
 // cluster, all other objects are automatically removed by Kubernetes garbage collector
@@ -425,7 +703,7 @@ func GetVolumesAndVolumeMounts(devfileObj parser.DevfileObj, volumeParams Volume
 	for volName, volInfo := range volumeParams.VolumeNameToVolumeInfo {
 		emptyDirVolume := false
 		for _, volumeComp := range volumeComponent {
-			if volumeComp.Name == volName && *volumeComp.Volume.Ephemeral {
+			if volumeComp.Name == volName && volumeComp.Volume.Ephemeral != nil && *volumeComp.Volume.Ephemeral {
 				emptyDirVolume = true
 				break
 			}
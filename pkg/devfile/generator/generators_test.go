@@ -17,8 +17,11 @@ package generator
 
 import (
 	"fmt"
+	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
@@ -403,6 +406,113 @@ func TestGetContainers(t *testing.T) {
 
 }
 
+func TestGetDevfileContainerComponents(t *testing.T) {
+	devfileContent := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+- name: tools
+  container:
+    image: quay.io/tools
+    sourceMapping: /src
+- name: sidecar
+  attributes:
+    skip-projects-volume-mount: true
+  container:
+    image: quay.io/sidecar
+- name: db
+  container:
+    image: quay.io/postgres
+    mountSources: false
+`
+	devfileObj, err := parser.ParseDevfile(parser.ParserArgs{Data: []byte(devfileContent)})
+	if err != nil {
+		t.Fatalf("TestGetDevfileContainerComponents(): unexpected error %v", err)
+	}
+
+	tests := []struct {
+		name                 string
+		projectsVolumeParams ProjectsVolumeParams
+		wantVolumeMounts     map[string][]corev1.VolumeMount
+		wantProjectsRoot     map[string]string
+	}{
+		{
+			name: "default volume name and mount path",
+			wantVolumeMounts: map[string][]corev1.VolumeMount{
+				"runtime": {{Name: DevfileProjectsVolumeName, MountPath: DevfileSourceVolumeMount}},
+				"tools":   {{Name: DevfileProjectsVolumeName, MountPath: "/src"}},
+			},
+			wantProjectsRoot: map[string]string{
+				"runtime": DevfileSourceVolumeMount,
+				"tools":   "/src",
+				"sidecar": DevfileSourceVolumeMount,
+			},
+		},
+		{
+			name: "custom volume name and mount path",
+			projectsVolumeParams: ProjectsVolumeParams{
+				VolumeName: "nodejs-projects",
+				MountPath:  "/workspace",
+			},
+			wantVolumeMounts: map[string][]corev1.VolumeMount{
+				"runtime": {{Name: "nodejs-projects", MountPath: "/workspace"}},
+				"tools":   {{Name: "nodejs-projects", MountPath: "/src"}},
+			},
+			wantProjectsRoot: map[string]string{
+				"runtime": "/workspace",
+				"tools":   "/src",
+				"sidecar": "/workspace",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			containers, err := GetDevfileContainerComponents(devfileObj, tt.projectsVolumeParams, common.DevfileOptions{})
+			if err != nil {
+				t.Fatalf("TestGetDevfileContainerComponents(): unexpected error: %v", err)
+			}
+			assert.Equal(t, 4, len(containers), "TestGetDevfileContainerComponents(): The two values should be the same.")
+			for _, container := range containers {
+				assert.Equal(t, tt.wantVolumeMounts[container.Name], container.VolumeMounts, "TestGetDevfileContainerComponents(): The volume mounts of container %s should match.", container.Name)
+
+				projectsRoot := ""
+				for _, env := range container.Env {
+					if env.Name == EnvProjectsRoot {
+						projectsRoot = env.Value
+					}
+				}
+				assert.Equal(t, tt.wantProjectsRoot[container.Name], projectsRoot, "TestGetDevfileContainerComponents(): The %s of container %s should match.", EnvProjectsRoot, container.Name)
+			}
+		})
+	}
+
+	t.Run("invalid opt-out attribute", func(t *testing.T) {
+		invalidContent := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+components:
+- name: runtime
+  attributes:
+    skip-projects-volume-mount: yes please
+  container:
+    image: quay.io/nodejs-16
+`
+		invalidObj, err := parser.ParseDevfile(parser.ParserArgs{Data: []byte(invalidContent)})
+		if err != nil {
+			t.Fatalf("TestGetDevfileContainerComponents(): unexpected error %v", err)
+		}
+		_, err = GetDevfileContainerComponents(invalidObj, ProjectsVolumeParams{}, common.DevfileOptions{})
+		if err == nil {
+			t.Fatalf("TestGetDevfileContainerComponents(): expected an error, didn't get one")
+		}
+		assert.Contains(t, err.Error(), "failed to parse skip-projects-volume-mount attribute on component runtime", "TestGetDevfileContainerComponents(): Error message should match")
+	})
+}
+
 func TestGetVolumesAndVolumeMounts(t *testing.T) {
 
 	type testVolumeMountInfo struct {
@@ -1202,3 +1312,380 @@ func TestGetDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDeploymentFromDevfile(t *testing.T) {
+	devfileContent := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+    command: ["npm"]
+    args: ["start"]
+    mountSources: false
+    env:
+    - name: DEBUG_PORT
+      value: "5858"
+    endpoints:
+    - name: http-3000
+      targetPort: 3000
+    volumeMounts:
+    - name: cache
+      path: /cache
+    - name: data
+- name: tools
+  container:
+    image: quay.io/tools
+    mountSources: false
+    volumeMounts:
+    - name: cache
+      path: /tools/cache
+- name: cache
+  volume:
+    ephemeral: true
+- name: data
+  volume:
+    size: 1Gi
+`
+	devfileObj, err := parser.ParseDevfile(parser.ParserArgs{Data: []byte(devfileContent)})
+	if err != nil {
+		t.Fatalf("TestGetDeploymentFromDevfile(): unexpected error %v", err)
+	}
+
+	labels := map[string]string{"app": "nodejs"}
+	objectMeta := metav1.ObjectMeta{
+		Name:   "nodejs",
+		Labels: labels,
+	}
+	want := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "nodejs",
+			Labels:      labels,
+			Annotations: map[string]string{},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: objectMeta,
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:            "runtime",
+							Image:           "quay.io/nodejs-16",
+							ImagePullPolicy: corev1.PullAlways,
+							Command:         []string{"npm"},
+							Args:            []string{"start"},
+							Env: []corev1.EnvVar{
+								{Name: "DEBUG_PORT", Value: "5858"},
+							},
+							Ports: []corev1.ContainerPort{
+								{Name: "http-3000", ContainerPort: 3000, Protocol: corev1.ProtocolTCP},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "cache", MountPath: "/cache"},
+								{Name: "data", MountPath: "/data"},
+							},
+						},
+						{
+							Name:            "tools",
+							Image:           "quay.io/tools",
+							ImagePullPolicy: corev1.PullAlways,
+							Env:             []corev1.EnvVar{},
+							Ports:           []corev1.ContainerPort{},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "cache", MountPath: "/tools/cache"},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "cache",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+						{
+							Name: "data",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: "nodejs-data",
+								},
+							},
+						},
+					},
+				},
+			},
+			Replicas: pointer.Int32Ptr(2),
+		},
+	}
+
+	got, err := GetDeploymentFromDevfile(devfileObj, DevfileDeploymentParams{
+		ObjectMeta:          objectMeta,
+		PodSelectorLabels:   labels,
+		Replicas:            pointer.Int32Ptr(2),
+		VolumeNameToPVCName: map[string]string{"data": "nodejs-data"},
+	}, common.DevfileOptions{})
+	if err != nil {
+		t.Fatalf("TestGetDeploymentFromDevfile(): unexpected error %v", err)
+	}
+	assert.Equal(t, want, got, "TestGetDeploymentFromDevfile(): The two values should be the same.")
+}
+
+func TestGetEndpointsObjects(t *testing.T) {
+	devfileContent := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+    mountSources: false
+    endpoints:
+    - name: http
+      targetPort: 3000
+    - name: https
+      targetPort: 8443
+      secure: true
+      path: /secure
+    - name: debug
+      targetPort: 5858
+      exposure: internal
+    - name: metrics
+      targetPort: 9090
+      exposure: none
+`
+	devfileObj, err := parser.ParseDevfile(parser.ParserArgs{Data: []byte(devfileContent)})
+	if err != nil {
+		t.Fatalf("TestGetEndpointsObjects(): unexpected error %v", err)
+	}
+
+	labels := map[string]string{"app": "nodejs"}
+	wantService := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "nodejs",
+			Labels:      labels,
+			Annotations: map[string]string{},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 3000, TargetPort: intstr.FromInt(3000)},
+				{Name: "https", Port: 8443, TargetPort: intstr.FromInt(8443)},
+				{Name: "debug", Port: 5858, TargetPort: intstr.FromInt(5858)},
+			},
+			Selector: labels,
+		},
+	}
+	pathType := networkingv1.PathTypeImplementationSpecific
+	getIngress := func(name string, port int32, path string, tls []networkingv1.IngressTLS) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			TypeMeta: metav1.TypeMeta{Kind: "Ingress", APIVersion: "networking.k8s.io/v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      labels,
+				Annotations: map[string]string{},
+			},
+			Spec: networkingv1.IngressSpec{
+				Rules: []networkingv1.IngressRule{
+					{
+						Host: name + ".example.com",
+						IngressRuleValue: networkingv1.IngressRuleValue{
+							HTTP: &networkingv1.HTTPIngressRuleValue{
+								Paths: []networkingv1.HTTPIngressPath{
+									{
+										Path:     path,
+										PathType: &pathType,
+										Backend: networkingv1.IngressBackend{
+											Service: &networkingv1.IngressServiceBackend{
+												Name: "nodejs",
+												Port: networkingv1.ServiceBackendPort{Number: port},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				TLS: tls,
+			},
+		}
+	}
+	getRoute := func(name string, port int, path string, tls *routev1.TLSConfig) routev1.Route {
+		return routev1.Route{
+			TypeMeta: metav1.TypeMeta{Kind: "Route", APIVersion: "route.openshift.io/v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      labels,
+				Annotations: map[string]string{},
+			},
+			Spec: routev1.RouteSpec{
+				To:   routev1.RouteTargetReference{Kind: "Service", Name: "nodejs"},
+				Port: &routev1.RoutePort{TargetPort: intstr.FromInt(port)},
+				Path: path,
+				TLS:  tls,
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		routing       EndpointRouting
+		tlsSecretName string
+		want          EndpointsObjects
+	}{
+		{
+			name:    "service only",
+			routing: NoEndpointRouting,
+			want:    EndpointsObjects{Service: wantService},
+		},
+		{
+			name:          "ingresses of the public endpoints",
+			routing:       IngressEndpointRouting,
+			tlsSecretName: "nodejs-tls",
+			want: EndpointsObjects{
+				Service: wantService,
+				Ingresses: []networkingv1.Ingress{
+					getIngress("nodejs-http", 3000, "/", nil),
+					getIngress("nodejs-https", 8443, "/secure", []networkingv1.IngressTLS{
+						{Hosts: []string{"nodejs-https.example.com"}, SecretName: "nodejs-tls"},
+					}),
+				},
+			},
+		},
+		{
+			name:    "ingresses of the public endpoints with the default certificate",
+			routing: IngressEndpointRouting,
+			want: EndpointsObjects{
+				Service: wantService,
+				Ingresses: []networkingv1.Ingress{
+					getIngress("nodejs-http", 3000, "/", nil),
+					getIngress("nodejs-https", 8443, "/secure", []networkingv1.IngressTLS{
+						{Hosts: []string{"nodejs-https.example.com"}},
+					}),
+				},
+			},
+		},
+		{
+			name:    "routes of the public endpoints",
+			routing: RouteEndpointRouting,
+			want: EndpointsObjects{
+				Service: wantService,
+				Routes: []routev1.Route{
+					getRoute("nodejs-http", 3000, "/", nil),
+					getRoute("nodejs-https", 8443, "/secure", &routev1.TLSConfig{
+						Termination:                   routev1.TLSTerminationEdge,
+						InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+					}),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetEndpointsObjects(devfileObj, EndpointsParams{
+				ObjectMeta:     metav1.ObjectMeta{Name: "nodejs", Labels: labels},
+				SelectorLabels: labels,
+				Routing:        tt.routing,
+				IngressDomain:  "example.com",
+				TLSSecretName:  tt.tlsSecretName,
+			}, common.DevfileOptions{})
+			if err != nil {
+				t.Fatalf("TestGetEndpointsObjects(): unexpected error %v", err)
+			}
+			assert.Equal(t, tt.want, *got, "TestGetEndpointsObjects(): The two values should be the same.")
+		})
+	}
+}
+
+func TestGetPVCsFromDevfile(t *testing.T) {
+	devfileContent := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+components:
+- name: data
+  volume:
+    size: 5Gi
+- name: cache
+  volume: {}
+- name: tmp
+  volume:
+    ephemeral: true
+`
+	devfileObj, err := parser.ParseDevfile(parser.ParserArgs{Data: []byte(devfileContent)})
+	if err != nil {
+		t.Fatalf("TestGetPVCsFromDevfile(): unexpected error %v", err)
+	}
+
+	storageClassName := "fast"
+	labels := map[string]string{"app": "nodejs"}
+	getPVC := func(name string, size string, accessMode corev1.PersistentVolumeAccessMode, storageClassName *string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			TypeMeta: metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{accessMode},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(size),
+					},
+				},
+				StorageClassName: storageClassName,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		pvcParams DevfilePVCParams
+		want      []corev1.PersistentVolumeClaim
+	}{
+		{
+			name: "sized and default-size volumes, the ephemeral volume is skipped",
+			pvcParams: DevfilePVCParams{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			},
+			want: []corev1.PersistentVolumeClaim{
+				getPVC("data", "5Gi", corev1.ReadWriteOnce, nil),
+				getPVC("cache", DefaultVolumeSize, corev1.ReadWriteOnce, nil),
+			},
+		},
+		{
+			name: "PVC names, storage class and access mode",
+			pvcParams: DevfilePVCParams{
+				ObjectMeta:          metav1.ObjectMeta{Labels: labels},
+				VolumeNameToPVCName: map[string]string{"data": "nodejs-data"},
+				StorageClassName:    &storageClassName,
+				AccessMode:          corev1.ReadWriteMany,
+			},
+			want: []corev1.PersistentVolumeClaim{
+				getPVC("nodejs-data", "5Gi", corev1.ReadWriteMany, &storageClassName),
+				getPVC("cache", DefaultVolumeSize, corev1.ReadWriteMany, &storageClassName),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetPVCsFromDevfile(devfileObj, tt.pvcParams, common.DevfileOptions{})
+			if err != nil {
+				t.Fatalf("TestGetPVCsFromDevfile(): unexpected error %v", err)
+			}
+			assert.Equal(t, tt.want, got, "TestGetPVCsFromDevfile(): The two values should be the same.")
+		})
+	}
+}
@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/go-multierror"
 	"path/filepath"
-	"reflect"
 	"strings"
 
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
@@ -40,6 +39,9 @@ import (
 
 const ContainerOverridesAttribute = "container-overrides"
 
+// SkipProjectsVolumeMountAttribute is the boolean attribute a container component sets to opt out of the projects volume mount
+const SkipProjectsVolumeMountAttribute = "skip-projects-volume-mount"
+
 // convertEnvs converts environment variables from the devfile structure to kubernetes structure
 func convertEnvs(vars []v1.EnvVar) []corev1.EnvVar {
 	kVars := []corev1.EnvVar{}
@@ -85,54 +87,53 @@ func convertPorts(endpoints []v1.Endpoint) []corev1.ContainerPort {
 
 // getResourceReqs creates a kubernetes ResourceRequirements object based on resource requirements set in the devfile
 func getResourceReqs(comp v1.Component) (corev1.ResourceRequirements, error) {
+	if comp.Container == nil {
+		return corev1.ResourceRequirements{}, nil
+	}
+	return getContainerResourceReqs(comp.Container.Container, fmt.Sprintf(" for component %s", comp.Name))
+}
+
+// GetResourceRequirements creates a kubernetes ResourceRequirements object from the memory and cpu limits and requests
+// of a devfile container, the unset ones are omitted. An error naming the field is returned for every invalid quantity.
+func GetResourceRequirements(container v1.Container) (corev1.ResourceRequirements, error) {
+	return getContainerResourceReqs(container, "")
+}
+
+// getContainerResourceReqs creates a kubernetes ResourceRequirements object from the resource requirements of the
+// container, errorContext is appended to the name of the invalid field in the errors
+func getContainerResourceReqs(container v1.Container, errorContext string) (corev1.ResourceRequirements, error) {
 	reqs := corev1.ResourceRequirements{}
 	limits := make(corev1.ResourceList)
 	requests := make(corev1.ResourceList)
 	var returnedErr error
-	if comp.Container != nil {
-		if comp.Container.MemoryLimit != "" {
-			memoryLimit, err := resource.ParseQuantity(comp.Container.MemoryLimit)
-			if err != nil {
-				errMsg := fmt.Errorf("error parsing memoryLimit requirement for component %s: %v", comp.Name, err.Error())
-				returnedErr = multierror.Append(returnedErr, errMsg)
-			} else {
-				limits[corev1.ResourceMemory] = memoryLimit
-			}
-		}
-		if comp.Container.CpuLimit != "" {
-			cpuLimit, err := resource.ParseQuantity(comp.Container.CpuLimit)
-			if err != nil {
-				errMsg := fmt.Errorf("error parsing cpuLimit requirement for component %s: %v", comp.Name, err.Error())
-				returnedErr = multierror.Append(returnedErr, errMsg)
-			} else {
-				limits[corev1.ResourceCPU] = cpuLimit
-			}
-		}
-		if comp.Container.MemoryRequest != "" {
-			memoryRequest, err := resource.ParseQuantity(comp.Container.MemoryRequest)
-			if err != nil {
-				errMsg := fmt.Errorf("error parsing memoryRequest requirement for component %s: %v", comp.Name, err.Error())
-				returnedErr = multierror.Append(returnedErr, errMsg)
-			} else {
-				requests[corev1.ResourceMemory] = memoryRequest
-			}
-		}
-		if comp.Container.CpuRequest != "" {
-			cpuRequest, err := resource.ParseQuantity(comp.Container.CpuRequest)
-			if err != nil {
-				errMsg := fmt.Errorf("error parsing cpuRequest requirement for component %s: %v", comp.Name, err.Error())
-				returnedErr = multierror.Append(returnedErr, errMsg)
-			} else {
-				requests[corev1.ResourceCPU] = cpuRequest
-			}
-		}
-		if !reflect.DeepEqual(limits, corev1.ResourceList{}) {
-			reqs.Limits = limits
+	for _, field := range []struct {
+		name     string
+		value    string
+		list     corev1.ResourceList
+		resource corev1.ResourceName
+	}{
+		{name: "memoryLimit", value: container.MemoryLimit, list: limits, resource: corev1.ResourceMemory},
+		{name: "cpuLimit", value: container.CpuLimit, list: limits, resource: corev1.ResourceCPU},
+		{name: "memoryRequest", value: container.MemoryRequest, list: requests, resource: corev1.ResourceMemory},
+		{name: "cpuRequest", value: container.CpuRequest, list: requests, resource: corev1.ResourceCPU},
+	} {
+		if field.value == "" {
+			continue
 		}
-		if !reflect.DeepEqual(requests, corev1.ResourceList{}) {
-			reqs.Requests = requests
+		quantity, err := resource.ParseQuantity(field.value)
+		if err != nil {
+			errMsg := fmt.Errorf("error parsing %s requirement%s: %v", field.name, errorContext, err.Error())
+			returnedErr = multierror.Append(returnedErr, errMsg)
+		} else {
+			field.list[field.resource] = quantity
 		}
 	}
+	if len(limits) > 0 {
+		reqs.Limits = limits
+	}
+	if len(requests) > 0 {
+		reqs.Requests = requests
+	}
 	return reqs, returnedErr
 }
 
@@ -582,6 +583,12 @@ func addVolumeMountToContainers(containers []corev1.Container, volumeName string
 
 // getAllContainers iterates through the devfile components and returns all container components
 func getAllContainers(devfileObj parser.DevfileObj, options common.DevfileOptions) ([]corev1.Container, error) {
+	return getAllContainersWithProjectsVolume(devfileObj, nil, options)
+}
+
+// getAllContainersWithProjectsVolume returns all container components, the projects volume is mounted
+// at the projects root of the containers mounting the sources if projectsVolume is not nil
+func getAllContainersWithProjectsVolume(devfileObj parser.DevfileObj, projectsVolume *ProjectsVolumeParams, options common.DevfileOptions) ([]corev1.Container, error) {
 	var containers []corev1.Container
 
 	options.ComponentOptions = common.ComponentOptions{
@@ -612,7 +619,11 @@ func getAllContainers(devfileObj parser.DevfileObj, options common.DevfileOption
 
 		// If `mountSources: true` was set PROJECTS_ROOT & PROJECT_SOURCE env
 		if comp.Container.MountSources == nil || *comp.Container.MountSources {
-			syncRootFolder := addSyncRootFolder(container, comp.Container.SourceMapping)
+			sourceMapping := comp.Container.SourceMapping
+			if sourceMapping == "" && projectsVolume != nil {
+				sourceMapping = projectsVolume.MountPath
+			}
+			syncRootFolder := addSyncRootFolder(container, sourceMapping)
 
 			projects, err := devfileObj.Data.GetProjects(common.DevfileOptions{})
 			if err != nil {
@@ -622,6 +633,19 @@ func getAllContainers(devfileObj parser.DevfileObj, options common.DevfileOption
 			if err != nil {
 				return nil, err
 			}
+
+			if projectsVolume != nil {
+				skip, err := skipProjectsVolumeMount(comp)
+				if err != nil {
+					return nil, err
+				}
+				if !skip {
+					container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+						Name:      projectsVolume.VolumeName,
+						MountPath: syncRootFolder,
+					})
+				}
+			}
 		}
 		// Check if there is an override attribute
 		if comp.Attributes.Exists(ContainerOverridesAttribute) {
@@ -637,6 +661,19 @@ func getAllContainers(devfileObj parser.DevfileObj, options common.DevfileOption
 	return containers, nil
 }
 
+// skipProjectsVolumeMount checks if the container component opts out of the projects volume mount with SkipProjectsVolumeMountAttribute
+func skipProjectsVolumeMount(comp v1.Component) (bool, error) {
+	if !comp.Attributes.Exists(SkipProjectsVolumeMountAttribute) {
+		return false, nil
+	}
+	var err error
+	skip := comp.Attributes.GetBoolean(SkipProjectsVolumeMountAttribute, &err)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s attribute on component %s: %w", SkipProjectsVolumeMountAttribute, comp.Name, err)
+	}
+	return skip, nil
+}
+
 // containerOverridesHandler overrides the attributes of a container component as defined inside ContainerOverridesAttribute by a strategic merge patch.
 func containerOverridesHandler(comp v1.Component, container *corev1.Container) (*corev1.Container, error) {
 	// Apply the override
@@ -299,6 +299,97 @@ func TestGetResourceReqs(t *testing.T) {
 	}
 }
 
+func TestGetResourceRequirements(t *testing.T) {
+	tests := []struct {
+		name      string
+		container v1.Container
+		want      corev1.ResourceRequirements
+		wantErr   []string
+	}{
+		{
+			name: "all the requirements set",
+			container: v1.Container{
+				MemoryLimit:   "1Gi",
+				MemoryRequest: "512Mi",
+				CpuLimit:      "500m",
+				CpuRequest:    "100m",
+			},
+			want: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+				},
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+				},
+			},
+		},
+		{
+			name: "limits only",
+			container: v1.Container{
+				MemoryLimit: "1Gi",
+				CpuLimit:    "500m",
+			},
+			want: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+				},
+			},
+		},
+		{
+			name: "memory request only",
+			container: v1.Container{
+				MemoryRequest: "512Mi",
+			},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+				},
+			},
+		},
+		{
+			name:      "no requirement set",
+			container: v1.Container{Image: "quay.io/nodejs-16"},
+			want:      corev1.ResourceRequirements{},
+		},
+		{
+			name: "invalid requirements",
+			container: v1.Container{
+				MemoryLimit: "1Gi",
+				CpuLimit:    "half",
+				CpuRequest:  "1x",
+			},
+			wantErr: []string{
+				"error parsing cpuLimit requirement: quantities must match the regular expression",
+				"error parsing cpuRequest requirement: quantities must match the regular expression",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := GetResourceRequirements(tt.container)
+			if tt.wantErr != nil {
+				merr, ok := err.(*multierror.Error)
+				if !ok {
+					t.Fatalf("TestGetResourceRequirements() expected errors %v, got: %v", tt.wantErr, err)
+				}
+				assert.Equal(t, len(tt.wantErr), len(merr.Errors), "Error list length should match")
+				for i := 0; i < len(merr.Errors); i++ {
+					assert.Contains(t, merr.Errors[i].Error(), tt.wantErr[i], "Error message should match")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("TestGetResourceRequirements() unexpected error: %v", err)
+			}
+			assert.Equal(t, tt.want, req, "TestGetResourceRequirements(): The two values should be the same.")
+		})
+	}
+}
+
 func TestAddSyncRootFolder(t *testing.T) {
 
 	tests := []struct {
@@ -16,6 +16,10 @@
 package devfile
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/devfile/api/v2/pkg/validation/variables"
 	"github.com/devfile/library/v2/pkg/devfile/parser"
 	"github.com/devfile/library/v2/pkg/devfile/validate"
@@ -107,6 +111,12 @@ func ParseDevfileAndValidate(args parser.ParserArgs) (d parser.DevfileObj, varWa
 
 		// replace the top level variable keys with their values in the devfile
 		varWarning = variables.ValidateAndReplaceGlobalVariable(d.Data.GetDevfileWorkspaceSpec())
+
+		if args.ErrorOnUnresolvedVariable != nil && *args.ErrorOnUnresolvedVariable {
+			if err = unresolvedVariablesError(varWarning); err != nil {
+				return d, varWarning, err
+			}
+		}
 	}
 
 	// generic validation on devfile content
@@ -115,5 +125,41 @@ func ParseDevfileAndValidate(args parser.ParserArgs) (d parser.DevfileObj, varWa
 		return d, varWarning, err
 	}
 
+	// custom validation of the components provided by the consumer
+	if args.ComponentValidator != nil {
+		err = validate.ValidateComponentsWith(d.Data, args.ComponentValidator)
+		if err != nil {
+			return d, varWarning, err
+		}
+	}
+
 	return d, varWarning, err
 }
+
+// unresolvedVariablesError returns an error listing the unresolved variable references of the variable warning
+// and the devfile objects holding them, or nil if there is no unresolved reference
+func unresolvedVariablesError(varWarning variables.VariableWarning) error {
+	var references []string
+	for _, objects := range []struct {
+		kind       string
+		references map[string][]string
+	}{
+		{kind: "component", references: varWarning.Components},
+		{kind: "command", references: varWarning.Commands},
+		{kind: "project", references: varWarning.Projects},
+		{kind: "starterProject", references: varWarning.StarterProjects},
+	} {
+		var names []string
+		for name := range objects.references {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			references = append(references, fmt.Sprintf("%s %s: %s", objects.kind, name, strings.Join(objects.references[name], ", ")))
+		}
+	}
+	if len(references) == 0 {
+		return nil
+	}
+	return fmt.Errorf("devfile has unresolved variable references:\n%s", strings.Join(references, "\n"))
+}
@@ -16,6 +16,7 @@
 package devfile
 
 import (
+	"fmt"
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"net"
 	"net/http"
@@ -342,3 +343,131 @@ schemaVersion: 2.2.0
 		})
 	}
 }
+
+func TestParseDevfileAndValidateComponentValidator(t *testing.T) {
+	devfileContent := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+variables:
+  registry: docker.io
+components:
+- name: runtime
+  container:
+    image: "{{registry}}/nodejs-16"
+- name: data
+  volume:
+    size: 1Gi
+`
+	allowedRegistry := func(component v1.Component) error {
+		if component.Container != nil && !strings.HasPrefix(component.Container.Image, "quay.io/") {
+			return fmt.Errorf("image %s is not pulled from quay.io", component.Container.Image)
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name              string
+		externalVariables map[string]string
+		wantErr           string
+	}{
+		{
+			name:    "component rejected by the validator",
+			wantErr: "component runtime: image docker.io/nodejs-16 is not pulled from quay.io",
+		},
+		{
+			name:              "component accepted by the validator after variable substitution",
+			externalVariables: map[string]string{"registry": "quay.io"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseDevfileAndValidate(parser.ParserArgs{
+				Data:               []byte(devfileContent),
+				ExternalVariables:  tt.externalVariables,
+				ComponentValidator: allowedRegistry,
+			})
+			if (err != nil) != (tt.wantErr != "") {
+				t.Errorf("TestParseDevfileAndValidateComponentValidator() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil && !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("TestParseDevfileAndValidateComponentValidator() error: expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseDevfileAndValidateUnresolvedVariables(t *testing.T) {
+	devfileContent := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+variables:
+  registry: quay.io
+components:
+- name: runtime
+  container:
+    image: "{{registry}}/nodejs-16:{{tag}}"
+    env:
+    - name: DEBUG_PORT
+      value: "{{debugPort}}"
+commands:
+- id: run
+  exec:
+    component: runtime
+    commandLine: npm start -- --mode={{mode}}
+`
+	strict := true
+	lenient := false
+
+	tests := []struct {
+		name                      string
+		externalVariables         map[string]string
+		errorOnUnresolvedVariable *bool
+		wantErr                   []string
+		wantComponentWarnings     map[string][]string
+	}{
+		{
+			name:                  "lenient by default",
+			wantComponentWarnings: map[string][]string{"runtime": {"debugPort", "tag"}},
+		},
+		{
+			name:                      "lenient mode",
+			errorOnUnresolvedVariable: &lenient,
+			wantComponentWarnings:     map[string][]string{"runtime": {"debugPort", "tag"}},
+		},
+		{
+			name:                      "strict mode lists the unresolved references",
+			errorOnUnresolvedVariable: &strict,
+			wantErr: []string{
+				"devfile has unresolved variable references",
+				"component runtime: debugPort, tag",
+				"command run: mode",
+			},
+			wantComponentWarnings: map[string][]string{"runtime": {"debugPort", "tag"}},
+		},
+		{
+			name:                      "strict mode with all the variables resolved",
+			externalVariables:         map[string]string{"tag": "latest", "debugPort": "5858", "mode": "dev"},
+			errorOnUnresolvedVariable: &strict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, varWarning, err := ParseDevfileAndValidate(parser.ParserArgs{
+				Data:                      []byte(devfileContent),
+				ExternalVariables:         tt.externalVariables,
+				ErrorOnUnresolvedVariable: tt.errorOnUnresolvedVariable,
+			})
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestParseDevfileAndValidateUnresolvedVariables() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					if !strings.Contains(err.Error(), wantErr) {
+						t.Errorf("TestParseDevfileAndValidateUnresolvedVariables() error: expected %v, got %v", wantErr, err)
+					}
+				}
+			}
+			if len(tt.wantComponentWarnings) > 0 && !reflect.DeepEqual(varWarning.Components, tt.wantComponentWarnings) {
+				t.Errorf("TestParseDevfileAndValidateUnresolvedVariables() error: expected component warnings %v, got %v", tt.wantComponentWarnings, varWarning.Components)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+)
+
+// BudgetLimit identifies a limit of the ResourceBudget
+type BudgetLimit string
+
+const (
+	// MaxDecodedSizeLimit is the limit of the size in bytes of the decoded content of a devfile
+	MaxDecodedSizeLimit BudgetLimit = "MaxDecodedSize"
+	// MaxImportDepthLimit is the limit of the depth of the parent and plugin imports
+	MaxImportDepthLimit BudgetLimit = "MaxImportDepth"
+	// MaxComponentsLimit is the limit of the number of components of a devfile
+	MaxComponentsLimit BudgetLimit = "MaxComponents"
+	// MaxCommandsLimit is the limit of the number of commands of a devfile
+	MaxCommandsLimit BudgetLimit = "MaxCommands"
+	// MaxDownloadedBytesLimit is the limit of the total number of bytes downloaded while parsing
+	MaxDownloadedBytesLimit BudgetLimit = "MaxDownloadedBytes"
+	// MaxImportsLimit is the limit of the total number of parents and plugins imported while parsing
+	MaxImportsLimit BudgetLimit = "MaxImports"
+)
+
+// DefaultMaxImports is the maximum total number of parents and plugins imported while parsing a devfile if the
+// ResourceBudget does not set MaxImports
+const DefaultMaxImports = 256
+
+// ResourceBudget defines the limits a devfile must comply with to be parsed, a zero value disables the limit
+type ResourceBudget struct {
+	// MaxDecodedSize is the maximum size in bytes of the decoded content of the devfile and of each of its parents and plugins
+	MaxDecodedSize int64
+	// MaxImportDepth is the maximum depth of the parent and plugin imports, the devfile itself being at depth 0
+	MaxImportDepth int64
+	// MaxComponents is the maximum number of components of the devfile, after its parents and plugins are resolved
+	MaxComponents int64
+	// MaxCommands is the maximum number of commands of the devfile, after its parents and plugins are resolved
+	MaxCommands int64
+	// MaxDownloadedBytes is the maximum number of bytes downloaded for the devfile and all of its parents and plugins
+	MaxDownloadedBytes int64
+	// MaxImports is the maximum total number of parents and plugins imported by the devfile and all of its parents and
+	// plugins. DefaultMaxImports is used if it is zero, a negative value disables the limit.
+	MaxImports int64
+}
+
+// BudgetExceededError is returned if the devfile exceeds a limit of the ResourceBudget
+type BudgetExceededError struct {
+	// Limit is the limit which has been exceeded
+	Limit BudgetLimit
+	// Max is the value of the limit
+	Max int64
+	// Actual is the value which exceeds the limit
+	Actual int64
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("resource budget exceeded: %s is %d, it should not exceed %d", e.Limit, e.Actual, e.Max)
+}
+
+// budgetTracker enforces the resource budget across the devfile and all of its parents and plugins
+type budgetTracker struct {
+	budget          ResourceBudget
+	downloadedBytes int64
+	imports         int64
+}
+
+// newBudgetTracker returns a budget tracker for the budget, only the default limits are enforced if no budget is set
+func newBudgetTracker(budget *ResourceBudget) *budgetTracker {
+	b := &budgetTracker{}
+	if budget != nil {
+		b.budget = *budget
+	}
+	if b.budget.MaxImports == 0 {
+		b.budget.MaxImports = DefaultMaxImports
+	}
+	return b
+}
+
+// check returns a BudgetExceededError if the value exceeds the limit, a zero max disabling the limit
+func (b *budgetTracker) check(limit BudgetLimit, max int64, actual int64) error {
+	if max > 0 && actual > max {
+		return &BudgetExceededError{Limit: limit, Max: max, Actual: actual}
+	}
+	return nil
+}
+
+// addDownloadedBytes adds the size of downloaded content to the total of downloaded bytes
+func (b *budgetTracker) addDownloadedBytes(size int) error {
+	if b == nil {
+		return nil
+	}
+	b.downloadedBytes += int64(size)
+	return b.check(MaxDownloadedBytesLimit, b.budget.MaxDownloadedBytes, b.downloadedBytes)
+}
+
+// addImport counts an import of a parent or plugin in the total of imports
+func (b *budgetTracker) addImport() error {
+	if b == nil {
+		return nil
+	}
+	b.imports++
+	return b.check(MaxImportsLimit, b.budget.MaxImports, b.imports)
+}
+
+// checkPopulatedDevfile checks the decoded content size and the import depth of a populated devfile
+func (b *budgetTracker) checkPopulatedDevfile(d DevfileObj, resolveCtx *resolutionContextTree) error {
+	if b == nil {
+		return nil
+	}
+	if err := b.check(MaxDecodedSizeLimit, b.budget.MaxDecodedSize, int64(d.Ctx.GetDevfileContentSize())); err != nil {
+		return err
+	}
+	var depth int64
+	for node := resolveCtx; node.parentNode != nil; node = node.parentNode {
+		depth++
+	}
+	return b.check(MaxImportDepthLimit, b.budget.MaxImportDepth, depth)
+}
+
+// checkParsedDevfile checks the number of components and commands of a parsed devfile
+func (b *budgetTracker) checkParsedDevfile(d DevfileObj) error {
+	if b == nil {
+		return nil
+	}
+	components, err := d.Data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+	if err := b.check(MaxComponentsLimit, b.budget.MaxComponents, int64(len(components))); err != nil {
+		return err
+	}
+	commands, err := d.Data.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+	return b.check(MaxCommandsLimit, b.budget.MaxCommands, int64(len(commands)))
+}
@@ -42,6 +42,8 @@ func (d *DevfileCtx) SetDevfileAPIVersion() error {
 		devfilePath = d.GetAbsPath()
 	} else if d.GetURL() != "" {
 		devfilePath = d.GetURL()
+	} else if d.GetName() != "" {
+		devfilePath = d.GetName()
 	}
 
 	if okSchema {
@@ -50,6 +52,12 @@ func (d *DevfileCtx) SetDevfileAPIVersion() error {
 			return fmt.Errorf("schemaVersion in devfile: %s cannot be empty", devfilePath)
 		}
 	} else {
+		// Get "apiVersion" value from map for legacy devfile V1
+		if apiVersion, ok := r["apiVersion"].(string); ok && strings.HasPrefix(apiVersion, "1.") {
+			d.apiVersion = apiVersion
+			klog.V(4).Infof("devfile apiVersion: '%s'", d.apiVersion)
+			return nil
+		}
 		return fmt.Errorf("schemaVersion not present in devfile: %s", devfilePath)
 	}
 
@@ -29,6 +29,7 @@ func TestSetDevfileAPIVersion(t *testing.T) {
 		concreteSchema         = `{"schemaVersion": "2.2.0-latest"}`
 		emptyJson              = "{}"
 		emptySchemaVersionJson = `{"schemaVersion": ""}`
+		v1Json                 = `{"apiVersion": "1.0.0"}`
 		devfilePath            = "/testpath/devfile.yaml"
 		devfileURL             = "http://server/devfile.yaml"
 	)
@@ -58,6 +59,12 @@ func TestSetDevfileAPIVersion(t *testing.T) {
 			want:       "",
 			wantErr:    fmt.Errorf("schemaVersion not present in devfile: %s", devfilePath),
 		},
+		{
+			name:       "legacy devfile 1.x apiVersion",
+			devfileCtx: DevfileCtx{rawContent: []byte(v1Json), absPath: devfilePath},
+			want:       "1.0.0",
+			wantErr:    nil,
+		},
 		{
 			name:       "schemaVersion empty",
 			devfileCtx: DevfileCtx{rawContent: []byte(emptySchemaVersionJson), url: devfileURL},
@@ -0,0 +1,84 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/hashicorp/go-multierror"
+)
+
+// RegisterAttributeType registers the Go type of the devfile top-level attribute with the given key.
+// proto is a value or a pointer of the type the attribute is decoded into when the devfile is parsed.
+func (d *DevfileCtx) RegisterAttributeType(key string, proto interface{}) {
+	protoType := reflect.TypeOf(proto)
+	if protoType != nil && protoType.Kind() == reflect.Ptr {
+		protoType = protoType.Elem()
+	}
+	if d.attributeTypes == nil {
+		d.attributeTypes = make(map[string]reflect.Type)
+	}
+	d.attributeTypes[key] = protoType
+}
+
+// HasAttributeTypes returns true if a Go type has been registered for any devfile top-level attribute
+func (d *DevfileCtx) HasAttributeTypes() bool {
+	return len(d.attributeTypes) > 0
+}
+
+// SetTypedAttributes decodes the devfile top-level attributes into the Go types registered for their keys,
+// attributes without a registered type are ignored. The decoding errors of all keys are returned together.
+func (d *DevfileCtx) SetTypedAttributes(devfileAttributes attributes.Attributes) error {
+	var returnedErr error
+	typedAttributes := make(map[string]interface{})
+	for key, attributeType := range d.attributeTypes {
+		if attributeType == nil {
+			returnedErr = multierror.Append(returnedErr, fmt.Errorf("no type registered for attribute %s", key))
+			continue
+		}
+		if !devfileAttributes.Exists(key) {
+			continue
+		}
+		value := reflect.New(attributeType)
+		if err := devfileAttributes.GetInto(key, value.Interface()); err != nil {
+			returnedErr = multierror.Append(returnedErr, fmt.Errorf("failed to decode attribute %s into %s: %v", key, attributeType, err))
+			continue
+		}
+		typedAttributes[key] = value.Interface()
+	}
+	d.typedAttributes = typedAttributes
+	return returnedErr
+}
+
+// GetTypedAttribute sets out, a pointer to the type registered for the attribute key, to the decoded attribute value
+func (d *DevfileCtx) GetTypedAttribute(key string, out interface{}) error {
+	attributeType, ok := d.attributeTypes[key]
+	if !ok {
+		return fmt.Errorf("no type registered for attribute %s", key)
+	}
+	value, ok := d.typedAttributes[key]
+	if !ok {
+		return fmt.Errorf("attribute %s is not found in the devfile", key)
+	}
+	outValue := reflect.ValueOf(out)
+	if outValue.Kind() != reflect.Ptr || outValue.IsNil() || outValue.Elem().Type() != attributeType {
+		return fmt.Errorf("cannot get attribute %s into %T, expected *%s", key, out, attributeType)
+	}
+	outValue.Elem().Set(reflect.ValueOf(value).Elem())
+	return nil
+}
@@ -0,0 +1,121 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/stretchr/testify/assert"
+)
+
+type toolExtension struct {
+	Name    string   `json:"name"`
+	Plugins []string `json:"plugins"`
+}
+
+func TestTypedAttributes(t *testing.T) {
+
+	extensionAttributes := attributes.Attributes{}.FromMap(map[string]interface{}{
+		"tool.io/extension": map[string]interface{}{
+			"name":    "my-tool",
+			"plugins": []string{"plugin1", "plugin2"},
+		},
+		"tool.io/invalid": "not-an-object",
+	}, nil)
+
+	notRegisteredErr := "no type registered for attribute tool.io/missing"
+	decodeErr := "failed to decode attribute tool.io/invalid into parser.toolExtension"
+	notFoundErr := "attribute tool.io/absent is not found in the devfile"
+	wrongTypeErr := "cannot get attribute tool.io/extension into \\*string, expected \\*parser.toolExtension"
+
+	tests := []struct {
+		name          string
+		registered    map[string]interface{}
+		key           string
+		out           interface{}
+		wantOut       interface{}
+		wantDecodeErr *string
+		wantErr       *string
+	}{
+		{
+			name:       "attribute registered with a value prototype",
+			registered: map[string]interface{}{"tool.io/extension": toolExtension{}},
+			key:        "tool.io/extension",
+			out:        &toolExtension{},
+			wantOut:    &toolExtension{Name: "my-tool", Plugins: []string{"plugin1", "plugin2"}},
+		},
+		{
+			name:       "attribute registered with a pointer prototype",
+			registered: map[string]interface{}{"tool.io/extension": &toolExtension{}},
+			key:        "tool.io/extension",
+			out:        &toolExtension{},
+			wantOut:    &toolExtension{Name: "my-tool", Plugins: []string{"plugin1", "plugin2"}},
+		},
+		{
+			name:       "attribute without a registered type",
+			registered: map[string]interface{}{"tool.io/extension": toolExtension{}},
+			key:        "tool.io/missing",
+			out:        &toolExtension{},
+			wantErr:    &notRegisteredErr,
+		},
+		{
+			name:       "registered attribute absent from the devfile",
+			registered: map[string]interface{}{"tool.io/absent": toolExtension{}},
+			key:        "tool.io/absent",
+			out:        &toolExtension{},
+			wantErr:    &notFoundErr,
+		},
+		{
+			name:       "out with a different type than the registered one",
+			registered: map[string]interface{}{"tool.io/extension": toolExtension{}},
+			key:        "tool.io/extension",
+			out:        new(string),
+			wantErr:    &wrongTypeErr,
+		},
+		{
+			name:          "attribute that cannot be decoded into the registered type",
+			registered:    map[string]interface{}{"tool.io/invalid": toolExtension{}},
+			wantDecodeErr: &decodeErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DevfileCtx{}
+			for key, proto := range tt.registered {
+				d.RegisterAttributeType(key, proto)
+			}
+
+			err := d.SetTypedAttributes(extensionAttributes)
+			if (err != nil) != (tt.wantDecodeErr != nil) {
+				t.Errorf("TestTypedAttributes() unexpected decode error: %v, wantErr %v", err, tt.wantDecodeErr)
+				return
+			} else if err != nil {
+				assert.Regexp(t, *tt.wantDecodeErr, err.Error(), "TestTypedAttributes(): Error message should match")
+				return
+			}
+
+			err = d.GetTypedAttribute(tt.key, tt.out)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestTypedAttributes() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantOut, tt.out, "TestTypedAttributes(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestTypedAttributes(): Error message should match")
+			}
+		})
+	}
+}
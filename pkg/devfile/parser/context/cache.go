@@ -0,0 +1,144 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// DefaultContentCacheSize is the default maximum size in bytes of the content held by a ContentCache
+const DefaultContentCacheSize int64 = 64 * 1024 * 1024
+
+// ContentCache caches the content downloaded from the remote URLs, so the content of a URL referenced by
+// several devfiles is only downloaded once. The least recently used content is evicted once the size of the
+// cached content exceeds the maximum size of the cache. It is safe for concurrent use.
+type ContentCache struct {
+	mutex   sync.Mutex
+	maxSize int64
+	size    int64
+	// entries holds the cached contents from the most to the least recently used
+	entries *list.List
+	content map[string]*list.Element
+}
+
+// contentCacheEntry is the content cached for a URL
+type contentCacheEntry struct {
+	url     string
+	content []byte
+}
+
+// NewContentCache returns a new empty ContentCache holding at most DefaultContentCacheSize bytes
+func NewContentCache() *ContentCache {
+	return NewContentCacheWithMaxSize(DefaultContentCacheSize)
+}
+
+// NewContentCacheWithMaxSize returns a new empty ContentCache holding at most maxSize bytes
+func NewContentCacheWithMaxSize(maxSize int64) *ContentCache {
+	return &ContentCache{
+		maxSize: maxSize,
+		entries: list.New(),
+		content: make(map[string]*list.Element),
+	}
+}
+
+// Get returns a copy of the content cached for the URL, the cache may be shared by concurrent parses
+func (c *ContentCache) Get(url string) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.content[url]
+	if !ok {
+		return nil, false
+	}
+	c.entries.MoveToFront(element)
+	return copyContent(element.Value.(*contentCacheEntry).content), true
+}
+
+// Add caches a copy of the content of the URL, the content is not cached if it is larger than the maximum size of the cache
+func (c *ContentCache) Add(url string, content []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.content[url]; ok {
+		c.removeElement(element)
+	}
+	if int64(len(content)) > c.maxSize {
+		return
+	}
+	c.content[url] = c.entries.PushFront(&contentCacheEntry{url: url, content: copyContent(content)})
+	c.size += int64(len(content))
+	for c.size > c.maxSize {
+		c.removeElement(c.entries.Back())
+	}
+}
+
+// removeElement removes the cached content of the element
+func (c *ContentCache) removeElement(element *list.Element) {
+	entry := c.entries.Remove(element).(*contentCacheEntry)
+	delete(c.content, entry.url)
+	c.size -= int64(len(entry.content))
+}
+
+// copyContent returns a copy of the content, the cached content is never shared with the callers of the cache
+func copyContent(content []byte) []byte {
+	contentCopy := make([]byte, len(content))
+	copy(contentCopy, content)
+	return contentCopy
+}
+
+// SchemaCache caches the devfile json schemas compiled by apiVersion, so the schema of an apiVersion is only
+// compiled once. It is safe for concurrent use.
+type SchemaCache struct {
+	mutex   sync.Mutex
+	schemas map[string]cachedSchema
+}
+
+// cachedSchema is a compiled json schema and the json schema it is compiled from
+type cachedSchema struct {
+	jsonSchema string
+	schema     *gojsonschema.Schema
+}
+
+// defaultSchemaCache is the cache of the compiled json schemas used by the devfile contexts without a SchemaCache
+var defaultSchemaCache = NewSchemaCache()
+
+// NewSchemaCache returns a new empty SchemaCache
+func NewSchemaCache() *SchemaCache {
+	return &SchemaCache{
+		schemas: make(map[string]cachedSchema),
+	}
+}
+
+// getSchema returns the compiled json schema of the apiVersion, the json schema is compiled if it is not cached yet
+// or if the cached schema of the apiVersion is compiled from another json schema
+func (c *SchemaCache) getSchema(apiVersion string, jsonSchema string) (*gojsonschema.Schema, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if cached, ok := c.schemas[apiVersion]; ok && cached.jsonSchema == jsonSchema {
+		return cached.schema, nil
+	}
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(jsonSchema))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile devfile schema for apiVersion %s", apiVersion)
+	}
+	c.schemas[apiVersion] = cachedSchema{
+		jsonSchema: jsonSchema,
+		schema:     schema,
+	}
+	return schema, nil
+}
@@ -0,0 +1,199 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	v200 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2/2.0.0"
+	v220 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2/2.2.0"
+	"github.com/devfile/library/v2/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentCache(t *testing.T) {
+
+	t.Run("least recently used content is evicted", func(t *testing.T) {
+		cache := NewContentCacheWithMaxSize(10)
+		cache.Add("a", []byte("aaaa"))
+		cache.Add("b", []byte("bbbb"))
+		_, ok := cache.Get("a")
+		assert.True(t, ok, "TestContentCache(): a should be cached")
+		cache.Add("c", []byte("cccc"))
+
+		_, ok = cache.Get("b")
+		assert.False(t, ok, "TestContentCache(): b should be evicted")
+		for _, url := range []string{"a", "c"} {
+			_, ok = cache.Get(url)
+			assert.True(t, ok, "TestContentCache(): %s should be cached", url)
+		}
+		assert.Equal(t, int64(8), cache.size, "TestContentCache(): The two values should be the same.")
+	})
+
+	t.Run("content larger than the cache is not cached", func(t *testing.T) {
+		cache := NewContentCacheWithMaxSize(10)
+		cache.Add("a", []byte("aaaa"))
+		cache.Add("a", []byte("aaaaaaaaaaaa"))
+		_, ok := cache.Get("a")
+		assert.False(t, ok, "TestContentCache(): a should not be cached")
+		assert.Equal(t, int64(0), cache.size, "TestContentCache(): The two values should be the same.")
+	})
+
+	t.Run("cached content is not altered by the callers", func(t *testing.T) {
+		cache := NewContentCache()
+		content := []byte("aaaa")
+		cache.Add("a", content)
+		content[0] = 'x'
+		cached, _ := cache.Get("a")
+		cached[1] = 'x'
+		cached, ok := cache.Get("a")
+		assert.True(t, ok, "TestContentCache(): a should be cached")
+		assert.Equal(t, []byte("aaaa"), cached, "TestContentCache(): The content should not be altered.")
+	})
+
+	t.Run("cached content larger than the maximum download size", func(t *testing.T) {
+		requests := 0
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if _, err := w.Write([]byte("aaaaaaaaaaaa")); err != nil {
+				t.Error(err)
+			}
+		}))
+		defer testServer.Close()
+
+		cache := NewContentCache()
+		d := DevfileCtx{contentCache: cache}
+		_, err := d.DownloadInMemory(util.HTTPRequestParams{URL: testServer.URL})
+		assert.NoError(t, err, "TestContentCache(): unexpected error")
+
+		d = DevfileCtx{contentCache: cache}
+		d.SetMaxDownloadSize(10)
+		_, err = d.DownloadInMemory(util.HTTPRequestParams{URL: testServer.URL})
+		if err == nil {
+			t.Fatalf("TestContentCache(): expected an error, didn't get one")
+		}
+		assert.Equal(t, fmt.Sprintf("failed to retrieve %s, the content exceeds the maximum size of 10 bytes", testServer.URL), err.Error(), "TestContentCache(): Error message should match")
+		assert.Equal(t, 1, requests, "TestContentCache(): The content should be read from the cache.")
+	})
+
+	t.Run("authenticated downloads are not cached", func(t *testing.T) {
+		requests := 0
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			_, err := w.Write([]byte(r.Header.Get("Private-Token")))
+			if err != nil {
+				t.Error(err)
+			}
+		}))
+		defer testServer.Close()
+
+		d := DevfileCtx{contentCache: NewContentCache()}
+		for _, params := range []util.HTTPRequestParams{
+			{URL: testServer.URL, Token: "token"},
+			{URL: testServer.URL, Username: "user", Password: "password"},
+			{URL: testServer.URL, Headers: map[string]string{"Private-Token": "secret"}},
+		} {
+			_, err := d.DownloadInMemory(params)
+			assert.NoError(t, err, "TestContentCache(): unexpected error")
+		}
+		data, err := d.DownloadInMemory(util.HTTPRequestParams{URL: testServer.URL})
+		assert.NoError(t, err, "TestContentCache(): unexpected error")
+		assert.Empty(t, data, "TestContentCache(): the authenticated content should not be served")
+		_, err = d.DownloadInMemory(util.HTTPRequestParams{URL: testServer.URL})
+		assert.NoError(t, err, "TestContentCache(): unexpected error")
+		assert.Equal(t, 4, requests, "TestContentCache(): The two values should be the same.")
+	})
+}
+
+func TestSchemaCache(t *testing.T) {
+
+	t.Run("validation results are unchanged by the cache", func(t *testing.T) {
+		cache := NewSchemaCache()
+		for i := 0; i < 2; i++ {
+			valid := DevfileCtx{apiVersion: "2.0.0", jsonSchema: v200.JsonSchema200, rawContent: validJsonRawContent200(), schemaCache: cache}
+			assert.NoError(t, valid.ValidateDevfileSchema(), "TestSchemaCache(): a valid devfile should be valid")
+
+			invalid := DevfileCtx{apiVersion: "2.0.0", jsonSchema: v200.JsonSchema200, rawContent: []byte("{}"), schemaCache: cache}
+			err := invalid.ValidateDevfileSchema()
+			if assert.Error(t, err, "TestSchemaCache(): an invalid devfile should be invalid") {
+				assert.Contains(t, err.Error(), "schemaVersion is required", "TestSchemaCache(): Error message should match")
+			}
+		}
+		assert.Len(t, cache.schemas, 1, "TestSchemaCache(): the schema should be compiled once")
+	})
+
+	t.Run("default schema cache", func(t *testing.T) {
+		d := DevfileCtx{apiVersion: "2.0.0", jsonSchema: v200.JsonSchema200, rawContent: validJsonRawContent200()}
+		assert.NoError(t, d.ValidateDevfileSchema(), "TestSchemaCache(): a valid devfile should be valid")
+
+		defaultSchemaCache.mutex.Lock()
+		defer defaultSchemaCache.mutex.Unlock()
+		assert.Contains(t, defaultSchemaCache.schemas, "2.0.0", "TestSchemaCache(): the schema should be cached in the default schema cache")
+	})
+
+	t.Run("schema changed for an apiVersion", func(t *testing.T) {
+		cache := NewSchemaCache()
+		schema200, err := cache.getSchema("", v200.JsonSchema200)
+		assert.NoError(t, err, "TestSchemaCache(): unexpected error")
+		schema220, err := cache.getSchema("", v220.JsonSchema220)
+		assert.NoError(t, err, "TestSchemaCache(): unexpected error")
+		assert.NotSame(t, schema200, schema220, "TestSchemaCache(): a schema should not be reused for another json schema")
+	})
+
+	t.Run("concurrent validations", func(t *testing.T) {
+		cache := NewSchemaCache()
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				d := DevfileCtx{apiVersion: "2.0.0", jsonSchema: v200.JsonSchema200, rawContent: validJsonRawContent200(), schemaCache: cache}
+				assert.NoError(t, d.ValidateDevfileSchema(), "TestSchemaCache(): a valid devfile should be valid")
+			}()
+		}
+		wg.Wait()
+		assert.Len(t, cache.schemas, 1, "TestSchemaCache(): the schema should be compiled once")
+	})
+}
+
+// BenchmarkValidateDevfileSchema compares the validation of a devfile with a cold schema cache, which compiles
+// the schema on every validation, and with a warm schema cache
+func BenchmarkValidateDevfileSchema(b *testing.B) {
+	rawContent := validJsonRawContent200()
+
+	b.Run("cold", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			d := DevfileCtx{apiVersion: "2.0.0", jsonSchema: v200.JsonSchema200, rawContent: rawContent, schemaCache: NewSchemaCache()}
+			if err := d.ValidateDevfileSchema(); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+
+	b.Run("warm", func(b *testing.B) {
+		cache := NewSchemaCache()
+		for i := 0; i < b.N; i++ {
+			d := DevfileCtx{apiVersion: "2.0.0", jsonSchema: v200.JsonSchema200, rawContent: rawContent, schemaCache: cache}
+			if err := d.ValidateDevfileSchema(); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+}
@@ -67,9 +67,13 @@ func (d *DevfileCtx) SetDevfileContent() error {
 	var err error
 	var data []byte
 	if d.url != "" {
+		devfileURL, err := d.RewriteURL(d.url)
+		if err != nil {
+			return err
+		}
 		// set the client identifier for telemetry
-		params := util.HTTPRequestParams{URL: d.url, TelemetryClientName: util.TelemetryClientName}
-		data, err = util.DownloadInMemory(params)
+		params := util.HTTPRequestParams{URL: devfileURL, TelemetryClientName: util.TelemetryClientName, Headers: d.httpHeaders}
+		data, err = d.DownloadInMemory(params)
 		if err != nil {
 			return errors.Wrap(err, "error getting devfile info from url")
 		}
@@ -90,6 +94,7 @@ func (d *DevfileCtx) SetDevfileContent() error {
 func (d *DevfileCtx) SetDevfileContentFromBytes(data []byte) error {
 	// If YAML file convert it to JSON
 	var err error
+	d.sourceContent = data
 	d.rawContent, err = YAMLToJSON(data)
 	if err != nil {
 		return err
@@ -99,7 +104,24 @@ func (d *DevfileCtx) SetDevfileContentFromBytes(data []byte) error {
 	return nil
 }
 
-// GetDevfileContent returns the devfile content
+// GetDevfileContent returns a copy of the devfile content, converted to JSON if the devfile is in YAML format.
+// It returns nil if the devfile content is not set yet.
 func (d *DevfileCtx) GetDevfileContent() []byte {
-	return d.rawContent
+	if d.rawContent == nil {
+		return nil
+	}
+	content := make([]byte, len(d.rawContent))
+	copy(content, d.rawContent)
+	return content
+}
+
+// GetDevfileContentSize returns the size in bytes of the devfile content returned by GetDevfileContent, without copying it
+func (d *DevfileCtx) GetDevfileContentSize() int {
+	return len(d.rawContent)
+}
+
+// GetDevfileSourceContent returns the devfile content as provided, before its conversion to JSON. For a devfile 1.x
+// converted to devfile 2.x, it is the devfile 1.x content, GetDevfileContent returns the converted content.
+func (d *DevfileCtx) GetDevfileSourceContent() []byte {
+	return d.sourceContent
 }
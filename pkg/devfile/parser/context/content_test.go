@@ -16,10 +16,13 @@
 package parser
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/devfile/library/v2/pkg/testingutil/filesystem"
+	"github.com/stretchr/testify/assert"
 )
 
 const (
@@ -190,3 +193,53 @@ func TestSetDevfileContentFromBytes(t *testing.T) {
 		}
 	})
 }
+
+func TestGetDevfileContent(t *testing.T) {
+
+	t.Run("content not set", func(t *testing.T) {
+		d := DevfileCtx{}
+		assert.Nil(t, d.GetDevfileContent(), "TestGetDevfileContent(): The content should be nil.")
+		assert.Equal(t, 0, d.GetDevfileContentSize(), "TestGetDevfileContent(): The two values should be the same.")
+	})
+
+	t.Run("content read from a file", func(t *testing.T) {
+		fakeFs := filesystem.NewFakeFs()
+		tempDevfile, err := fakeFs.TempFile(os.TempDir(), TempJSONDevfilePrefix)
+		if err != nil {
+			t.Fatalf("failed to create temp devfile, %v", err)
+		}
+		defer os.Remove(tempDevfile.Name())
+		if _, err := tempDevfile.Write(validJsonRawContent200()); err != nil {
+			t.Fatalf("failed to write to temp devfile")
+		}
+
+		d := DevfileCtx{
+			absPath: tempDevfile.Name(),
+			fs:      fakeFs,
+		}
+		if err := d.SetDevfileContent(); err != nil {
+			t.Fatalf("unexpected error '%v'", err)
+		}
+		assert.Equal(t, validJsonRawContent200(), d.GetDevfileContent(), "TestGetDevfileContent(): The two values should be the same.")
+		assert.Equal(t, len(validJsonRawContent200()), d.GetDevfileContentSize(), "TestGetDevfileContent(): The two values should be the same.")
+
+		// the returned content is a copy of the devfile content
+		d.GetDevfileContent()[0] = 'x'
+		assert.Equal(t, validJsonRawContent200(), d.GetDevfileContent(), "TestGetDevfileContent(): The content should not be altered.")
+	})
+
+	t.Run("content read from a URL", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := w.Write(validJsonRawContent200()); err != nil {
+				t.Error(err)
+			}
+		}))
+		defer testServer.Close()
+
+		d := NewURLDevfileCtx(testServer.URL)
+		if err := d.SetDevfileContent(); err != nil {
+			t.Fatalf("unexpected error '%v'", err)
+		}
+		assert.Equal(t, validJsonRawContent200(), d.GetDevfileContent(), "TestGetDevfileContent(): The two values should be the same.")
+	})
+}
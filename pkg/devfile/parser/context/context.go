@@ -16,17 +16,26 @@
 package parser
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
-	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/library/v2/pkg/testingutil/filesystem"
 	"github.com/devfile/library/v2/pkg/util"
+	"github.com/pkg/errors"
 	"k8s.io/klog"
 )
 
+// DefaultMaxDownloadSize is the maximum size in bytes of the devfile and of each resource it references downloaded from
+// a URL if no maximum size is set
+const DefaultMaxDownloadSize int64 = 10 * 1024 * 1024
+
 // DevfileCtx stores context info regarding devfile
 type DevfileCtx struct {
 
@@ -39,12 +48,24 @@ type DevfileCtx struct {
 	// relative path of devfile
 	relPath string
 
+	// name of the devfile content held in memory, only used to identify the devfile in the error messages
+	name string
+
 	// raw content of the devfile
 	rawContent []byte
 
+	// content of the devfile as provided, before its conversion to JSON
+	sourceContent []byte
+
 	// devfile json schema
 	jsonSchema string
 
+	// devfile json schema has been set by the consumer instead of being derived from the apiVersion
+	customJSONSchema bool
+
+	// base URI which the relative $refs of a custom devfile json schema are resolved against
+	jsonSchemaBaseURI string
+
 	//url path of the devfile
 	url string
 
@@ -53,8 +74,59 @@ type DevfileCtx struct {
 
 	// devfile kubernetes components has been coverted from uri to inlined in memory
 	convertUriToInlined bool
+
+	// Go types registered for devfile top-level attribute keys
+	attributeTypes map[string]reflect.Type
+
+	// devfile top-level attributes decoded into their registered Go types
+	typedAttributes map[string]interface{}
+
+	// names of the env vars whose values are redacted in diagnostic messages
+	redactedEnvNames []string
+
+	// rewrites the remote URLs before they are fetched
+	urlRewriter URLRewriter
+
+	// warnings raised while parsing the devfile
+	warnings []string
+
+	// YAML anchors and aliases of the devfile source are preserved when the devfile is written
+	preserveAnchors bool
+
+	// legacy devfile 1.x is converted to devfile 2.x when it is populated
+	convertV1 bool
+
+	// parents merged in order after the parent of the devfile when it is flattened
+	additionalParents []v1.Parent
+
+	// shell of the exec commands which do not set one, nil if it is not set
+	defaultShell *string
+
+	// caches the content downloaded from the remote URLs
+	contentCache *ContentCache
+
+	// caches the compiled devfile json schemas
+	schemaCache *SchemaCache
+
+	// http client downloading the devfile and the resources it references from URLs
+	httpClient *http.Client
+
+	// retry policy of the downloads of the devfile and the resources it references from URLs
+	retryPolicy RetryPolicy
+
+	// headers of the download of the devfile from its URL, e.g. the Authorization header of a private endpoint
+	httpHeaders map[string]string
+
+	// maximum size in bytes of each download, DefaultMaxDownloadSize is used if it is zero and a negative value disables the limit
+	maxDownloadSize int64
+
+	// context of the parse, the downloads of the devfile and the resources it references are aborted when it is done
+	ctx context.Context
 }
 
+// URLRewriter rewrites a remote URL into the URL it is fetched from, e.g. the path of an internal mirror
+type URLRewriter func(url string) (string, error)
+
 // NewDevfileCtx returns a new DevfileCtx type object
 func NewDevfileCtx(path string) DevfileCtx {
 	return DevfileCtx{
@@ -79,6 +151,20 @@ func NewByteContentDevfileCtx(data []byte) (d DevfileCtx, err error) {
 	return d, nil
 }
 
+// NewRawDevfileCtx sets the devfile content held in memory and returns a new DevfileCtx type object, the devfile
+// is not read from the filesystem or a URL. name is optional, it identifies the devfile in the error messages.
+func NewRawDevfileCtx(data []byte, name string) (DevfileCtx, error) {
+	d, err := NewByteContentDevfileCtx(data)
+	if err != nil {
+		if name != "" {
+			return DevfileCtx{}, errors.Wrapf(err, "failed to set the content of the devfile %s", name)
+		}
+		return DevfileCtx{}, err
+	}
+	d.name = name
+	return d, nil
+}
+
 // populateDevfile checks the API version is supported and returns the JSON schema for the given devfile API Version
 func (d *DevfileCtx) populateDevfile() (err error) {
 
@@ -87,21 +173,43 @@ func (d *DevfileCtx) populateDevfile() (err error) {
 		return err
 	}
 
+	// Convert legacy devfile 1.x content to devfile 2.x
+	if d.IsV1() {
+		if !d.convertV1 {
+			return fmt.Errorf("devfile apiVersion %s is not supported, devfile 1.x must be converted to devfile 2.x", d.apiVersion)
+		}
+		devfile, err := d.ConvertV1ToV2()
+		if err != nil {
+			return err
+		}
+		if d.rawContent, err = json.Marshal(devfile); err != nil {
+			return errors.Wrapf(err, "failed to encode converted devfile 1.x content")
+		}
+		d.apiVersion = ConvertedSchemaVersion
+	}
+
 	// Read and save devfile JSON schema for provided apiVersion
 	return d.SetDevfileJSONSchema()
 }
 
-// Populate fills the DevfileCtx struct with relevant context info
+// devfileFileNames are the names of the devfile looked up in order when the devfile path is a directory
+var devfileFileNames = []string{"devfile.yaml", ".devfile.yaml", "devfile.yml", ".devfile.yml"}
+
+// Populate fills the DevfileCtx struct with relevant context info. If the devfile path does not end with
+// .yaml or .yml, it is a directory and the devfile is the first of devfile.yaml, .devfile.yaml, devfile.yml
+// and .devfile.yml found in it. The devfile is looked up and read on the filesystem of the context.
 func (d *DevfileCtx) Populate() (err error) {
-	if !strings.HasSuffix(d.relPath, ".yaml") {
-		if _, err := os.Stat(filepath.Join(d.relPath, "devfile.yaml")); os.IsNotExist(err) {
-			if _, err := os.Stat(filepath.Join(d.relPath, ".devfile.yaml")); os.IsNotExist(err) {
-				return fmt.Errorf("the provided path is not a valid yaml filepath, and devfile.yaml or .devfile.yaml not found in the provided path : %s", d.relPath)
-			} else {
-				d.relPath = filepath.Join(d.relPath, ".devfile.yaml")
+	if !strings.HasSuffix(d.relPath, ".yaml") && !strings.HasSuffix(d.relPath, ".yml") {
+		found := false
+		for _, fileName := range devfileFileNames {
+			if _, err := d.GetFs().Stat(filepath.Join(d.relPath, fileName)); err == nil {
+				d.relPath = filepath.Join(d.relPath, fileName)
+				found = true
+				break
 			}
-		} else {
-			d.relPath = filepath.Join(d.relPath, "devfile.yaml")
+		}
+		if !found {
+			return fmt.Errorf("the provided path is not a valid yaml filepath, and %s not found in the provided path : %s", strings.Join(devfileFileNames, ", "), d.relPath)
 		}
 	}
 	if err := d.SetAbsPath(); err != nil {
@@ -115,7 +223,8 @@ func (d *DevfileCtx) Populate() (err error) {
 	return d.populateDevfile()
 }
 
-// PopulateFromURL fills the DevfileCtx struct with relevant context info
+// PopulateFromURL fills the DevfileCtx struct with relevant context info. The devfile URL is downloaded as is,
+// whatever its extension.
 func (d *DevfileCtx) PopulateFromURL() (err error) {
 	_, err = url.ParseRequestURI(d.url)
 	if err != nil {
@@ -133,6 +242,27 @@ func (d *DevfileCtx) PopulateFromRaw() (err error) {
 	return d.populateDevfile()
 }
 
+// PopulateWithContext fills the DevfileCtx struct with relevant context info like PopulateFromURL, PopulateFromRaw
+// or Populate, depending on whether the devfile is a URL, a content held in memory or a path. The downloads of the
+// devfile and of the resources it references are aborted when the context is done.
+func (d *DevfileCtx) PopulateWithContext(ctx context.Context) error {
+	d.ctx = ctx
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d.url != "" {
+		return d.PopulateFromURL()
+	} else if d.rawContent != nil {
+		return d.PopulateFromRaw()
+	}
+	return d.Populate()
+}
+
+// GetContext returns the context of the parse, it is nil if the devfile context is not populated with a context
+func (d *DevfileCtx) GetContext() context.Context {
+	return d.ctx
+}
+
 // Validate func validates devfile JSON schema for the given apiVersion
 func (d *DevfileCtx) Validate() error {
 
@@ -150,6 +280,11 @@ func (d *DevfileCtx) GetURL() string {
 	return d.url
 }
 
+// GetName func returns the name of the devfile content held in memory
+func (d *DevfileCtx) GetName() string {
+	return d.name
+}
+
 // SetAbsPath sets absolute file path for devfile
 func (d *DevfileCtx) SetAbsPath() (err error) {
 	// Set devfile absolute path
@@ -162,6 +297,160 @@ func (d *DevfileCtx) SetAbsPath() (err error) {
 
 }
 
+// SetURLRewriter sets the function which rewrites the remote URLs referenced by the devfile before they are fetched
+func (d *DevfileCtx) SetURLRewriter(rewriter URLRewriter) {
+	d.urlRewriter = rewriter
+}
+
+// RewriteURL returns the URL the remote URL is fetched from, the URL is returned as is if no URL rewriter is set
+func (d *DevfileCtx) RewriteURL(url string) (string, error) {
+	return d.urlRewriter.Rewrite(url)
+}
+
+// Rewrite returns the URL the remote URL is fetched from, the URL is returned as is if the rewriter is nil
+func (r URLRewriter) Rewrite(url string) (string, error) {
+	if r == nil {
+		return url, nil
+	}
+	rewrittenURL, err := r(url)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to rewrite url %s", url)
+	}
+	klog.V(4).Infof("rewrote url '%s' to '%s'", url, rewrittenURL)
+	return rewrittenURL, nil
+}
+
+// GetContentCache returns the cache of the content downloaded from the remote URLs, nil if the content is not cached
+func (d *DevfileCtx) GetContentCache() *ContentCache {
+	return d.contentCache
+}
+
+// SetContentCache sets the cache of the content downloaded from the remote URLs
+func (d *DevfileCtx) SetContentCache(cache *ContentCache) {
+	d.contentCache = cache
+}
+
+// SetSchemaCache sets the cache of the compiled devfile json schemas
+func (d *DevfileCtx) SetSchemaCache(cache *SchemaCache) {
+	d.schemaCache = cache
+}
+
+// SetHTTPClient sets the http client downloading the devfile and the kubernetes resources and parents it references
+// from URLs, e.g. a client with a proxy and custom TLS roots. A default client is used if it is not set.
+func (d *DevfileCtx) SetHTTPClient(client *http.Client) {
+	d.httpClient = client
+}
+
+// SetHTTPHeaders sets the headers sent with the download of the devfile from its URL, e.g. an Authorization header
+// to fetch the devfile from a private endpoint. The headers are not sent with the downloads of the resources and
+// parents the devfile references, and the headers are dropped when the download is redirected to another host.
+func (d *DevfileCtx) SetHTTPHeaders(headers map[string]string) {
+	d.httpHeaders = headers
+}
+
+// SetMaxDownloadSize sets the maximum size in bytes of the devfile and of each resource it references downloaded from
+// a URL, the download is aborted once the limit is exceeded. DefaultMaxDownloadSize is used if it is not set, a negative
+// value disables the limit.
+func (d *DevfileCtx) SetMaxDownloadSize(size int64) {
+	d.maxDownloadSize = size
+}
+
+// SetRetryPolicy sets how the downloads of the devfile and the kubernetes resources and parents it references from URLs
+// are retried when they fail with a transient error. The downloads are not retried by default.
+func (d *DevfileCtx) SetRetryPolicy(policy RetryPolicy) {
+	d.retryPolicy = policy
+}
+
+// DownloadInMemory downloads the content of the URL of the params with the http client and the retry policy if they are set,
+// the content is read from and added to the content cache if it is set. The content of an authenticated download is never
+// cached, the cache may be shared with callers without the credentials.
+func (d *DevfileCtx) DownloadInMemory(params util.HTTPRequestParams) ([]byte, error) {
+	if d.httpClient != nil {
+		params.HTTPClient = d.httpClient
+	}
+	if params.Context == nil {
+		params.Context = d.ctx
+	}
+	if params.MaxBytes == 0 {
+		params.MaxBytes = d.maxDownloadSize
+		if params.MaxBytes == 0 {
+			params.MaxBytes = DefaultMaxDownloadSize
+		}
+	}
+	useCache := d.contentCache != nil && !isAuthenticatedRequest(params)
+	if useCache {
+		if data, ok := d.contentCache.Get(params.URL); ok {
+			klog.V(4).Infof("read content of url '%s' from the cache", params.URL)
+			// the content may have been cached by a download with a larger limit
+			if params.MaxBytes > 0 && int64(len(data)) > params.MaxBytes {
+				return nil, &util.ContentTooLargeError{URL: params.URL, MaxBytes: params.MaxBytes}
+			}
+			return data, nil
+		}
+	}
+	data, err := d.retryPolicy.download(params.Context, params.URL, func() ([]byte, error) {
+		return util.DownloadInMemory(params)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if useCache {
+		d.contentCache.Add(params.URL, data)
+	}
+	return data, nil
+}
+
+// isAuthenticatedRequest checks if the request carries credentials or caller-supplied headers
+func isAuthenticatedRequest(params util.HTTPRequestParams) bool {
+	return params.Token != "" || params.Username != "" || params.Password != "" || len(params.Headers) > 0
+}
+
+// GetWarnings returns the warnings raised while parsing the devfile, e.g. an optional parent which failed to be resolved
+func (d *DevfileCtx) GetWarnings() []string {
+	return d.warnings
+}
+
+// SetWarnings sets the warnings raised while parsing the devfile
+func (d *DevfileCtx) SetWarnings(warnings []string) {
+	d.warnings = warnings
+}
+
+// GetPreserveAnchors func returns if the YAML anchors and aliases of the devfile source are preserved when the devfile is written
+func (d *DevfileCtx) GetPreserveAnchors() bool {
+	return d.preserveAnchors
+}
+
+// SetPreserveAnchors sets if the YAML anchors and aliases of the devfile source are preserved when the devfile is written,
+// instead of being expanded
+func (d *DevfileCtx) SetPreserveAnchors(value bool) {
+	d.preserveAnchors = value
+}
+
+// GetAdditionalParents func returns the parents merged in order after the parent of the devfile when it is flattened
+func (d *DevfileCtx) GetAdditionalParents() []v1.Parent {
+	return d.additionalParents
+}
+
+// SetAdditionalParents sets the parents merged in order after the parent of the devfile when it is flattened,
+// the elements of a parent override the elements of the previous parents with the same name
+func (d *DevfileCtx) SetAdditionalParents(parents []v1.Parent) {
+	d.additionalParents = parents
+}
+
+// GetDefaultShell returns the shell of the exec commands which do not set one, and whether it is set
+func (d *DevfileCtx) GetDefaultShell() (string, bool) {
+	if d.defaultShell == nil {
+		return "", false
+	}
+	return *d.defaultShell, true
+}
+
+// SetDefaultShell sets the shell of the exec commands which do not set one, an empty shell means the command lines
+// are run directly. The devfile is not modified.
+func (d *DevfileCtx) SetDefaultShell(shell string) {
+	d.defaultShell = &shell
+}
+
 // GetConvertUriToInlined func returns if the devfile kubernetes comp has been converted from uri to inlined
 func (d *DevfileCtx) GetConvertUriToInlined() bool {
 	return d.convertUriToInlined
@@ -16,10 +16,16 @@
 package parser
 
 import (
+	"bytes"
+	"context"
+	"github.com/devfile/library/v2/pkg/testingutil/filesystem"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestPopulateFromBytes(t *testing.T) {
@@ -83,6 +89,224 @@ func TestPopulateFromInvalidURL(t *testing.T) {
 	})
 }
 
+// roundTripperFunc stubs the transport of an http client
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestPopulateFromURLWithHTTPClient(t *testing.T) {
+	const devfileURL = "https://registry.example.com/devfiles/nodejs/devfile.yaml"
+
+	var requestedURLs []string
+	client := &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requestedURLs = append(requestedURLs, req.URL.String())
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(validJsonRawContent200())),
+				Header:     make(http.Header),
+				Request:    req,
+			}, nil
+		}),
+	}
+
+	d := NewURLDevfileCtx(devfileURL)
+	d.SetHTTPClient(client)
+	if err := d.PopulateFromURL(); err != nil {
+		t.Fatalf("TestPopulateFromURLWithHTTPClient(): unexpected error: %v", err)
+	}
+	assert.Equal(t, []string{devfileURL}, requestedURLs, "TestPopulateFromURLWithHTTPClient(): The devfile should be downloaded with the http client.")
+	assert.Equal(t, validJsonRawContent200(), d.GetDevfileContent(), "TestPopulateFromURLWithHTTPClient(): The two values should be the same.")
+}
+
+func TestPopulateFromURLWithHTTPHeaders(t *testing.T) {
+	var authorization, apiKey string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		apiKey = r.Header.Get("X-Api-Key")
+		_, err := w.Write(validJsonRawContent200())
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer testServer.Close()
+
+	d := NewURLDevfileCtx(testServer.URL)
+	d.SetHTTPHeaders(map[string]string{"Authorization": "Bearer my-token", "X-Api-Key": "my-key"})
+	if err := d.PopulateFromURL(); err != nil {
+		t.Fatalf("TestPopulateFromURLWithHTTPHeaders(): unexpected error: %v", err)
+	}
+	assert.Equal(t, "Bearer my-token", authorization, "TestPopulateFromURLWithHTTPHeaders(): The Authorization header should be sent.")
+	assert.Equal(t, "my-key", apiKey, "TestPopulateFromURLWithHTTPHeaders(): The X-Api-Key header should be sent.")
+}
+
+func TestPopulateFromURLMaxDownloadSize(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write(validJsonRawContent200())
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer testServer.Close()
+
+	d := NewURLDevfileCtx(testServer.URL)
+	d.SetMaxDownloadSize(10)
+	err := d.PopulateFromURL()
+	if err == nil {
+		t.Fatalf("TestPopulateFromURLMaxDownloadSize(): expected an error, didn't get one")
+	}
+	assert.Contains(t, err.Error(), "the content exceeds the maximum size of 10 bytes", "TestPopulateFromURLMaxDownloadSize(): Error message should match")
+
+	d = NewURLDevfileCtx(testServer.URL)
+	d.SetMaxDownloadSize(-1)
+	if err := d.PopulateFromURL(); err != nil {
+		t.Fatalf("TestPopulateFromURLMaxDownloadSize(): unexpected error: %v", err)
+	}
+}
+
+func TestPopulateWithContext(t *testing.T) {
+	release := make(chan struct{})
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// hold the download until the test is done
+		<-release
+	}))
+	defer testServer.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	d := NewURLDevfileCtx(testServer.URL)
+	d.SetRetryPolicy(RetryPolicy{Attempts: 3, BaseDelay: time.Second})
+	start := time.Now()
+	err := d.PopulateWithContext(ctx)
+	if err == nil {
+		t.Fatalf("TestPopulateWithContext(): expected an error, didn't get one")
+	}
+	assert.Contains(t, err.Error(), context.Canceled.Error(), "TestPopulateWithContext(): Error message should match")
+	assert.Less(t, int64(time.Since(start)), int64(time.Second), "TestPopulateWithContext(): The download should be aborted when the context is cancelled.")
+}
+
+func TestPopulate(t *testing.T) {
+	notFoundErr := "the provided path is not a valid yaml filepath, and devfile.yaml, .devfile.yaml, devfile.yml, .devfile.yml not found in the provided path"
+
+	tests := []struct {
+		name        string
+		fileNames   []string
+		path        string
+		wantPath    string
+		expectError *string
+	}{
+		{
+			name:      "directory with devfile.yaml",
+			fileNames: []string{"devfile.yaml"},
+			wantPath:  "devfile.yaml",
+		},
+		{
+			name:      "directory with only devfile.yml",
+			fileNames: []string{"devfile.yml"},
+			wantPath:  "devfile.yml",
+		},
+		{
+			name:      "directory with only .devfile.yml",
+			fileNames: []string{".devfile.yml"},
+			wantPath:  ".devfile.yml",
+		},
+		{
+			name:      ".yaml devfiles are looked up before .yml devfiles",
+			fileNames: []string{"devfile.yml", ".devfile.yaml"},
+			wantPath:  ".devfile.yaml",
+		},
+		{
+			name:      "devfile path with .yml extension",
+			fileNames: []string{"foo.yml"},
+			path:      "foo.yml",
+			wantPath:  "foo.yml",
+		},
+		{
+			name:        "directory without devfile",
+			fileNames:   []string{"devfile.json"},
+			expectError: &notFoundErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, fileName := range tt.fileNames {
+				if err := ioutil.WriteFile(filepath.Join(dir, fileName), validJsonRawContent200(), 0644); err != nil {
+					t.Fatalf("TestPopulate(): unexpected error: %v", err)
+				}
+			}
+
+			d := NewDevfileCtx(filepath.Join(dir, tt.path))
+			err := d.Populate()
+			if (tt.expectError != nil) != (err != nil) {
+				t.Errorf("TestPopulate(): unexpected error: %v, wantErr: %v", err, tt.expectError)
+			} else if tt.expectError != nil {
+				assert.Regexp(t, *tt.expectError, err.Error(), "TestPopulate(): Error message should match")
+			} else {
+				assert.Equal(t, filepath.Join(dir, tt.wantPath), d.GetAbsPath(), "TestPopulate(): The two values should be the same.")
+			}
+		})
+	}
+}
+
 func invalidJsonRawContent200() []byte {
 	return []byte(InvalidDevfileContent)
 }
+
+func TestPopulateWithFilesystem(t *testing.T) {
+	notFoundErr := "the provided path is not a valid yaml filepath, and devfile.yaml, .devfile.yaml, devfile.yml, .devfile.yml not found in the provided path"
+	// the directory only exists on the fake filesystem, the lookup fails if the OS filesystem is used
+	const dir = "/devfile-library-fake-fs/nodejs"
+
+	tests := []struct {
+		name        string
+		fileNames   []string
+		wantPath    string
+		expectError *string
+	}{
+		{
+			name:      "directory with only .devfile.yaml",
+			fileNames: []string{".devfile.yaml"},
+			wantPath:  ".devfile.yaml",
+		},
+		{
+			name:      ".yaml devfiles are looked up before .yml devfiles",
+			fileNames: []string{"devfile.yml", ".devfile.yaml"},
+			wantPath:  ".devfile.yaml",
+		},
+		{
+			name:        "directory without devfile",
+			fileNames:   []string{"devfile.json"},
+			expectError: &notFoundErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := filesystem.NewFakeFs()
+			if err := fs.MkdirAll(dir, 0755); err != nil {
+				t.Fatalf("TestPopulateWithFilesystem(): unexpected error: %v", err)
+			}
+			for _, fileName := range tt.fileNames {
+				if err := fs.WriteFile(filepath.Join(dir, fileName), validJsonRawContent200(), 0644); err != nil {
+					t.Fatalf("TestPopulateWithFilesystem(): unexpected error: %v", err)
+				}
+			}
+
+			d := NewDevfileCtx(dir)
+			d.SetFilesystem(fs)
+			err := d.Populate()
+			if (tt.expectError != nil) != (err != nil) {
+				t.Errorf("TestPopulateWithFilesystem(): unexpected error: %v, wantErr: %v", err, tt.expectError)
+			} else if tt.expectError != nil {
+				assert.Regexp(t, *tt.expectError, err.Error(), "TestPopulateWithFilesystem(): Error message should match")
+			} else {
+				assert.Equal(t, filepath.Join(dir, tt.wantPath), d.GetAbsPath(), "TestPopulateWithFilesystem(): The two values should be the same.")
+				assert.Equal(t, validJsonRawContent200(), d.GetDevfileContent(), "TestPopulateWithFilesystem(): The two values should be the same.")
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	devfilepkg "github.com/devfile/api/v2/pkg/devfile"
+	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
+	"github.com/pkg/errors"
+)
+
+// ConvertedSchemaVersion is the schemaVersion of the devfiles converted from a devfile 1.x
+const ConvertedSchemaVersion = "2.2.0"
+
+// devfileV1 is the legacy devfile 1.x structure
+type devfileV1 struct {
+	APIVersion string               `json:"apiVersion"`
+	Metadata   devfileV1Metadata    `json:"metadata"`
+	Projects   []devfileV1Project   `json:"projects"`
+	Components []devfileV1Component `json:"components"`
+	Commands   []devfileV1Command   `json:"commands"`
+}
+
+type devfileV1Metadata struct {
+	Name         string `json:"name"`
+	GenerateName string `json:"generateName"`
+}
+
+type devfileV1Project struct {
+	Name      string                 `json:"name"`
+	ClonePath string                 `json:"clonePath"`
+	Source    devfileV1ProjectSource `json:"source"`
+}
+
+type devfileV1ProjectSource struct {
+	Type              string `json:"type"`
+	Location          string `json:"location"`
+	Branch            string `json:"branch"`
+	Tag               string `json:"tag"`
+	CommitID          string `json:"commitId"`
+	StartPoint        string `json:"startPoint"`
+	SparseCheckoutDir string `json:"sparseCheckoutDir"`
+}
+
+type devfileV1Component struct {
+	Alias            string              `json:"alias"`
+	Type             string              `json:"type"`
+	Image            string              `json:"image"`
+	MemoryLimit      string              `json:"memoryLimit"`
+	MemoryRequest    string              `json:"memoryRequest"`
+	CPULimit         string              `json:"cpuLimit"`
+	CPURequest       string              `json:"cpuRequest"`
+	MountSources     *bool               `json:"mountSources"`
+	Command          []string            `json:"command"`
+	Args             []string            `json:"args"`
+	Env              []devfileV1EnvVar   `json:"env"`
+	Endpoints        []devfileV1Endpoint `json:"endpoints"`
+	Volumes          []devfileV1Volume   `json:"volumes"`
+	Reference        string              `json:"reference"`
+	ReferenceContent string              `json:"referenceContent"`
+}
+
+type devfileV1EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type devfileV1Endpoint struct {
+	Name       string            `json:"name"`
+	Port       int               `json:"port"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+type devfileV1Volume struct {
+	Name          string `json:"name"`
+	ContainerPath string `json:"containerPath"`
+}
+
+type devfileV1Command struct {
+	Name       string                   `json:"name"`
+	Actions    []devfileV1CommandAction `json:"actions"`
+	PreviewURL *json.RawMessage         `json:"previewUrl"`
+}
+
+type devfileV1CommandAction struct {
+	Type      string `json:"type"`
+	Component string `json:"component"`
+	Command   string `json:"command"`
+	Workdir   string `json:"workdir"`
+}
+
+// devfileV1Fields are the top-level fields of a devfile 1.x which are converted to devfile 2.x
+var devfileV1Fields = map[string]bool{"apiVersion": true, "metadata": true, "projects": true, "components": true, "commands": true}
+
+// invalidIDCharacters matches the characters which are not allowed in the id of a devfile 2.x command
+var invalidIDCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// IsV1 returns true if the devfile is a legacy devfile 1.x
+func (d *DevfileCtx) IsV1() bool {
+	return strings.HasPrefix(d.apiVersion, "1.")
+}
+
+// GetConvertV1 func returns if a legacy devfile 1.x is converted to devfile 2.x when it is populated
+func (d *DevfileCtx) GetConvertV1() bool {
+	return d.convertV1
+}
+
+// SetConvertV1 sets if a legacy devfile 1.x is converted to devfile 2.x when it is populated
+func (d *DevfileCtx) SetConvertV1(value bool) {
+	d.convertV1 = value
+}
+
+// ConvertV1ToV2 converts the content of a legacy devfile 1.x into devfile 2.x. The projects, the dockerimage, kubernetes
+// and openshift components and the exec commands are converted. A warning retrievable with GetWarnings() is recorded
+// for each field which cannot be converted. It returns an error if the name of a command cannot be converted to a
+// command id or if several commands are converted to the same id. The source content of the devfile is not modified,
+// it remains the devfile 1.x content as provided.
+func (d *DevfileCtx) ConvertV1ToV2() (*v2.DevfileV2, error) {
+	if !d.IsV1() {
+		return nil, fmt.Errorf("the devfile apiVersion %s is not a devfile 1.x", d.apiVersion)
+	}
+
+	var devfile devfileV1
+	if err := json.Unmarshal(d.rawContent, &devfile); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode devfile 1.x content")
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(d.rawContent, &fields); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode devfile 1.x content")
+	}
+	var unconvertedFields []string
+	for field := range fields {
+		if !devfileV1Fields[field] {
+			unconvertedFields = append(unconvertedFields, field)
+		}
+	}
+	sort.Strings(unconvertedFields)
+	for _, field := range unconvertedFields {
+		d.addConversionWarning("the %s field is not converted", field)
+	}
+
+	name := devfile.Metadata.Name
+	if name == "" {
+		name = strings.TrimSuffix(devfile.Metadata.GenerateName, "-")
+	}
+	converted := &v2.DevfileV2{
+		Devfile: v1.Devfile{
+			DevfileHeader: devfilepkg.DevfileHeader{
+				SchemaVersion: ConvertedSchemaVersion,
+				Metadata: devfilepkg.DevfileMetadata{
+					Name: name,
+				},
+			},
+		},
+	}
+
+	for _, project := range devfile.Projects {
+		if convertedProject, ok := d.convertV1Project(project); ok {
+			converted.Projects = append(converted.Projects, convertedProject)
+		}
+	}
+
+	volumes := make(map[string]bool)
+	for _, component := range devfile.Components {
+		convertedComponent, ok := d.convertV1Component(component)
+		if !ok {
+			continue
+		}
+		converted.Components = append(converted.Components, convertedComponent)
+		for _, volume := range component.Volumes {
+			if !volumes[volume.Name] {
+				volumes[volume.Name] = true
+				converted.Components = append(converted.Components, v1.Component{
+					Name: volume.Name,
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{},
+					},
+				})
+			}
+		}
+	}
+
+	commandNames := make(map[string]string)
+	for _, command := range devfile.Commands {
+		convertedCommand, ok := d.convertV1Command(command)
+		if !ok {
+			continue
+		}
+		if convertedCommand.Id == "" {
+			return nil, fmt.Errorf("command %q cannot be converted, its name has no character allowed in a command id", command.Name)
+		}
+		if name, ok := commandNames[convertedCommand.Id]; ok {
+			return nil, fmt.Errorf("commands %q and %q cannot be converted, they are both converted to the command id %s", name, command.Name, convertedCommand.Id)
+		}
+		commandNames[convertedCommand.Id] = command.Name
+		converted.Commands = append(converted.Commands, convertedCommand)
+	}
+
+	return converted, nil
+}
+
+// convertV1Project converts a devfile 1.x project, it returns false if the project cannot be converted
+func (d *DevfileCtx) convertV1Project(project devfileV1Project) (v1.Project, bool) {
+	convertedProject := v1.Project{
+		Name:      project.Name,
+		ClonePath: project.ClonePath,
+	}
+	switch project.Source.Type {
+	case "git", "github":
+		convertedProject.Git = &v1.GitProjectSource{
+			GitLikeProjectSource: v1.GitLikeProjectSource{
+				Remotes: map[string]string{"origin": project.Source.Location},
+			},
+		}
+		for _, revision := range []string{project.Source.Branch, project.Source.Tag, project.Source.CommitID, project.Source.StartPoint} {
+			if revision != "" {
+				convertedProject.Git.CheckoutFrom = &v1.CheckoutFrom{Revision: revision}
+				break
+			}
+		}
+		if project.Source.SparseCheckoutDir != "" {
+			d.addConversionWarning("the sparseCheckoutDir of project %s is not converted", project.Name)
+		}
+	case "zip":
+		convertedProject.Zip = &v1.ZipProjectSource{
+			Location: project.Source.Location,
+		}
+	default:
+		d.addConversionWarning("project %s is not converted, the source type %s is not supported", project.Name, project.Source.Type)
+		return v1.Project{}, false
+	}
+	return convertedProject, true
+}
+
+// convertV1Component converts a devfile 1.x component, it returns false if the component cannot be converted
+func (d *DevfileCtx) convertV1Component(component devfileV1Component) (v1.Component, bool) {
+	if component.Alias == "" {
+		d.addConversionWarning("a %s component is not converted, it does not define an alias", component.Type)
+		return v1.Component{}, false
+	}
+
+	convertedComponent := v1.Component{Name: component.Alias}
+	switch component.Type {
+	case "dockerimage":
+		container := &v1.ContainerComponent{
+			Container: v1.Container{
+				Image:         component.Image,
+				MemoryLimit:   component.MemoryLimit,
+				MemoryRequest: component.MemoryRequest,
+				CpuLimit:      component.CPULimit,
+				CpuRequest:    component.CPURequest,
+				MountSources:  component.MountSources,
+				Command:       component.Command,
+				Args:          component.Args,
+			},
+		}
+		for _, env := range component.Env {
+			container.Env = append(container.Env, v1.EnvVar{Name: env.Name, Value: env.Value})
+		}
+		for _, volume := range component.Volumes {
+			container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{Name: volume.Name, Path: volume.ContainerPath})
+		}
+		for _, endpoint := range component.Endpoints {
+			container.Endpoints = append(container.Endpoints, d.convertV1Endpoint(component.Alias, endpoint))
+		}
+		convertedComponent.Container = container
+	case "kubernetes", "openshift":
+		location := v1.K8sLikeComponentLocation{
+			Uri:     component.Reference,
+			Inlined: component.ReferenceContent,
+		}
+		if location.Inlined != "" {
+			location.Uri = ""
+		}
+		if component.Type == "kubernetes" {
+			convertedComponent.Kubernetes = &v1.KubernetesComponent{K8sLikeComponent: v1.K8sLikeComponent{K8sLikeComponentLocation: location}}
+		} else {
+			convertedComponent.Openshift = &v1.OpenshiftComponent{K8sLikeComponent: v1.K8sLikeComponent{K8sLikeComponentLocation: location}}
+		}
+	default:
+		d.addConversionWarning("component %s is not converted, the component type %s is not supported", component.Alias, component.Type)
+		return v1.Component{}, false
+	}
+	return convertedComponent, true
+}
+
+// convertV1Endpoint converts a devfile 1.x endpoint of a dockerimage component
+func (d *DevfileCtx) convertV1Endpoint(componentName string, endpoint devfileV1Endpoint) v1.Endpoint {
+	convertedEndpoint := v1.Endpoint{
+		Name:       endpoint.Name,
+		TargetPort: endpoint.Port,
+	}
+	keys := make([]string, 0, len(endpoint.Attributes))
+	for key := range endpoint.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := endpoint.Attributes[key]
+		switch key {
+		case "public":
+			if value == "false" {
+				convertedEndpoint.Exposure = v1.InternalEndpointExposure
+			}
+		case "protocol":
+			convertedEndpoint.Protocol = v1.EndpointProtocol(value)
+		case "path":
+			convertedEndpoint.Path = value
+		case "secure":
+			secure := value == "true"
+			convertedEndpoint.Secure = &secure
+		default:
+			d.addConversionWarning("the attribute %s of endpoint %s of component %s is not converted", key, endpoint.Name, componentName)
+		}
+	}
+	return convertedEndpoint
+}
+
+// convertV1Command converts a devfile 1.x command to an exec command, it returns false if the command cannot be converted
+func (d *DevfileCtx) convertV1Command(command devfileV1Command) (v1.Command, bool) {
+	if len(command.Actions) == 0 || command.Actions[0].Type != "exec" {
+		d.addConversionWarning("command %s is not converted, only exec commands are supported", command.Name)
+		return v1.Command{}, false
+	}
+	if len(command.Actions) > 1 {
+		d.addConversionWarning("only the first action of command %s is converted", command.Name)
+	}
+	if command.PreviewURL != nil {
+		d.addConversionWarning("the previewUrl of command %s is not converted", command.Name)
+	}
+
+	action := command.Actions[0]
+	return v1.Command{
+		Id: strings.Trim(invalidIDCharacters.ReplaceAllString(strings.ToLower(command.Name), "-"), "-"),
+		CommandUnion: v1.CommandUnion{
+			Exec: &v1.ExecCommand{
+				CommandLine: action.Command,
+				Component:   action.Component,
+				WorkingDir:  action.Workdir,
+			},
+		},
+	}, true
+}
+
+// addConversionWarning records a warning for a devfile 1.x field which cannot be converted
+func (d *DevfileCtx) addConversionWarning(format string, args ...interface{}) {
+	d.warnings = append(d.warnings, fmt.Sprintf("devfile 1.x conversion: "+format, args...))
+}
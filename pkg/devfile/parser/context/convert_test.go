@@ -0,0 +1,307 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	devfilepkg "github.com/devfile/api/v2/pkg/devfile"
+	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertV1ToV2(t *testing.T) {
+	const devfileV1Content = `apiVersion: 1.0.0
+metadata:
+  generateName: nodejs-
+projects:
+- name: nodejs-web-app
+  source:
+    type: git
+    location: https://github.com/che-samples/web-nodejs-sample.git
+    branch: main
+- name: archive
+  source:
+    type: zip
+    location: https://example.com/archive.zip
+components:
+- type: chePlugin
+  id: redhat/vscode-yaml/latest
+- alias: nodejs
+  type: dockerimage
+  image: quay.io/eclipse/che-nodejs10-ubi:nightly
+  memoryLimit: 512Mi
+  mountSources: true
+  env:
+  - name: NODE_ENV
+    value: development
+  endpoints:
+  - name: nodejs
+    port: 3000
+    attributes:
+      public: "false"
+      protocol: http
+      discoverable: "true"
+  volumes:
+  - name: node-modules
+    containerPath: /projects/node_modules
+- alias: deploy
+  type: kubernetes
+  reference: deploy.yaml
+commands:
+- name: Run the web app
+  actions:
+  - type: exec
+    component: nodejs
+    command: node app.js
+    workdir: ${CHE_PROJECTS_ROOT}/nodejs-web-app/app
+  previewUrl:
+    port: 3000
+- name: debug
+  actions:
+  - type: vscode-launch
+    referenceContent: "{}"
+attributes:
+  persistVolumes: "false"
+`
+
+	mountSources := true
+	wantDevfile := &v2.DevfileV2{
+		Devfile: v1.Devfile{
+			DevfileHeader: devfilepkg.DevfileHeader{
+				SchemaVersion: ConvertedSchemaVersion,
+				Metadata: devfilepkg.DevfileMetadata{
+					Name: "nodejs",
+				},
+			},
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Projects: []v1.Project{
+						{
+							Name: "nodejs-web-app",
+							ProjectSource: v1.ProjectSource{
+								Git: &v1.GitProjectSource{
+									GitLikeProjectSource: v1.GitLikeProjectSource{
+										Remotes:      map[string]string{"origin": "https://github.com/che-samples/web-nodejs-sample.git"},
+										CheckoutFrom: &v1.CheckoutFrom{Revision: "main"},
+									},
+								},
+							},
+						},
+						{
+							Name: "archive",
+							ProjectSource: v1.ProjectSource{
+								Zip: &v1.ZipProjectSource{
+									Location: "https://example.com/archive.zip",
+								},
+							},
+						},
+					},
+					Components: []v1.Component{
+						{
+							Name: "nodejs",
+							ComponentUnion: v1.ComponentUnion{
+								Container: &v1.ContainerComponent{
+									Container: v1.Container{
+										Image:        "quay.io/eclipse/che-nodejs10-ubi:nightly",
+										MemoryLimit:  "512Mi",
+										MountSources: &mountSources,
+										Env:          []v1.EnvVar{{Name: "NODE_ENV", Value: "development"}},
+										VolumeMounts: []v1.VolumeMount{{Name: "node-modules", Path: "/projects/node_modules"}},
+									},
+									Endpoints: []v1.Endpoint{
+										{
+											Name:       "nodejs",
+											TargetPort: 3000,
+											Exposure:   v1.InternalEndpointExposure,
+											Protocol:   v1.HTTPEndpointProtocol,
+										},
+									},
+								},
+							},
+						},
+						{
+							Name: "node-modules",
+							ComponentUnion: v1.ComponentUnion{
+								Volume: &v1.VolumeComponent{},
+							},
+						},
+						{
+							Name: "deploy",
+							ComponentUnion: v1.ComponentUnion{
+								Kubernetes: &v1.KubernetesComponent{
+									K8sLikeComponent: v1.K8sLikeComponent{
+										K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+											Uri: "deploy.yaml",
+										},
+									},
+								},
+							},
+						},
+					},
+					Commands: []v1.Command{
+						{
+							Id: "run-the-web-app",
+							CommandUnion: v1.CommandUnion{
+								Exec: &v1.ExecCommand{
+									CommandLine: "node app.js",
+									Component:   "nodejs",
+									WorkingDir:  "${CHE_PROJECTS_ROOT}/nodejs-web-app/app",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	wantWarnings := []string{
+		"devfile 1.x conversion: the attributes field is not converted",
+		"devfile 1.x conversion: a chePlugin component is not converted, it does not define an alias",
+		"devfile 1.x conversion: the attribute discoverable of endpoint nodejs of component nodejs is not converted",
+		"devfile 1.x conversion: the previewUrl of command Run the web app is not converted",
+		"devfile 1.x conversion: command debug is not converted, only exec commands are supported",
+	}
+
+	t.Run("convert devfile 1.x", func(t *testing.T) {
+		d, err := NewByteContentDevfileCtx([]byte(devfileV1Content))
+		if err != nil {
+			t.Fatalf("TestConvertV1ToV2() unexpected error: %v", err)
+		}
+		if err = d.SetDevfileAPIVersion(); err != nil {
+			t.Fatalf("TestConvertV1ToV2() unexpected error: %v", err)
+		}
+		got, err := d.ConvertV1ToV2()
+		if err != nil {
+			t.Errorf("TestConvertV1ToV2() unexpected error: %v", err)
+			return
+		}
+		assert.Equal(t, wantDevfile, got, "TestConvertV1ToV2(): The two values should be the same.")
+		assert.Equal(t, wantWarnings, d.GetWarnings(), "TestConvertV1ToV2(): The two values should be the same.")
+	})
+
+	t.Run("source content of a converted devfile 1.x", func(t *testing.T) {
+		d, err := NewByteContentDevfileCtx([]byte(devfileV1Content))
+		if err != nil {
+			t.Fatalf("TestConvertV1ToV2() unexpected error: %v", err)
+		}
+		d.SetConvertV1(true)
+		if err = d.PopulateFromRaw(); err != nil {
+			t.Fatalf("TestConvertV1ToV2() unexpected error: %v", err)
+		}
+		assert.Equal(t, []byte(devfileV1Content), d.GetDevfileSourceContent(), "TestConvertV1ToV2(): The two values should be the same.")
+		assert.Contains(t, string(d.GetDevfileContent()), ConvertedSchemaVersion, "TestConvertV1ToV2(): The devfile content should be converted.")
+	})
+
+	for _, tt := range []struct {
+		name     string
+		commands string
+		wantErr  string
+	}{
+		{
+			name: "command name without id character",
+			commands: `- name: "++"
+  actions:
+  - type: exec
+    component: nodejs
+    command: npm test
+`,
+			wantErr: `command "++" cannot be converted, its name has no character allowed in a command id`,
+		},
+		{
+			name: "command names converted to the same id",
+			commands: `- name: Run App
+  actions:
+  - type: exec
+    component: nodejs
+    command: npm start
+- name: run-app
+  actions:
+  - type: exec
+    component: nodejs
+    command: node app.js
+`,
+			wantErr: `commands "Run App" and "run-app" cannot be converted, they are both converted to the command id run-app`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewByteContentDevfileCtx([]byte("apiVersion: 1.0.0\nmetadata:\n  name: nodejs\ncommands:\n" + tt.commands))
+			if err != nil {
+				t.Fatalf("TestConvertV1ToV2() unexpected error: %v", err)
+			}
+			if err = d.SetDevfileAPIVersion(); err != nil {
+				t.Fatalf("TestConvertV1ToV2() unexpected error: %v", err)
+			}
+			_, err = d.ConvertV1ToV2()
+			assert.EqualError(t, err, tt.wantErr, "TestConvertV1ToV2(): Error message should match")
+		})
+	}
+
+	t.Run("convert devfile 2.x", func(t *testing.T) {
+		d := DevfileCtx{apiVersion: "2.2.0"}
+		_, err := d.ConvertV1ToV2()
+		assert.EqualError(t, err, "the devfile apiVersion 2.2.0 is not a devfile 1.x", "TestConvertV1ToV2(): Error message should match")
+	})
+}
+
+func TestPopulateV1Devfile(t *testing.T) {
+	const devfileV1Content = `apiVersion: 1.0.0
+metadata:
+  name: nodejs
+components:
+- alias: nodejs
+  type: dockerimage
+  image: quay.io/eclipse/che-nodejs10-ubi:nightly
+`
+
+	unsupportedErr := "devfile apiVersion 1.0.0 is not supported, devfile 1.x must be converted to devfile 2.x"
+
+	tests := []struct {
+		name           string
+		convertV1      bool
+		wantApiVersion string
+		wantErr        *string
+	}{
+		{
+			name:           "devfile 1.x is converted",
+			convertV1:      true,
+			wantApiVersion: ConvertedSchemaVersion,
+		},
+		{
+			name:    "devfile 1.x is rejected",
+			wantErr: &unsupportedErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewByteContentDevfileCtx([]byte(devfileV1Content))
+			if err != nil {
+				t.Fatalf("TestPopulateV1Devfile() unexpected error: %v", err)
+			}
+			d.SetConvertV1(tt.convertV1)
+			err = d.PopulateFromRaw()
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestPopulateV1Devfile() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantApiVersion, d.GetApiVersion(), "TestPopulateV1Devfile(): The two values should be the same.")
+				assert.NoError(t, d.Validate(), "TestPopulateV1Devfile(): The converted devfile should be valid.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestPopulateV1Devfile(): Error message should match")
+			}
+		})
+	}
+}
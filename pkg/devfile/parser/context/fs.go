@@ -17,7 +17,16 @@ package parser
 
 import "github.com/devfile/library/v2/pkg/testingutil/filesystem"
 
-// GetFs returns the filesystem object
+// GetFs returns the filesystem object, the OS filesystem is returned if no filesystem is set
 func (d *DevfileCtx) GetFs() filesystem.Filesystem {
+	if d.fs == nil {
+		return filesystem.DefaultFs{}
+	}
 	return d.fs
 }
+
+// SetFilesystem sets the filesystem the devfile and the resources and parents it references by relative paths are
+// read from, e.g. a fake filesystem or a filesystem backed by an embed.FS
+func (d *DevfileCtx) SetFilesystem(fs filesystem.Filesystem) {
+	d.fs = fs
+}
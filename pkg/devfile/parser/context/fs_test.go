@@ -0,0 +1,101 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"embed"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/devfile/library/v2/pkg/testingutil/filesystem"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:embed testdata/embedded
+var embeddedDevfiles embed.FS
+
+func TestPopulateFromIOFs(t *testing.T) {
+	missingFileErr := "failed to read devfile from path '/testdata/embedded/missing.yaml'"
+
+	tests := []struct {
+		name        string
+		fs          filesystem.Filesystem
+		absPath     string
+		wantName    string
+		wantVersion string
+		expectError *string
+	}{
+		{
+			name:        "devfile read from an embed.FS",
+			fs:          filesystem.NewIOFs(embeddedDevfiles),
+			absPath:     "/testdata/embedded/devfile.yaml",
+			wantName:    "embedded-devfile",
+			wantVersion: "2.2.0",
+		},
+		{
+			name:        "devfile read from a fstest.MapFS",
+			fs:          filesystem.NewIOFs(fstest.MapFS{"devfile.yaml": {Data: validJsonRawContent200()}}),
+			absPath:     "/devfile.yaml",
+			wantName:    "nodejs-stack",
+			wantVersion: "2.0.0",
+		},
+		{
+			name:        "devfile missing from the fs.FS",
+			fs:          filesystem.NewIOFs(embeddedDevfiles),
+			absPath:     "/testdata/embedded/missing.yaml",
+			expectError: &missingFileErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := FakeContext(tt.fs, tt.absPath)
+			err := d.SetDevfileContent()
+			if err == nil {
+				err = d.populateDevfile()
+			}
+			if (tt.expectError != nil) != (err != nil) {
+				t.Fatalf("TestPopulateFromIOFs(): unexpected error: %v, wantErr: %v", err, tt.expectError)
+			} else if tt.expectError != nil {
+				assert.Contains(t, err.Error(), *tt.expectError, "TestPopulateFromIOFs(): Error message should match")
+				return
+			}
+			assert.Equal(t, tt.wantVersion, d.GetApiVersion(), "TestPopulateFromIOFs(): The two values should be the same.")
+			assert.Contains(t, string(d.GetDevfileContent()), tt.wantName, "TestPopulateFromIOFs(): The devfile content should be read from the fs.FS.")
+		})
+	}
+}
+
+func TestIOFsReadOnly(t *testing.T) {
+	fs := filesystem.NewIOFs(embeddedDevfiles)
+	err := fs.WriteFile("/testdata/embedded/devfile.yaml", []byte{}, 0644)
+	if !errors.Is(err, filesystem.ErrReadOnly) {
+		t.Errorf("TestIOFsReadOnly(): expected a read-only filesystem error, got: %v", err)
+	}
+	_, err = fs.Create("/devfile.yaml")
+	if !errors.Is(err, filesystem.ErrReadOnly) {
+		t.Errorf("TestIOFsReadOnly(): expected a read-only filesystem error, got: %v", err)
+	}
+}
+
+func TestSetFilesystem(t *testing.T) {
+	d := NewURLDevfileCtx("https://registry.example.com/devfile.yaml")
+	assert.Equal(t, filesystem.DefaultFs{}, d.GetFs(), "TestSetFilesystem(): The OS filesystem should be used if no filesystem is set.")
+
+	fs := filesystem.NewFakeFs()
+	d.SetFilesystem(fs)
+	assert.Equal(t, fs, d.GetFs(), "TestSetFilesystem(): The two values should be the same.")
+}
@@ -0,0 +1,76 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GetElementLocation returns the line and column in the devfile source of the element at the JSON pointer path,
+// e.g. /components/2/container/image. The path refers to the devfile source, not to the flattened devfile.
+// It returns an error if the path does not resolve to an element of the devfile source.
+func (d *DevfileCtx) GetElementLocation(path string) (line, col int, err error) {
+	if len(d.sourceContent) == 0 {
+		return 0, 0, fmt.Errorf("the devfile source content is not available")
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(d.sourceContent, &document); err != nil {
+		return 0, 0, fmt.Errorf("failed to decode the devfile source: %v", err)
+	}
+	if len(document.Content) == 0 {
+		return 0, 0, fmt.Errorf("the devfile source is empty")
+	}
+
+	node := document.Content[0]
+	if path != "" {
+		if !strings.HasPrefix(path, "/") {
+			return 0, 0, fmt.Errorf("invalid path %s, it should start with /", path)
+		}
+		for _, token := range strings.Split(path[1:], "/") {
+			token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+			if node = getChildNode(node, token); node == nil {
+				return 0, 0, fmt.Errorf("path %s does not resolve to an element of the devfile source", path)
+			}
+		}
+	}
+	return node.Line, node.Column, nil
+}
+
+// getChildNode returns the value of the mapping key, or the item at the sequence index, of the node
+func getChildNode(node *yaml.Node, token string) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == token {
+				return node.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		index, err := strconv.Atoi(token)
+		if err == nil && index >= 0 && index < len(node.Content) {
+			return node.Content[index]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetElementLocation(t *testing.T) {
+	devfileContent := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+    endpoints:
+    - name: http/api
+      targetPort: 3000
+- name: tools
+  container:
+    image: quay.io/tools
+attributes:
+  app.kubernetes.io/name: nodejs
+`
+
+	unresolvedPathErr := "path .* does not resolve to an element of the devfile source"
+	invalidPathErr := "invalid path components, it should start with /"
+
+	tests := []struct {
+		name     string
+		path     string
+		wantLine int
+		wantCol  int
+		wantErr  *string
+	}{
+		{
+			name:     "devfile root",
+			path:     "",
+			wantLine: 1,
+			wantCol:  1,
+		},
+		{
+			name:     "component",
+			path:     "/components/1",
+			wantLine: 11,
+			wantCol:  3,
+		},
+		{
+			name:     "container image",
+			path:     "/components/0/container/image",
+			wantLine: 7,
+			wantCol:  12,
+		},
+		{
+			name:     "endpoint name",
+			path:     "/components/0/container/endpoints/0/name",
+			wantLine: 9,
+			wantCol:  13,
+		},
+		{
+			name:     "escaped path token",
+			path:     "/attributes/app.kubernetes.io~1name",
+			wantLine: 15,
+			wantCol:  27,
+		},
+		{
+			name:    "out of range index",
+			path:    "/components/2/container/image",
+			wantErr: &unresolvedPathErr,
+		},
+		{
+			name:    "missing key",
+			path:    "/components/0/volume",
+			wantErr: &unresolvedPathErr,
+		},
+		{
+			name:    "path without leading slash",
+			path:    "components",
+			wantErr: &invalidPathErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewByteContentDevfileCtx([]byte(devfileContent))
+			if err != nil {
+				t.Fatalf("TestGetElementLocation() unexpected error: %v", err)
+			}
+			line, col, err := d.GetElementLocation(tt.path)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestGetElementLocation() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantLine, line, "TestGetElementLocation(): The two values should be the same.")
+				assert.Equal(t, tt.wantCol, col, "TestGetElementLocation(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestGetElementLocation(): Error message should match")
+			}
+		})
+	}
+}
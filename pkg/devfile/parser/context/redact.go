@@ -0,0 +1,93 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// redactedValue replaces the values of the redacted env vars in diagnostic messages
+const redactedValue = "***"
+
+// SetRedactedEnvNames sets the names of the env vars whose values are replaced with *** in the diagnostic messages
+// of the devfile, e.g. schema validation errors. The parsed env var values are not altered.
+func (d *DevfileCtx) SetRedactedEnvNames(names []string) {
+	d.redactedEnvNames = names
+}
+
+// GetRedactedEnvNames returns the names of the env vars whose values are redacted in diagnostic messages
+func (d *DevfileCtx) GetRedactedEnvNames() []string {
+	return d.redactedEnvNames
+}
+
+// RedactEnvValues returns the message with the values of the redacted env vars of the devfile replaced with ***
+func (d *DevfileCtx) RedactEnvValues(message string) string {
+	if len(d.redactedEnvNames) == 0 || len(d.rawContent) == 0 {
+		return message
+	}
+
+	var content interface{}
+	if err := json.Unmarshal(d.rawContent, &content); err != nil {
+		return message
+	}
+
+	redactedNames := make(map[string]bool)
+	for _, name := range d.redactedEnvNames {
+		redactedNames[name] = true
+	}
+	values := getEnvValues(content, redactedNames)
+
+	// replace the longest values first, so a value containing another one is fully redacted
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+	for _, value := range values {
+		message = strings.ReplaceAll(message, value, redactedValue)
+	}
+	return message
+}
+
+// getEnvValues returns the non empty values of the env vars with the given names, found in any env list of the content
+func getEnvValues(content interface{}, names map[string]bool) []string {
+	var values []string
+	switch content := content.(type) {
+	case map[string]interface{}:
+		for key, child := range content {
+			if envList, ok := child.([]interface{}); ok && key == "env" {
+				for _, env := range envList {
+					envVar, ok := env.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					name, _ := envVar["name"].(string)
+					value, _ := envVar["value"].(string)
+					if names[name] && value != "" {
+						values = append(values, value)
+					}
+				}
+				continue
+			}
+			values = append(values, getEnvValues(child, names)...)
+		}
+	case []interface{}:
+		for _, child := range content {
+			values = append(values, getEnvValues(child, names)...)
+		}
+	}
+	return values
+}
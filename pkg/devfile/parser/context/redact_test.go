@@ -0,0 +1,87 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactEnvValues(t *testing.T) {
+	devfileContent := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+    env:
+    - name: PASSWORD
+      value: s3cr3t
+    - name: TOKEN
+      value: s3cr3t-t0ken
+    - name: DEBUG
+      value: "true"
+commands:
+- id: run
+  exec:
+    component: runtime
+    commandLine: npm start
+    env:
+    - name: PASSWORD
+      value: another-s3cr3t
+`
+
+	tests := []struct {
+		name          string
+		redactedNames []string
+		message       string
+		wantMessage   string
+	}{
+		{
+			name:        "no redacted env names",
+			message:     "invalid value s3cr3t",
+			wantMessage: "invalid value s3cr3t",
+		},
+		{
+			name:          "values of the redacted env vars of components and commands are replaced",
+			redactedNames: []string{"PASSWORD", "TOKEN"},
+			message:       "invalid values s3cr3t-t0ken, s3cr3t, another-s3cr3t and true",
+			wantMessage:   "invalid values ***, ***, *** and true",
+		},
+		{
+			name:          "values of env vars which are not redacted are kept",
+			redactedNames: []string{"TOKEN"},
+			message:       "invalid values s3cr3t and true",
+			wantMessage:   "invalid values s3cr3t and true",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewByteContentDevfileCtx([]byte(devfileContent))
+			if err != nil {
+				t.Errorf("TestRedactEnvValues() unexpected error: %v", err)
+				return
+			}
+			rawContent := string(d.GetDevfileContent())
+			d.SetRedactedEnvNames(tt.redactedNames)
+
+			assert.Equal(t, tt.wantMessage, d.RedactEnvValues(tt.message), "TestRedactEnvValues(): The two values should be the same.")
+			assert.Equal(t, rawContent, string(d.GetDevfileContent()), "TestRedactEnvValues(): The devfile content should not be altered.")
+		})
+	}
+}
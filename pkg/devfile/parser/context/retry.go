@@ -0,0 +1,81 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/devfile/library/v2/pkg/util"
+	"k8s.io/klog"
+)
+
+// DefaultRetryableStatusCodes are the HTTP status codes retried if the RetryPolicy does not set any
+var DefaultRetryableStatusCodes = []int{500, 502, 503, 504}
+
+// RetryPolicy defines how the download of a URL is retried when it fails with a transient error, i.e. a connection
+// error or a retryable HTTP status code. The zero value does not retry.
+type RetryPolicy struct {
+	// Attempts is the maximum number of attempts, including the first one
+	Attempts int
+	// BaseDelay is the delay before the first retry, the delay is doubled before each next retry
+	BaseDelay time.Duration
+	// RetryableStatusCodes are the HTTP status codes retried, DefaultRetryableStatusCodes are retried if it is empty
+	RetryableStatusCodes []int
+}
+
+// isRetryable returns if the download error is transient
+func (p RetryPolicy) isRetryable(err error) bool {
+	var statusErr *util.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		statusCodes := p.RetryableStatusCodes
+		if len(statusCodes) == 0 {
+			statusCodes = DefaultRetryableStatusCodes
+		}
+		for _, statusCode := range statusCodes {
+			if statusErr.StatusCode == statusCode {
+				return true
+			}
+		}
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// download calls the download function until it succeeds, fails with an error which is not retryable,
+// the maximum number of attempts is reached or the context is done. ctx is optional.
+func (p RetryPolicy) download(ctx context.Context, downloadURL string, download func() ([]byte, error)) ([]byte, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	delay := p.BaseDelay
+	for attempt := 1; ; attempt++ {
+		data, err := download()
+		if err == nil || attempt >= p.Attempts || ctx.Err() != nil || !p.isRetryable(err) {
+			return data, err
+		}
+		klog.V(4).Infof("attempt %d to download url '%s' failed, retrying in %v: %v", attempt, downloadURL, delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,105 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPopulateFromURLWithRetryPolicy(t *testing.T) {
+	serviceUnavailableErr := "failed to retrieve .*, 503: Service Unavailable"
+	notFoundErr := "failed to retrieve .*, 404: Not Found"
+
+	tests := []struct {
+		name        string
+		policy      *RetryPolicy
+		failures    int
+		failureCode int
+		wantHits    int
+		expectError *string
+	}{
+		{
+			name:        "transient failures are retried",
+			policy:      &RetryPolicy{Attempts: 3, BaseDelay: time.Millisecond},
+			failures:    2,
+			failureCode: http.StatusServiceUnavailable,
+			wantHits:    3,
+		},
+		{
+			name:        "downloads are not retried by default",
+			failures:    2,
+			failureCode: http.StatusServiceUnavailable,
+			wantHits:    1,
+			expectError: &serviceUnavailableErr,
+		},
+		{
+			name:        "retries are bounded by the number of attempts",
+			policy:      &RetryPolicy{Attempts: 2, BaseDelay: time.Millisecond},
+			failures:    2,
+			failureCode: http.StatusServiceUnavailable,
+			wantHits:    2,
+			expectError: &serviceUnavailableErr,
+		},
+		{
+			name:        "non retryable status fails fast",
+			policy:      &RetryPolicy{Attempts: 3, BaseDelay: time.Millisecond},
+			failures:    2,
+			failureCode: http.StatusNotFound,
+			wantHits:    1,
+			expectError: &notFoundErr,
+		},
+		{
+			name:        "configured retryable status",
+			policy:      &RetryPolicy{Attempts: 3, BaseDelay: time.Millisecond, RetryableStatusCodes: []int{http.StatusNotFound}},
+			failures:    1,
+			failureCode: http.StatusNotFound,
+			wantHits:    2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits := 0
+			testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits++
+				if hits <= tt.failures {
+					w.WriteHeader(tt.failureCode)
+					return
+				}
+				if _, err := w.Write(validJsonRawContent200()); err != nil {
+					t.Error(err)
+				}
+			}))
+			defer testServer.Close()
+
+			d := NewURLDevfileCtx(testServer.URL)
+			if tt.policy != nil {
+				d.SetRetryPolicy(*tt.policy)
+			}
+			err := d.PopulateFromURL()
+			if (tt.expectError != nil) != (err != nil) {
+				t.Errorf("TestPopulateFromURLWithRetryPolicy(): unexpected error: %v, wantErr: %v", err, tt.expectError)
+			} else if tt.expectError != nil {
+				assert.Regexp(t, *tt.expectError, err.Error(), "TestPopulateFromURLWithRetryPolicy(): Error message should match")
+			}
+			assert.Equal(t, tt.wantHits, hits, "TestPopulateFromURLWithRetryPolicy(): The two values should be the same.")
+		})
+	}
+}
@@ -16,7 +16,11 @@
 package parser
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
 
 	"github.com/devfile/library/v2/pkg/devfile/parser/data"
 	"github.com/pkg/errors"
@@ -27,6 +31,11 @@ import (
 // SetDevfileJSONSchema returns the JSON schema for the given devfile apiVersion
 func (d *DevfileCtx) SetDevfileJSONSchema() error {
 
+	// A custom json schema set by the consumer takes precedence over the apiVersion schema
+	if d.customJSONSchema {
+		return nil
+	}
+
 	// Check if json schema is present for the given apiVersion
 	jsonSchema, err := data.GetDevfileJSONSchema(d.apiVersion)
 	if err != nil {
@@ -36,28 +45,245 @@ func (d *DevfileCtx) SetDevfileJSONSchema() error {
 	return nil
 }
 
-// ValidateDevfileSchema validate JSON schema of the provided devfile
+// SetCustomJSONSchema sets a custom json schema to validate the devfile against, instead of the
+// schema of the devfile apiVersion. schemaBaseURI is optional, it is a directory path or a URL
+// which the relative $refs of the custom schema are resolved against.
+func (d *DevfileCtx) SetCustomJSONSchema(jsonSchema string, schemaBaseURI string) {
+	d.jsonSchema = jsonSchema
+	d.jsonSchemaBaseURI = schemaBaseURI
+	d.customJSONSchema = true
+}
+
+// GetJSONSchema returns the json schema the devfile is validated against, it is the schema of the devfile apiVersion
+// unless a custom json schema is set. It returns an empty string before the devfile context is populated.
+func (d *DevfileCtx) GetJSONSchema() string {
+	return d.jsonSchema
+}
+
+// SchemaIssueSeverity is the severity of a devfile json schema issue
+type SchemaIssueSeverity string
+
+// SchemaIssueError is the severity of the issues which make the devfile invalid
+const SchemaIssueError SchemaIssueSeverity = "error"
+
+// SchemaIssue is a violation of the devfile json schema
+type SchemaIssue struct {
+	// Path is the JSON pointer of the devfile element the issue is about, it is empty for the devfile root
+	Path string
+	// Field is the name of the invalid field as reported by the schema validator, e.g. components.0.name
+	Field string
+	// Message describes the issue
+	Message string
+	// Severity is the severity of the issue
+	Severity SchemaIssueSeverity
+}
+
+// ValidateDevfileSchema validates the devfile against its json schema, all the schema issues are reported in the returned error
 func (d *DevfileCtx) ValidateDevfileSchema() error {
+	issues, err := d.GetDevfileSchemaIssues()
+	if err != nil {
+		return err
+	}
+
+	if len(issues) > 0 {
+		errMsg := "invalid devfile schema. errors :\n"
+		for _, issue := range issues {
+			errMsg = errMsg + fmt.Sprintf("- %s: %s\n", issue.Field, issue.Message)
+		}
+		return fmt.Errorf(d.RedactEnvValues(errMsg))
+	}
+
+	// Sucessful
+	klog.V(4).Info("validated devfile schema")
+	return nil
+}
+
+// GetDevfileSchemaIssues validates the devfile against its json schema and returns all the schema issues found,
+// the env var values which are redacted are replaced in the issue messages. An error is returned if the devfile
+// cannot be validated.
+func (d *DevfileCtx) GetDevfileSchemaIssues() ([]SchemaIssue, error) {
 	var (
 		schemaLoader   = gojsonschema.NewStringLoader(d.jsonSchema)
 		documentLoader = gojsonschema.NewStringLoader(string(d.rawContent))
 	)
 
-	// Validate devfile with JSON schema
-	result, err := gojsonschema.Validate(schemaLoader, documentLoader)
+	var result *gojsonschema.Result
+	var err error
+	if d.customJSONSchema && d.jsonSchemaBaseURI != "" {
+		var schema *gojsonschema.Schema
+		schema, err = d.compileJSONSchemaWithBaseURI(d.jsonSchema, d.jsonSchemaBaseURI)
+		if err != nil {
+			return nil, err
+		}
+		result, err = schema.Validate(documentLoader)
+	} else if !d.customJSONSchema {
+		// the compiled schema of the apiVersion is reused across the parses
+		schemaCache := d.schemaCache
+		if schemaCache == nil {
+			schemaCache = defaultSchemaCache
+		}
+		var schema *gojsonschema.Schema
+		schema, err = schemaCache.getSchema(d.apiVersion, d.jsonSchema)
+		if err != nil {
+			return nil, err
+		}
+		result, err = schema.Validate(documentLoader)
+	} else {
+		// Validate devfile with JSON schema
+		result, err = gojsonschema.Validate(schemaLoader, documentLoader)
+	}
 	if err != nil {
-		return errors.Wrapf(err, "failed to validate devfile schema")
+		return nil, errors.Wrapf(err, "failed to validate devfile schema")
 	}
 
-	if !result.Valid() {
-		errMsg := "invalid devfile schema. errors :\n"
-		for _, desc := range result.Errors() {
-			errMsg = errMsg + fmt.Sprintf("- %s\n", desc)
-		}
-		return fmt.Errorf(errMsg)
+	var issues []SchemaIssue
+	for _, desc := range result.Errors() {
+		issues = append(issues, SchemaIssue{
+			Path:     getJSONPointer(desc.Context()),
+			Field:    desc.Field(),
+			Message:  d.RedactEnvValues(desc.Description()),
+			Severity: SchemaIssueError,
+		})
 	}
+	return issues, nil
+}
 
-	// Sucessful
-	klog.V(4).Info("validated devfile schema")
+// getJSONPointer converts the context of a schema validation error to a JSON pointer
+func getJSONPointer(context *gojsonschema.JsonContext) string {
+	if context == nil {
+		return ""
+	}
+	// the context elements are joined with a separator which cannot appear in the keys, the first element is the root
+	elements := strings.Split(context.String("\x00"), "\x00")
+	pointer := ""
+	for _, element := range elements[1:] {
+		pointer += "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(element)
+	}
+	return pointer
+}
+
+// compileJSONSchemaWithBaseURI compiles the json schema with its relative $refs resolved against the base URI.
+// The local schema documents are read from the filesystem of the devfile context.
+func (d *DevfileCtx) compileJSONSchemaWithBaseURI(jsonSchema string, schemaBaseURI string) (*gojsonschema.Schema, error) {
+	baseURL, err := d.getSchemaBaseURL(schemaBaseURI)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid custom devfile schema base URI %s", schemaBaseURI)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonSchema), &schema); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode custom devfile schema")
+	}
+	// relative $refs are resolved against the $id of the schema
+	if _, ok := schema["$id"]; !ok {
+		schema["$id"] = baseURL.String()
+	}
+
+	// load every referenced document upfront so an unresolved $ref is reported by name, the referenced documents
+	// are added to the schema loader which then does not load them again
+	schemaLoader := gojsonschema.NewSchemaLoader()
+	if err := d.loadSchemaRefs(schemaLoader, "custom devfile schema", baseURL, schema, map[string]bool{}); err != nil {
+		return nil, err
+	}
+
+	compiledSchema, err := schemaLoader.Compile(gojsonschema.NewGoLoader(schema))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile custom devfile schema")
+	}
+	return compiledSchema, nil
+}
+
+// loadSchemaRefs loads the documents referenced by the $refs of the schema document and the documents they
+// reference in turn, documentURL is the URL the relative $refs of the document are resolved against and
+// documentName names the document in the errors. loaded holds the URLs of the documents already loaded,
+// a document is only loaded once.
+func (d *DevfileCtx) loadSchemaRefs(schemaLoader *gojsonschema.SchemaLoader, documentName string, documentURL *url.URL, document interface{}, loaded map[string]bool) error {
+	for _, ref := range getSchemaRefs(document) {
+		refURL, err := url.Parse(ref)
+		if err != nil {
+			return errors.Wrapf(err, "unable to resolve $ref %s in %s", ref, documentName)
+		}
+		refURL = documentURL.ResolveReference(refURL)
+		refURL.Fragment = ""
+		if loaded[refURL.String()] {
+			continue
+		}
+		loaded[refURL.String()] = true
+
+		refDocument, err := d.loadSchemaDocument(refURL)
+		if err != nil {
+			return errors.Wrapf(err, "unable to resolve $ref %s in %s", ref, documentName)
+		}
+		// the $refs of the document are collected before the schema loader makes them absolute
+		refDocumentURL := refURL
+		if refMap, ok := refDocument.(map[string]interface{}); ok {
+			if id, ok := refMap["$id"].(string); ok {
+				if idURL, err := url.Parse(id); err == nil {
+					refDocumentURL = refURL.ResolveReference(idURL)
+				}
+			}
+		}
+		if err := d.loadSchemaRefs(schemaLoader, "custom devfile schema document "+refURL.String(), refDocumentURL, refDocument, loaded); err != nil {
+			return err
+		}
+		if err := schemaLoader.AddSchema(refURL.String(), gojsonschema.NewGoLoader(refDocument)); err != nil {
+			return errors.Wrapf(err, "unable to resolve $ref %s in %s", ref, documentName)
+		}
+	}
 	return nil
 }
+
+// loadSchemaDocument loads the schema document of the URL, a file URL is read from the filesystem of the devfile context
+func (d *DevfileCtx) loadSchemaDocument(documentURL *url.URL) (interface{}, error) {
+	if documentURL.Scheme != "file" {
+		return gojsonschema.NewReferenceLoader(documentURL.String()).LoadJSON()
+	}
+	content, err := d.GetFs().ReadFile(filepath.FromSlash(documentURL.Path))
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// getSchemaBaseURL converts the schema base URI to a URL, a local directory of the filesystem of the devfile context
+// is converted to a file URL
+func (d *DevfileCtx) getSchemaBaseURL(schemaBaseURI string) (*url.URL, error) {
+	if strings.HasPrefix(schemaBaseURI, "http://") || strings.HasPrefix(schemaBaseURI, "https://") || strings.HasPrefix(schemaBaseURI, "file://") {
+		return url.Parse(schemaBaseURI)
+	}
+
+	absPath, err := filepath.Abs(schemaBaseURI)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := d.GetFs().Stat(absPath); err == nil && info.IsDir() {
+		absPath = absPath + string(filepath.Separator)
+	}
+	return &url.URL{Scheme: "file", Path: filepath.ToSlash(absPath)}, nil
+}
+
+// getSchemaRefs returns all the $refs of the schema which point outside of the schema document
+func getSchemaRefs(schema interface{}) []string {
+	var refs []string
+	switch value := schema.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				if !strings.HasPrefix(ref, "#") {
+					refs = append(refs, ref)
+				}
+				continue
+			}
+			refs = append(refs, getSchemaRefs(child)...)
+		}
+	case []interface{}:
+		for _, child := range value {
+			refs = append(refs, getSchemaRefs(child)...)
+		}
+	}
+	return refs
+}
@@ -16,10 +16,15 @@
 package parser
 
 import (
-	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	v200 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2/2.0.0"
+	"github.com/devfile/library/v2/pkg/testingutil/filesystem"
 )
 
 const (
@@ -169,6 +174,211 @@ func TestValidateDevfileSchema(t *testing.T) {
 	})
 }
 
+func TestGetDevfileSchemaIssues(t *testing.T) {
+
+	t.Run("valid 2.0.0 json schema", func(t *testing.T) {
+		d := DevfileCtx{
+			jsonSchema: v200.JsonSchema200,
+			rawContent: validJsonRawContent200(),
+		}
+
+		issues, err := d.GetDevfileSchemaIssues()
+		if err != nil {
+			t.Errorf("TestGetDevfileSchemaIssues() unexpected error: '%v'", err)
+		}
+		assert.Empty(t, issues, "TestGetDevfileSchemaIssues(): a valid devfile should not have issues")
+	})
+
+	t.Run("several schema violations", func(t *testing.T) {
+		d := DevfileCtx{
+			jsonSchema: v200.JsonSchema200,
+			rawContent: []byte(`{"metadata": "nodejs", "components": [{"name": "Bad_Name", "container": {}}]}`),
+		}
+
+		issues, err := d.GetDevfileSchemaIssues()
+		if err != nil {
+			t.Fatalf("TestGetDevfileSchemaIssues() unexpected error: '%v'", err)
+		}
+		wantIssues := []SchemaIssue{
+			{Path: "", Field: "(root)", Message: "schemaVersion is required", Severity: SchemaIssueError},
+			{Path: "/metadata", Field: "metadata", Message: "Invalid type. Expected: object, given: string", Severity: SchemaIssueError},
+			{Path: "/components/0/name", Field: "components.0.name", Message: "Does not match pattern '^[a-z0-9]([-a-z0-9]*[a-z0-9])?$'", Severity: SchemaIssueError},
+			{Path: "/components/0/container", Field: "components.0.container", Message: "image is required", Severity: SchemaIssueError},
+		}
+		assert.ElementsMatch(t, wantIssues, issues, "TestGetDevfileSchemaIssues(): The two values should be the same.")
+
+		err = d.ValidateDevfileSchema()
+		if err == nil {
+			t.Errorf("TestGetDevfileSchemaIssues() expected error, didn't get one")
+			return
+		}
+		for _, issue := range wantIssues {
+			assert.Contains(t, err.Error(), "- "+issue.Field+": "+issue.Message, "TestGetDevfileSchemaIssues(): Error message should list every issue")
+		}
+	})
+}
+
+func TestGetJSONSchema(t *testing.T) {
+	d, err := NewRawDevfileCtx(validJsonRawContent200(), "")
+	if err != nil {
+		t.Fatalf("TestGetJSONSchema() unexpected error: %v", err)
+	}
+	assert.Empty(t, d.GetApiVersion(), "TestGetJSONSchema(): The apiVersion should be empty before populate")
+	assert.Empty(t, d.GetJSONSchema(), "TestGetJSONSchema(): The json schema should be empty before populate")
+
+	if err := d.PopulateFromRaw(); err != nil {
+		t.Fatalf("TestGetJSONSchema() unexpected error: %v", err)
+	}
+	assert.Equal(t, "2.0.0", d.GetApiVersion(), "TestGetJSONSchema(): The two values should be the same.")
+	assert.Equal(t, v200.JsonSchema200, d.GetJSONSchema(), "TestGetJSONSchema(): The two values should be the same.")
+}
+
+func TestValidateDevfileCustomSchema(t *testing.T) {
+	const (
+		customSchema = `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object",
+			"properties": {
+				"schemaVersion": {
+					"$ref": "definitions.json#/definitions/schemaVersion"
+				}
+			}
+		}`
+		customSchemaMissingRef = `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object",
+			"properties": {
+				"schemaVersion": {
+					"$ref": "missing.json#/definitions/schemaVersion"
+				}
+			}
+		}`
+		customSchemaNestedRef = `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object",
+			"properties": {
+				"schemaVersion": {
+					"$ref": "nested/version.json#/definitions/schemaVersion"
+				}
+			}
+		}`
+		customSchemaNestedMissingRef = `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object",
+			"properties": {
+				"schemaVersion": {
+					"$ref": "broken.json#/definitions/schemaVersion"
+				}
+			}
+		}`
+		definitions = `{"definitions": {"schemaVersion": {"type": "string", "pattern": "^2\\.2\\.0$"}, "cycle": {"$ref": "nested/version.json#/definitions/schemaVersion"}}}`
+		version     = `{"definitions": {"schemaVersion": {"$ref": "../definitions.json#/definitions/schemaVersion"}}}`
+		broken      = `{"definitions": {"schemaVersion": {"$ref": "missing.json#/definitions/schemaVersion"}}}`
+	)
+	schemaFiles := map[string]string{
+		"definitions.json":    definitions,
+		"nested/version.json": version,
+		"broken.json":         broken,
+	}
+
+	schemaDir, err := ioutil.TempDir("", "devfile-schema")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(schemaDir)
+	// the in-memory schema directory does not exist on the OS filesystem
+	inMemoryFs := filesystem.NewFakeFs()
+	inMemorySchemaDir := filepath.Join(schemaDir, "in-memory")
+	for name, content := range schemaFiles {
+		for _, fs := range []filesystem.Filesystem{filesystem.DefaultFs{}, inMemoryFs} {
+			dir := schemaDir
+			if fs == inMemoryFs {
+				dir = inMemorySchemaDir
+			}
+			if err = fs.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+				t.Fatalf("failed to create schema directory: %v", err)
+			}
+			if err = fs.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write schema file %s: %v", name, err)
+			}
+		}
+	}
+
+	invalidSchemaErr := "invalid devfile schema. errors :\n*.*schemaVersion: Does not match pattern"
+	missingRefErr := "unable to resolve \\$ref missing.json#/definitions/schemaVersion in custom devfile schema"
+	nestedMissingRefErr := "unable to resolve \\$ref missing.json#/definitions/schemaVersion in custom devfile schema document file://.*/broken.json"
+
+	tests := []struct {
+		name       string
+		schema     string
+		rawContent string
+		inMemory   bool
+		wantErr    *string
+	}{
+		{
+			name:       "relative $ref resolved against the base URI",
+			schema:     customSchema,
+			rawContent: `{"schemaVersion": "2.2.0"}`,
+		},
+		{
+			name:       "devfile not matching the relative $ref definition",
+			schema:     customSchema,
+			rawContent: `{"schemaVersion": "2.1.0"}`,
+			wantErr:    &invalidSchemaErr,
+		},
+		{
+			name:       "unresolved relative $ref",
+			schema:     customSchemaMissingRef,
+			rawContent: `{"schemaVersion": "2.2.0"}`,
+			wantErr:    &missingRefErr,
+		},
+		{
+			name:       "relative $refs of a referenced document resolved against the document",
+			schema:     customSchemaNestedRef,
+			rawContent: `{"schemaVersion": "2.1.0"}`,
+			wantErr:    &invalidSchemaErr,
+		},
+		{
+			name:       "unresolved relative $ref of a referenced document",
+			schema:     customSchemaNestedMissingRef,
+			rawContent: `{"schemaVersion": "2.2.0"}`,
+			wantErr:    &nestedMissingRefErr,
+		},
+		{
+			name:       "relative $refs resolved against a directory of the devfile context filesystem",
+			schema:     customSchemaNestedRef,
+			rawContent: `{"schemaVersion": "2.2.0"}`,
+			inMemory:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DevfileCtx{
+				apiVersion: "2.2.0",
+				rawContent: []byte(tt.rawContent),
+			}
+			if tt.inMemory {
+				d.SetFilesystem(inMemoryFs)
+				d.SetCustomJSONSchema(tt.schema, inMemorySchemaDir)
+			} else {
+				d.SetCustomJSONSchema(tt.schema, schemaDir)
+			}
+			err := d.SetDevfileJSONSchema()
+			if err != nil {
+				t.Errorf("TestValidateDevfileCustomSchema() unexpected error: '%v'", err)
+			}
+			assert.Equal(t, tt.schema, d.jsonSchema, "TestValidateDevfileCustomSchema(): custom schema should not be overridden")
+
+			err = d.ValidateDevfileSchema()
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateDevfileCustomSchema() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestValidateDevfileCustomSchema(): Error message should match")
+			}
+		})
+	}
+}
+
 func validJsonRawContent200() []byte {
 	return []byte(validJson200)
 }
@@ -18,6 +18,7 @@ package data
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"k8s.io/klog"
@@ -34,8 +35,7 @@ func NewDevfileData(version string) (obj DevfileData, err error) {
 	// Fetch devfile struct type from map
 	devfileType, ok := apiVersionToDevfileStruct[supportedApiVersion(version)]
 	if !ok {
-		errMsg := fmt.Sprintf("devfile type not present for apiVersion '%s'", version)
-		return obj, fmt.Errorf(errMsg)
+		return obj, unsupportedApiVersionError(version)
 	}
 
 	return reflect.New(devfileType).Interface().(DevfileData), nil
@@ -47,11 +47,7 @@ func GetDevfileJSONSchema(version string) (string, error) {
 	// Fetch json schema from the devfileApiVersionToJSONSchema map
 	schema, ok := devfileApiVersionToJSONSchema[supportedApiVersion(version)]
 	if !ok {
-		var supportedVersions []string
-		for version := range devfileApiVersionToJSONSchema {
-			supportedVersions = append(supportedVersions, string(version))
-		}
-		return "", fmt.Errorf("unable to find schema for version %q. The parser supports devfile schema for version %s", version, strings.Join(supportedVersions, ", "))
+		return "", unsupportedApiVersionError(version)
 	}
 	klog.V(4).Infof("devfile apiVersion '%s' is supported", version)
 
@@ -63,3 +59,18 @@ func GetDevfileJSONSchema(version string) (string, error) {
 func IsApiVersionSupported(version string) bool {
 	return apiVersionToDevfileStruct[supportedApiVersion(version)] != nil
 }
+
+// SupportedApiVersions returns the devfile API versions supported by the library, sorted
+func SupportedApiVersions() []string {
+	var versions []string
+	for version := range apiVersionToDevfileStruct {
+		versions = append(versions, version.String())
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// unsupportedApiVersionError returns the error of an unsupported devfile API version, listing the supported versions
+func unsupportedApiVersionError(version string) error {
+	return fmt.Errorf("devfile apiVersion %q is not supported, the supported apiVersions are: %s", version, strings.Join(SupportedApiVersions(), ", "))
+}
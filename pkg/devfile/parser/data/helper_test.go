@@ -90,6 +90,21 @@ func TestGetDevfileJSONSchema(t *testing.T) {
 			t.Errorf("expected an error, didn't get one")
 		}
 	})
+
+	t.Run("unsupported devfile apiVersion lists the supported versions", func(t *testing.T) {
+
+		var (
+			version = "2.3.0"
+			want    = `devfile apiVersion "2.3.0" is not supported, the supported apiVersions are: 2.0.0, 2.1.0, 2.2.0, v1alpha2`
+			_, err  = GetDevfileJSONSchema(version)
+		)
+
+		if err == nil {
+			t.Errorf("expected an error, didn't get one")
+		} else if err.Error() != want {
+			t.Errorf("want: '%s', got: '%s'", want, err.Error())
+		}
+	})
 }
 
 func TestIsApiVersionSupported(t *testing.T) {
@@ -120,3 +135,15 @@ func TestIsApiVersionSupported(t *testing.T) {
 		}
 	})
 }
+
+func TestSupportedApiVersions(t *testing.T) {
+
+	var (
+		want = []string{"2.0.0", "2.1.0", "2.2.0", "v1alpha2"}
+		got  = SupportedApiVersions()
+	)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: '%v', got: '%v'", want, got)
+	}
+}
@@ -32,14 +32,35 @@ type DevfileData interface {
 
 	GetSchemaVersion() string
 	SetSchemaVersion(version string)
+	UpgradeSchemaVersion(target string) error
 	GetMetadata() devfilepkg.DevfileMetadata
 	SetMetadata(metadata devfilepkg.DevfileMetadata)
+	SetMetadataName(name string)
+	SetMetadataVersion(version string)
+	SetMetadataDisplayName(displayName string)
+	SetProjectType(projectType string)
+	SetLanguage(language string)
+	GetMetadataTags() []string
+	AddMetadataTag(tag string)
+	RemoveMetadataTag(tag string) bool
 
 	// top-level attributes related method
 
 	GetAttributes() (attributes.Attributes, error)
 	AddAttributes(key string, value interface{}) error
 	UpdateAttributes(key string, value interface{}) error
+	GetAttributeString(key string) (string, error)
+	GetAttributeBool(key string) (bool, error)
+	GetAttributeNumber(key string) (float64, error)
+	SetAttribute(key string, value interface{}) error
+	GetAllAttributeKeys() []string
+	StripAttributesByPrefix(prefix string) int
+
+	// top-level variables related method
+
+	GetVariables() map[string]string
+	AddVariable(name, value string, overwrite bool) error
+	RemoveVariable(name string) error
 
 	// parent related methods
 
@@ -49,15 +70,30 @@ type DevfileData interface {
 	// event related methods
 
 	GetEvents() v1.Events
+	GetEventsByType(eventType string) ([]string, error)
+	AddEventsByType(eventType string, ids ...string) error
+	RemoveEventsByType(eventType string, ids ...string) error
 	AddEvents(events v1.Events) error
 	UpdateEvents(postStart, postStop, preStart, preStop []string)
 
 	// component related methods
 
 	GetComponents(common.DevfileOptions) ([]v1.Component, error)
+	GetComponentByName(name string) (v1.Component, error)
+	GetContainerComponents(common.DevfileOptions) ([]v1.Component, error)
+	GetVolumeComponents(common.DevfileOptions) ([]v1.Component, error)
+	GetKubernetesComponents(common.DevfileOptions) ([]v1.Component, error)
+	GetImageComponents(common.DevfileOptions) ([]v1.Component, error)
 	AddComponents(components []v1.Component) error
 	UpdateComponent(component v1.Component) error
 	DeleteComponent(name string) error
+	GetAutoBuildImageComponents() []v1.Component
+	GetDeployByDefaultComponents() []v1.Component
+	NormalizeImageReferences(mode common.ImageNormMode) error
+	GetContainersWithoutEndpoints() []string
+	GetEndpointAnnotations(componentName, endpointName string) (map[string]string, error)
+	GetEffectiveExposure(componentName, endpointName string) (v1.EndpointExposure, error)
+	GetComponentHealthSettings(componentName string) (common.HealthSettings, error)
 
 	// project related methods
 
@@ -69,6 +105,7 @@ type DevfileData interface {
 	// starter projects related commands
 
 	GetStarterProjects(common.DevfileOptions) ([]v1.StarterProject, error)
+	GetStarterProjectByName(name string) (v1.StarterProject, error)
 	AddStarterProjects(projects []v1.StarterProject) error
 	UpdateStarterProject(project v1.StarterProject) error
 	DeleteStarterProject(name string) error
@@ -76,15 +113,30 @@ type DevfileData interface {
 	// command related methods
 
 	GetCommands(common.DevfileOptions) ([]v1.Command, error)
+	GetCommandByName(id string) (v1.Command, error)
+	GetExecCommands(common.DevfileOptions) ([]v1.Command, error)
+	GetApplyCommands(common.DevfileOptions) ([]v1.Command, error)
+	GetCompositeCommands(common.DevfileOptions) ([]v1.Command, error)
+	GetDefaultCommand(groupKind v1.CommandGroupKind) (v1.Command, error)
+	SetDefaultCommand(commandId string) error
 	AddCommands(commands []v1.Command) error
 	UpdateCommand(command v1.Command) error
 	DeleteCommand(id string) error
+	GetCommandGraph() (common.CommandGraph, error)
+	GetCommandsGroupedByComponent() map[string][]v1.Command
+	GetEffectiveWorkingDir(commandID string) (string, error)
+	GetCommandEnvConflicts() map[string][]string
+	GetComponentsTouchedByCommand(commandID string) ([]string, error)
+	GetCommandShell(commandID string) (string, error)
 
 	// volume mount related methods
 
 	AddVolumeMounts(containerName string, volumeMounts []v1.VolumeMount) error
+	UpdateVolumeMount(componentName, mountName, newPath string) error
 	DeleteVolumeMount(name string) error
-	GetVolumeMountPaths(mountName, containerName string) ([]string, error)
+	GetVolumeMountPaths(mountName, containerName string, options ...common.DevfileOptions) ([]string, error)
+	GetVolumeMounts(componentName string, options ...common.DevfileOptions) ([]v1.VolumeMount, error)
+	RenameVolume(oldName, newName string) error
 
 	// workspace related methods
 
@@ -92,6 +144,8 @@ type DevfileData interface {
 	SetDevfileWorkspaceSpecContent(content v1.DevWorkspaceTemplateSpecContent)
 	GetDevfileWorkspaceSpec() *v1.DevWorkspaceTemplateSpec
 	SetDevfileWorkspaceSpec(spec v1.DevWorkspaceTemplateSpec)
+	ToDevWorkspaceTemplateSpec() (v1.DevWorkspaceTemplateSpec, error)
+	Summary() common.DevfileSummary
 
 	// utils
 
@@ -102,5 +156,11 @@ type DevfileData interface {
 	RemoveEnvVars(containerEnvMap map[string][]string) error
 	SetPorts(containerPortsMap map[string][]string) error
 	AddEnvVars(containerEnvMap map[string][]v1.EnvVar) error
+	AddContainerEnvVars(componentName string, envVars []v1.EnvVar) error
+	RemoveContainerEnvVars(componentName string, names []string) error
+	AddEndpoints(componentName string, endpoints []v1.Endpoint) error
+	RemoveEndpoint(componentName, endpointName string) error
 	RemovePorts(containerPortsMap map[string][]string) error
+	GetImagePullPolicy(componentName string) (string, error)
+	SetImagePullPolicy(componentName, policy string) error
 }
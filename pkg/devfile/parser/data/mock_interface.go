@@ -79,6 +79,48 @@ func (mr *MockDevfileDataMockRecorder) AddComponents(components interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComponents", reflect.TypeOf((*MockDevfileData)(nil).AddComponents), components)
 }
 
+// AddContainerEnvVars mocks base method.
+func (m *MockDevfileData) AddContainerEnvVars(componentName string, envVars []v1alpha2.EnvVar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddContainerEnvVars", componentName, envVars)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddContainerEnvVars indicates an expected call of AddContainerEnvVars.
+func (mr *MockDevfileDataMockRecorder) AddContainerEnvVars(componentName, envVars interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContainerEnvVars", reflect.TypeOf((*MockDevfileData)(nil).AddContainerEnvVars), componentName, envVars)
+}
+
+// AddEndpoints mocks base method.
+func (m *MockDevfileData) AddEndpoints(componentName string, endpoints []v1alpha2.Endpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEndpoints", componentName, endpoints)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEndpoints indicates an expected call of AddEndpoints.
+func (mr *MockDevfileDataMockRecorder) AddEndpoints(componentName, endpoints interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEndpoints", reflect.TypeOf((*MockDevfileData)(nil).AddEndpoints), componentName, endpoints)
+}
+
+// AddEnvVars mocks base method.
+func (m *MockDevfileData) AddEnvVars(containerEnvMap map[string][]v1alpha2.EnvVar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEnvVars", containerEnvMap)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEnvVars indicates an expected call of AddEnvVars.
+func (mr *MockDevfileDataMockRecorder) AddEnvVars(containerEnvMap interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEnvVars", reflect.TypeOf((*MockDevfileData)(nil).AddEnvVars), containerEnvMap)
+}
+
 // AddEvents mocks base method.
 func (m *MockDevfileData) AddEvents(events v1alpha2.Events) error {
 	m.ctrl.T.Helper()
@@ -93,6 +135,37 @@ func (mr *MockDevfileDataMockRecorder) AddEvents(events interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvents", reflect.TypeOf((*MockDevfileData)(nil).AddEvents), events)
 }
 
+// AddEventsByType mocks base method.
+func (m *MockDevfileData) AddEventsByType(eventType string, ids ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{eventType}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddEventsByType", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEventsByType indicates an expected call of AddEventsByType.
+func (mr *MockDevfileDataMockRecorder) AddEventsByType(eventType interface{}, ids ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{eventType}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEventsByType", reflect.TypeOf((*MockDevfileData)(nil).AddEventsByType), varargs...)
+}
+
+// AddMetadataTag mocks base method.
+func (m *MockDevfileData) AddMetadataTag(tag string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddMetadataTag", tag)
+}
+
+// AddMetadataTag indicates an expected call of AddMetadataTag.
+func (mr *MockDevfileDataMockRecorder) AddMetadataTag(tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMetadataTag", reflect.TypeOf((*MockDevfileData)(nil).AddMetadataTag), tag)
+}
+
 // AddProjects mocks base method.
 func (m *MockDevfileData) AddProjects(projects []v1alpha2.Project) error {
 	m.ctrl.T.Helper()
@@ -121,6 +194,20 @@ func (mr *MockDevfileDataMockRecorder) AddStarterProjects(projects interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStarterProjects", reflect.TypeOf((*MockDevfileData)(nil).AddStarterProjects), projects)
 }
 
+// AddVariable mocks base method.
+func (m *MockDevfileData) AddVariable(name, value string, overwrite bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVariable", name, value, overwrite)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVariable indicates an expected call of AddVariable.
+func (mr *MockDevfileDataMockRecorder) AddVariable(name, value, overwrite interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVariable", reflect.TypeOf((*MockDevfileData)(nil).AddVariable), name, value, overwrite)
+}
+
 // AddVolumeMounts mocks base method.
 func (m *MockDevfileData) AddVolumeMounts(containerName string, volumeMounts []v1alpha2.VolumeMount) error {
 	m.ctrl.T.Helper()
@@ -205,367 +292,962 @@ func (mr *MockDevfileDataMockRecorder) DeleteVolumeMount(name interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVolumeMount", reflect.TypeOf((*MockDevfileData)(nil).DeleteVolumeMount), name)
 }
 
-// GetAttributes mocks base method.
-func (m *MockDevfileData) GetAttributes() (attributes.Attributes, error) {
+// GetAllAttributeKeys mocks base method.
+func (m *MockDevfileData) GetAllAttributeKeys() []string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAttributes")
-	ret0, _ := ret[0].(attributes.Attributes)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetAllAttributeKeys")
+	ret0, _ := ret[0].([]string)
+	return ret0
 }
 
-// GetAttributes indicates an expected call of GetAttributes.
-func (mr *MockDevfileDataMockRecorder) GetAttributes() *gomock.Call {
+// GetAllAttributeKeys indicates an expected call of GetAllAttributeKeys.
+func (mr *MockDevfileDataMockRecorder) GetAllAttributeKeys() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributes", reflect.TypeOf((*MockDevfileData)(nil).GetAttributes))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAttributeKeys", reflect.TypeOf((*MockDevfileData)(nil).GetAllAttributeKeys))
 }
 
-// GetCommands mocks base method.
-func (m *MockDevfileData) GetCommands(arg0 common.DevfileOptions) ([]v1alpha2.Command, error) {
+// GetApplyCommands mocks base method.
+func (m *MockDevfileData) GetApplyCommands(arg0 common.DevfileOptions) ([]v1alpha2.Command, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommands", arg0)
+	ret := m.ctrl.Call(m, "GetApplyCommands", arg0)
 	ret0, _ := ret[0].([]v1alpha2.Command)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCommands indicates an expected call of GetCommands.
-func (mr *MockDevfileDataMockRecorder) GetCommands(arg0 interface{}) *gomock.Call {
+// GetApplyCommands indicates an expected call of GetApplyCommands.
+func (mr *MockDevfileDataMockRecorder) GetApplyCommands(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommands", reflect.TypeOf((*MockDevfileData)(nil).GetCommands), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplyCommands", reflect.TypeOf((*MockDevfileData)(nil).GetApplyCommands), arg0)
 }
 
-// GetComponents mocks base method.
-func (m *MockDevfileData) GetComponents(arg0 common.DevfileOptions) ([]v1alpha2.Component, error) {
+// GetAttributeBool mocks base method.
+func (m *MockDevfileData) GetAttributeBool(key string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetComponents", arg0)
-	ret0, _ := ret[0].([]v1alpha2.Component)
+	ret := m.ctrl.Call(m, "GetAttributeBool", key)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetComponents indicates an expected call of GetComponents.
-func (mr *MockDevfileDataMockRecorder) GetComponents(arg0 interface{}) *gomock.Call {
+// GetAttributeBool indicates an expected call of GetAttributeBool.
+func (mr *MockDevfileDataMockRecorder) GetAttributeBool(key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponents", reflect.TypeOf((*MockDevfileData)(nil).GetComponents), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributeBool", reflect.TypeOf((*MockDevfileData)(nil).GetAttributeBool), key)
 }
 
-// GetDevfileContainerComponents mocks base method.
-func (m *MockDevfileData) GetDevfileContainerComponents(arg0 common.DevfileOptions) ([]v1alpha2.Component, error) {
+// GetAttributeNumber mocks base method.
+func (m *MockDevfileData) GetAttributeNumber(key string) (float64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDevfileContainerComponents", arg0)
-	ret0, _ := ret[0].([]v1alpha2.Component)
+	ret := m.ctrl.Call(m, "GetAttributeNumber", key)
+	ret0, _ := ret[0].(float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDevfileContainerComponents indicates an expected call of GetDevfileContainerComponents.
-func (mr *MockDevfileDataMockRecorder) GetDevfileContainerComponents(arg0 interface{}) *gomock.Call {
+// GetAttributeNumber indicates an expected call of GetAttributeNumber.
+func (mr *MockDevfileDataMockRecorder) GetAttributeNumber(key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevfileContainerComponents", reflect.TypeOf((*MockDevfileData)(nil).GetDevfileContainerComponents), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributeNumber", reflect.TypeOf((*MockDevfileData)(nil).GetAttributeNumber), key)
 }
 
-// GetDevfileVolumeComponents mocks base method.
-func (m *MockDevfileData) GetDevfileVolumeComponents(arg0 common.DevfileOptions) ([]v1alpha2.Component, error) {
+// GetAttributeString mocks base method.
+func (m *MockDevfileData) GetAttributeString(key string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDevfileVolumeComponents", arg0)
-	ret0, _ := ret[0].([]v1alpha2.Component)
+	ret := m.ctrl.Call(m, "GetAttributeString", key)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDevfileVolumeComponents indicates an expected call of GetDevfileVolumeComponents.
-func (mr *MockDevfileDataMockRecorder) GetDevfileVolumeComponents(arg0 interface{}) *gomock.Call {
+// GetAttributeString indicates an expected call of GetAttributeString.
+func (mr *MockDevfileDataMockRecorder) GetAttributeString(key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevfileVolumeComponents", reflect.TypeOf((*MockDevfileData)(nil).GetDevfileVolumeComponents), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributeString", reflect.TypeOf((*MockDevfileData)(nil).GetAttributeString), key)
 }
 
-// GetDevfileWorkspaceSpec mocks base method.
-func (m *MockDevfileData) GetDevfileWorkspaceSpec() *v1alpha2.DevWorkspaceTemplateSpec {
+// GetAttributes mocks base method.
+func (m *MockDevfileData) GetAttributes() (attributes.Attributes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDevfileWorkspaceSpec")
-	ret0, _ := ret[0].(*v1alpha2.DevWorkspaceTemplateSpec)
-	return ret0
+	ret := m.ctrl.Call(m, "GetAttributes")
+	ret0, _ := ret[0].(attributes.Attributes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetDevfileWorkspaceSpec indicates an expected call of GetDevfileWorkspaceSpec.
-func (mr *MockDevfileDataMockRecorder) GetDevfileWorkspaceSpec() *gomock.Call {
+// GetAttributes indicates an expected call of GetAttributes.
+func (mr *MockDevfileDataMockRecorder) GetAttributes() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevfileWorkspaceSpec", reflect.TypeOf((*MockDevfileData)(nil).GetDevfileWorkspaceSpec))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributes", reflect.TypeOf((*MockDevfileData)(nil).GetAttributes))
 }
 
-// GetDevfileWorkspaceSpecContent mocks base method.
-func (m *MockDevfileData) GetDevfileWorkspaceSpecContent() *v1alpha2.DevWorkspaceTemplateSpecContent {
+// GetAutoBuildImageComponents mocks base method.
+func (m *MockDevfileData) GetAutoBuildImageComponents() []v1alpha2.Component {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDevfileWorkspaceSpecContent")
-	ret0, _ := ret[0].(*v1alpha2.DevWorkspaceTemplateSpecContent)
+	ret := m.ctrl.Call(m, "GetAutoBuildImageComponents")
+	ret0, _ := ret[0].([]v1alpha2.Component)
 	return ret0
 }
 
-// GetDevfileWorkspaceSpecContent indicates an expected call of GetDevfileWorkspaceSpecContent.
-func (mr *MockDevfileDataMockRecorder) GetDevfileWorkspaceSpecContent() *gomock.Call {
+// GetAutoBuildImageComponents indicates an expected call of GetAutoBuildImageComponents.
+func (mr *MockDevfileDataMockRecorder) GetAutoBuildImageComponents() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevfileWorkspaceSpecContent", reflect.TypeOf((*MockDevfileData)(nil).GetDevfileWorkspaceSpecContent))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutoBuildImageComponents", reflect.TypeOf((*MockDevfileData)(nil).GetAutoBuildImageComponents))
 }
 
-// GetEvents mocks base method.
-func (m *MockDevfileData) GetEvents() v1alpha2.Events {
+// GetCommandByName mocks base method.
+func (m *MockDevfileData) GetCommandByName(id string) (v1alpha2.Command, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEvents")
-	ret0, _ := ret[0].(v1alpha2.Events)
-	return ret0
+	ret := m.ctrl.Call(m, "GetCommandByName", id)
+	ret0, _ := ret[0].(v1alpha2.Command)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetEvents indicates an expected call of GetEvents.
-func (mr *MockDevfileDataMockRecorder) GetEvents() *gomock.Call {
+// GetCommandByName indicates an expected call of GetCommandByName.
+func (mr *MockDevfileDataMockRecorder) GetCommandByName(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockDevfileData)(nil).GetEvents))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommandByName", reflect.TypeOf((*MockDevfileData)(nil).GetCommandByName), id)
 }
 
-// GetMetadata mocks base method.
-func (m *MockDevfileData) GetMetadata() devfile.DevfileMetadata {
+// GetCommandEnvConflicts mocks base method.
+func (m *MockDevfileData) GetCommandEnvConflicts() map[string][]string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMetadata")
-	ret0, _ := ret[0].(devfile.DevfileMetadata)
+	ret := m.ctrl.Call(m, "GetCommandEnvConflicts")
+	ret0, _ := ret[0].(map[string][]string)
 	return ret0
 }
 
-// GetMetadata indicates an expected call of GetMetadata.
-func (mr *MockDevfileDataMockRecorder) GetMetadata() *gomock.Call {
+// GetCommandEnvConflicts indicates an expected call of GetCommandEnvConflicts.
+func (mr *MockDevfileDataMockRecorder) GetCommandEnvConflicts() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockDevfileData)(nil).GetMetadata))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommandEnvConflicts", reflect.TypeOf((*MockDevfileData)(nil).GetCommandEnvConflicts))
 }
 
-// GetParent mocks base method.
-func (m *MockDevfileData) GetParent() *v1alpha2.Parent {
+// GetCommandGraph mocks base method.
+func (m *MockDevfileData) GetCommandGraph() (common.CommandGraph, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetParent")
-	ret0, _ := ret[0].(*v1alpha2.Parent)
-	return ret0
+	ret := m.ctrl.Call(m, "GetCommandGraph")
+	ret0, _ := ret[0].(common.CommandGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetParent indicates an expected call of GetParent.
-func (mr *MockDevfileDataMockRecorder) GetParent() *gomock.Call {
+// GetCommandGraph indicates an expected call of GetCommandGraph.
+func (mr *MockDevfileDataMockRecorder) GetCommandGraph() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParent", reflect.TypeOf((*MockDevfileData)(nil).GetParent))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommandGraph", reflect.TypeOf((*MockDevfileData)(nil).GetCommandGraph))
 }
 
-// GetProjects mocks base method.
-func (m *MockDevfileData) GetProjects(arg0 common.DevfileOptions) ([]v1alpha2.Project, error) {
+// GetCommandShell mocks base method.
+func (m *MockDevfileData) GetCommandShell(commandID string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProjects", arg0)
-	ret0, _ := ret[0].([]v1alpha2.Project)
+	ret := m.ctrl.Call(m, "GetCommandShell", commandID)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProjects indicates an expected call of GetProjects.
-func (mr *MockDevfileDataMockRecorder) GetProjects(arg0 interface{}) *gomock.Call {
+// GetCommandShell indicates an expected call of GetCommandShell.
+func (mr *MockDevfileDataMockRecorder) GetCommandShell(commandID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockDevfileData)(nil).GetProjects), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommandShell", reflect.TypeOf((*MockDevfileData)(nil).GetCommandShell), commandID)
 }
 
-// GetSchemaVersion mocks base method.
-func (m *MockDevfileData) GetSchemaVersion() string {
+// GetCommands mocks base method.
+func (m *MockDevfileData) GetCommands(arg0 common.DevfileOptions) ([]v1alpha2.Command, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSchemaVersion")
-	ret0, _ := ret[0].(string)
-	return ret0
+	ret := m.ctrl.Call(m, "GetCommands", arg0)
+	ret0, _ := ret[0].([]v1alpha2.Command)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetSchemaVersion indicates an expected call of GetSchemaVersion.
-func (mr *MockDevfileDataMockRecorder) GetSchemaVersion() *gomock.Call {
+// GetCommands indicates an expected call of GetCommands.
+func (mr *MockDevfileDataMockRecorder) GetCommands(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaVersion", reflect.TypeOf((*MockDevfileData)(nil).GetSchemaVersion))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommands", reflect.TypeOf((*MockDevfileData)(nil).GetCommands), arg0)
 }
 
-// GetStarterProjects mocks base method.
-func (m *MockDevfileData) GetStarterProjects(arg0 common.DevfileOptions) ([]v1alpha2.StarterProject, error) {
+// GetCommandsGroupedByComponent mocks base method.
+func (m *MockDevfileData) GetCommandsGroupedByComponent() map[string][]v1alpha2.Command {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStarterProjects", arg0)
-	ret0, _ := ret[0].([]v1alpha2.StarterProject)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetCommandsGroupedByComponent")
+	ret0, _ := ret[0].(map[string][]v1alpha2.Command)
+	return ret0
 }
 
-// GetStarterProjects indicates an expected call of GetStarterProjects.
-func (mr *MockDevfileDataMockRecorder) GetStarterProjects(arg0 interface{}) *gomock.Call {
+// GetCommandsGroupedByComponent indicates an expected call of GetCommandsGroupedByComponent.
+func (mr *MockDevfileDataMockRecorder) GetCommandsGroupedByComponent() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStarterProjects", reflect.TypeOf((*MockDevfileData)(nil).GetStarterProjects), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommandsGroupedByComponent", reflect.TypeOf((*MockDevfileData)(nil).GetCommandsGroupedByComponent))
 }
 
-// GetVolumeMountPaths mocks base method.
-func (m *MockDevfileData) GetVolumeMountPaths(mountName, containerName string) ([]string, error) {
+// GetComponentByName mocks base method.
+func (m *MockDevfileData) GetComponentByName(name string) (v1alpha2.Component, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVolumeMountPaths", mountName, containerName)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "GetComponentByName", name)
+	ret0, _ := ret[0].(v1alpha2.Component)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetVolumeMountPaths indicates an expected call of GetVolumeMountPaths.
-func (mr *MockDevfileDataMockRecorder) GetVolumeMountPaths(mountName, containerName interface{}) *gomock.Call {
+// GetComponentByName indicates an expected call of GetComponentByName.
+func (mr *MockDevfileDataMockRecorder) GetComponentByName(name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolumeMountPaths", reflect.TypeOf((*MockDevfileData)(nil).GetVolumeMountPaths), mountName, containerName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponentByName", reflect.TypeOf((*MockDevfileData)(nil).GetComponentByName), name)
 }
 
-// SetDevfileWorkspaceSpec mocks base method.
-func (m *MockDevfileData) SetDevfileWorkspaceSpec(spec v1alpha2.DevWorkspaceTemplateSpec) {
+// GetComponentHealthSettings mocks base method.
+func (m *MockDevfileData) GetComponentHealthSettings(componentName string) (common.HealthSettings, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetDevfileWorkspaceSpec", spec)
+	ret := m.ctrl.Call(m, "GetComponentHealthSettings", componentName)
+	ret0, _ := ret[0].(common.HealthSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SetDevfileWorkspaceSpec indicates an expected call of SetDevfileWorkspaceSpec.
-func (mr *MockDevfileDataMockRecorder) SetDevfileWorkspaceSpec(spec interface{}) *gomock.Call {
+// GetComponentHealthSettings indicates an expected call of GetComponentHealthSettings.
+func (mr *MockDevfileDataMockRecorder) GetComponentHealthSettings(componentName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDevfileWorkspaceSpec", reflect.TypeOf((*MockDevfileData)(nil).SetDevfileWorkspaceSpec), spec)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponentHealthSettings", reflect.TypeOf((*MockDevfileData)(nil).GetComponentHealthSettings), componentName)
 }
 
-// SetDevfileWorkspaceSpecContent mocks base method.
-func (m *MockDevfileData) SetDevfileWorkspaceSpecContent(content v1alpha2.DevWorkspaceTemplateSpecContent) {
+// GetComponents mocks base method.
+func (m *MockDevfileData) GetComponents(arg0 common.DevfileOptions) ([]v1alpha2.Component, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetDevfileWorkspaceSpecContent", content)
+	ret := m.ctrl.Call(m, "GetComponents", arg0)
+	ret0, _ := ret[0].([]v1alpha2.Component)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SetDevfileWorkspaceSpecContent indicates an expected call of SetDevfileWorkspaceSpecContent.
-func (mr *MockDevfileDataMockRecorder) SetDevfileWorkspaceSpecContent(content interface{}) *gomock.Call {
+// GetComponents indicates an expected call of GetComponents.
+func (mr *MockDevfileDataMockRecorder) GetComponents(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDevfileWorkspaceSpecContent", reflect.TypeOf((*MockDevfileData)(nil).SetDevfileWorkspaceSpecContent), content)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponents", reflect.TypeOf((*MockDevfileData)(nil).GetComponents), arg0)
 }
 
-// SetMetadata mocks base method.
-func (m *MockDevfileData) SetMetadata(metadata devfile.DevfileMetadata) {
+// GetComponentsTouchedByCommand mocks base method.
+func (m *MockDevfileData) GetComponentsTouchedByCommand(commandID string) ([]string, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetMetadata", metadata)
+	ret := m.ctrl.Call(m, "GetComponentsTouchedByCommand", commandID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SetMetadata indicates an expected call of SetMetadata.
-func (mr *MockDevfileDataMockRecorder) SetMetadata(metadata interface{}) *gomock.Call {
+// GetComponentsTouchedByCommand indicates an expected call of GetComponentsTouchedByCommand.
+func (mr *MockDevfileDataMockRecorder) GetComponentsTouchedByCommand(commandID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMetadata", reflect.TypeOf((*MockDevfileData)(nil).SetMetadata), metadata)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponentsTouchedByCommand", reflect.TypeOf((*MockDevfileData)(nil).GetComponentsTouchedByCommand), commandID)
 }
 
-// SetParent mocks base method.
-func (m *MockDevfileData) SetParent(parent *v1alpha2.Parent) {
+// GetCompositeCommands mocks base method.
+func (m *MockDevfileData) GetCompositeCommands(arg0 common.DevfileOptions) ([]v1alpha2.Command, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetParent", parent)
+	ret := m.ctrl.Call(m, "GetCompositeCommands", arg0)
+	ret0, _ := ret[0].([]v1alpha2.Command)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SetParent indicates an expected call of SetParent.
-func (mr *MockDevfileDataMockRecorder) SetParent(parent interface{}) *gomock.Call {
+// GetCompositeCommands indicates an expected call of GetCompositeCommands.
+func (mr *MockDevfileDataMockRecorder) GetCompositeCommands(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParent", reflect.TypeOf((*MockDevfileData)(nil).SetParent), parent)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompositeCommands", reflect.TypeOf((*MockDevfileData)(nil).GetCompositeCommands), arg0)
 }
 
-// SetSchemaVersion mocks base method.
-func (m *MockDevfileData) SetSchemaVersion(version string) {
+// GetContainerComponents mocks base method.
+func (m *MockDevfileData) GetContainerComponents(arg0 common.DevfileOptions) ([]v1alpha2.Component, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetSchemaVersion", version)
+	ret := m.ctrl.Call(m, "GetContainerComponents", arg0)
+	ret0, _ := ret[0].([]v1alpha2.Component)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SetSchemaVersion indicates an expected call of SetSchemaVersion.
-func (mr *MockDevfileDataMockRecorder) SetSchemaVersion(version interface{}) *gomock.Call {
+// GetContainerComponents indicates an expected call of GetContainerComponents.
+func (mr *MockDevfileDataMockRecorder) GetContainerComponents(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchemaVersion", reflect.TypeOf((*MockDevfileData)(nil).SetSchemaVersion), version)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContainerComponents", reflect.TypeOf((*MockDevfileData)(nil).GetContainerComponents), arg0)
 }
 
-// UpdateAttributes mocks base method.
-func (m *MockDevfileData) UpdateAttributes(key string, value interface{}) error {
+// GetContainersWithoutEndpoints mocks base method.
+func (m *MockDevfileData) GetContainersWithoutEndpoints() []string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAttributes", key, value)
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "GetContainersWithoutEndpoints")
+	ret0, _ := ret[0].([]string)
 	return ret0
 }
 
-// UpdateAttributes indicates an expected call of UpdateAttributes.
-func (mr *MockDevfileDataMockRecorder) UpdateAttributes(key, value interface{}) *gomock.Call {
+// GetContainersWithoutEndpoints indicates an expected call of GetContainersWithoutEndpoints.
+func (mr *MockDevfileDataMockRecorder) GetContainersWithoutEndpoints() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttributes", reflect.TypeOf((*MockDevfileData)(nil).UpdateAttributes), key, value)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContainersWithoutEndpoints", reflect.TypeOf((*MockDevfileData)(nil).GetContainersWithoutEndpoints))
 }
 
-// UpdateCommand mocks base method.
-func (m *MockDevfileData) UpdateCommand(command v1alpha2.Command) error {
+// GetDefaultCommand mocks base method.
+func (m *MockDevfileData) GetDefaultCommand(groupKind v1alpha2.CommandGroupKind) (v1alpha2.Command, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCommand", command)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetDefaultCommand", groupKind)
+	ret0, _ := ret[0].(v1alpha2.Command)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateCommand indicates an expected call of UpdateCommand.
-func (mr *MockDevfileDataMockRecorder) UpdateCommand(command interface{}) *gomock.Call {
+// GetDefaultCommand indicates an expected call of GetDefaultCommand.
+func (mr *MockDevfileDataMockRecorder) GetDefaultCommand(groupKind interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCommand", reflect.TypeOf((*MockDevfileData)(nil).UpdateCommand), command)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultCommand", reflect.TypeOf((*MockDevfileData)(nil).GetDefaultCommand), groupKind)
 }
 
-// UpdateComponent mocks base method.
-func (m *MockDevfileData) UpdateComponent(component v1alpha2.Component) error {
+// GetDeployByDefaultComponents mocks base method.
+func (m *MockDevfileData) GetDeployByDefaultComponents() []v1alpha2.Component {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateComponent", component)
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "GetDeployByDefaultComponents")
+	ret0, _ := ret[0].([]v1alpha2.Component)
 	return ret0
 }
 
-// UpdateComponent indicates an expected call of UpdateComponent.
-func (mr *MockDevfileDataMockRecorder) UpdateComponent(component interface{}) *gomock.Call {
+// GetDeployByDefaultComponents indicates an expected call of GetDeployByDefaultComponents.
+func (mr *MockDevfileDataMockRecorder) GetDeployByDefaultComponents() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComponent", reflect.TypeOf((*MockDevfileData)(nil).UpdateComponent), component)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployByDefaultComponents", reflect.TypeOf((*MockDevfileData)(nil).GetDeployByDefaultComponents))
 }
 
-// UpdateEvents mocks base method.
-func (m *MockDevfileData) UpdateEvents(postStart, postStop, preStart, preStop []string) {
+// GetDevfileContainerComponents mocks base method.
+func (m *MockDevfileData) GetDevfileContainerComponents(arg0 common.DevfileOptions) ([]v1alpha2.Component, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevfileContainerComponents", arg0)
+	ret0, _ := ret[0].([]v1alpha2.Component)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevfileContainerComponents indicates an expected call of GetDevfileContainerComponents.
+func (mr *MockDevfileDataMockRecorder) GetDevfileContainerComponents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevfileContainerComponents", reflect.TypeOf((*MockDevfileData)(nil).GetDevfileContainerComponents), arg0)
+}
+
+// GetDevfileVolumeComponents mocks base method.
+func (m *MockDevfileData) GetDevfileVolumeComponents(arg0 common.DevfileOptions) ([]v1alpha2.Component, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevfileVolumeComponents", arg0)
+	ret0, _ := ret[0].([]v1alpha2.Component)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevfileVolumeComponents indicates an expected call of GetDevfileVolumeComponents.
+func (mr *MockDevfileDataMockRecorder) GetDevfileVolumeComponents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevfileVolumeComponents", reflect.TypeOf((*MockDevfileData)(nil).GetDevfileVolumeComponents), arg0)
+}
+
+// GetDevfileWorkspaceSpec mocks base method.
+func (m *MockDevfileData) GetDevfileWorkspaceSpec() *v1alpha2.DevWorkspaceTemplateSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevfileWorkspaceSpec")
+	ret0, _ := ret[0].(*v1alpha2.DevWorkspaceTemplateSpec)
+	return ret0
+}
+
+// GetDevfileWorkspaceSpec indicates an expected call of GetDevfileWorkspaceSpec.
+func (mr *MockDevfileDataMockRecorder) GetDevfileWorkspaceSpec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevfileWorkspaceSpec", reflect.TypeOf((*MockDevfileData)(nil).GetDevfileWorkspaceSpec))
+}
+
+// GetDevfileWorkspaceSpecContent mocks base method.
+func (m *MockDevfileData) GetDevfileWorkspaceSpecContent() *v1alpha2.DevWorkspaceTemplateSpecContent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevfileWorkspaceSpecContent")
+	ret0, _ := ret[0].(*v1alpha2.DevWorkspaceTemplateSpecContent)
+	return ret0
+}
+
+// GetDevfileWorkspaceSpecContent indicates an expected call of GetDevfileWorkspaceSpecContent.
+func (mr *MockDevfileDataMockRecorder) GetDevfileWorkspaceSpecContent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevfileWorkspaceSpecContent", reflect.TypeOf((*MockDevfileData)(nil).GetDevfileWorkspaceSpecContent))
+}
+
+// GetEffectiveExposure mocks base method.
+func (m *MockDevfileData) GetEffectiveExposure(componentName, endpointName string) (v1alpha2.EndpointExposure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectiveExposure", componentName, endpointName)
+	ret0, _ := ret[0].(v1alpha2.EndpointExposure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectiveExposure indicates an expected call of GetEffectiveExposure.
+func (mr *MockDevfileDataMockRecorder) GetEffectiveExposure(componentName, endpointName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectiveExposure", reflect.TypeOf((*MockDevfileData)(nil).GetEffectiveExposure), componentName, endpointName)
+}
+
+// GetEffectiveWorkingDir mocks base method.
+func (m *MockDevfileData) GetEffectiveWorkingDir(commandID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectiveWorkingDir", commandID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectiveWorkingDir indicates an expected call of GetEffectiveWorkingDir.
+func (mr *MockDevfileDataMockRecorder) GetEffectiveWorkingDir(commandID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectiveWorkingDir", reflect.TypeOf((*MockDevfileData)(nil).GetEffectiveWorkingDir), commandID)
+}
+
+// GetEndpointAnnotations mocks base method.
+func (m *MockDevfileData) GetEndpointAnnotations(componentName, endpointName string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndpointAnnotations", componentName, endpointName)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEndpointAnnotations indicates an expected call of GetEndpointAnnotations.
+func (mr *MockDevfileDataMockRecorder) GetEndpointAnnotations(componentName, endpointName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpointAnnotations", reflect.TypeOf((*MockDevfileData)(nil).GetEndpointAnnotations), componentName, endpointName)
+}
+
+// GetEvents mocks base method.
+func (m *MockDevfileData) GetEvents() v1alpha2.Events {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents")
+	ret0, _ := ret[0].(v1alpha2.Events)
+	return ret0
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockDevfileDataMockRecorder) GetEvents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockDevfileData)(nil).GetEvents))
+}
+
+// GetEventsByType mocks base method.
+func (m *MockDevfileData) GetEventsByType(eventType string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsByType", eventType)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventsByType indicates an expected call of GetEventsByType.
+func (mr *MockDevfileDataMockRecorder) GetEventsByType(eventType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsByType", reflect.TypeOf((*MockDevfileData)(nil).GetEventsByType), eventType)
+}
+
+// GetExecCommands mocks base method.
+func (m *MockDevfileData) GetExecCommands(arg0 common.DevfileOptions) ([]v1alpha2.Command, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExecCommands", arg0)
+	ret0, _ := ret[0].([]v1alpha2.Command)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExecCommands indicates an expected call of GetExecCommands.
+func (mr *MockDevfileDataMockRecorder) GetExecCommands(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecCommands", reflect.TypeOf((*MockDevfileData)(nil).GetExecCommands), arg0)
+}
+
+// GetImageComponents mocks base method.
+func (m *MockDevfileData) GetImageComponents(arg0 common.DevfileOptions) ([]v1alpha2.Component, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageComponents", arg0)
+	ret0, _ := ret[0].([]v1alpha2.Component)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImageComponents indicates an expected call of GetImageComponents.
+func (mr *MockDevfileDataMockRecorder) GetImageComponents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageComponents", reflect.TypeOf((*MockDevfileData)(nil).GetImageComponents), arg0)
+}
+
+// GetImagePullPolicy mocks base method.
+func (m *MockDevfileData) GetImagePullPolicy(componentName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImagePullPolicy", componentName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImagePullPolicy indicates an expected call of GetImagePullPolicy.
+func (mr *MockDevfileDataMockRecorder) GetImagePullPolicy(componentName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImagePullPolicy", reflect.TypeOf((*MockDevfileData)(nil).GetImagePullPolicy), componentName)
+}
+
+// GetKubernetesComponents mocks base method.
+func (m *MockDevfileData) GetKubernetesComponents(arg0 common.DevfileOptions) ([]v1alpha2.Component, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKubernetesComponents", arg0)
+	ret0, _ := ret[0].([]v1alpha2.Component)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKubernetesComponents indicates an expected call of GetKubernetesComponents.
+func (mr *MockDevfileDataMockRecorder) GetKubernetesComponents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKubernetesComponents", reflect.TypeOf((*MockDevfileData)(nil).GetKubernetesComponents), arg0)
+}
+
+// GetMetadata mocks base method.
+func (m *MockDevfileData) GetMetadata() devfile.DevfileMetadata {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadata")
+	ret0, _ := ret[0].(devfile.DevfileMetadata)
+	return ret0
+}
+
+// GetMetadata indicates an expected call of GetMetadata.
+func (mr *MockDevfileDataMockRecorder) GetMetadata() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockDevfileData)(nil).GetMetadata))
+}
+
+// GetMetadataTags mocks base method.
+func (m *MockDevfileData) GetMetadataTags() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadataTags")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetMetadataTags indicates an expected call of GetMetadataTags.
+func (mr *MockDevfileDataMockRecorder) GetMetadataTags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadataTags", reflect.TypeOf((*MockDevfileData)(nil).GetMetadataTags))
+}
+
+// GetParent mocks base method.
+func (m *MockDevfileData) GetParent() *v1alpha2.Parent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParent")
+	ret0, _ := ret[0].(*v1alpha2.Parent)
+	return ret0
+}
+
+// GetParent indicates an expected call of GetParent.
+func (mr *MockDevfileDataMockRecorder) GetParent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParent", reflect.TypeOf((*MockDevfileData)(nil).GetParent))
+}
+
+// GetProjects mocks base method.
+func (m *MockDevfileData) GetProjects(arg0 common.DevfileOptions) ([]v1alpha2.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects", arg0)
+	ret0, _ := ret[0].([]v1alpha2.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockDevfileDataMockRecorder) GetProjects(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockDevfileData)(nil).GetProjects), arg0)
+}
+
+// GetSchemaVersion mocks base method.
+func (m *MockDevfileData) GetSchemaVersion() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemaVersion")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSchemaVersion indicates an expected call of GetSchemaVersion.
+func (mr *MockDevfileDataMockRecorder) GetSchemaVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaVersion", reflect.TypeOf((*MockDevfileData)(nil).GetSchemaVersion))
+}
+
+// GetStarterProjectByName mocks base method.
+func (m *MockDevfileData) GetStarterProjectByName(name string) (v1alpha2.StarterProject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStarterProjectByName", name)
+	ret0, _ := ret[0].(v1alpha2.StarterProject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStarterProjectByName indicates an expected call of GetStarterProjectByName.
+func (mr *MockDevfileDataMockRecorder) GetStarterProjectByName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStarterProjectByName", reflect.TypeOf((*MockDevfileData)(nil).GetStarterProjectByName), name)
+}
+
+// GetStarterProjects mocks base method.
+func (m *MockDevfileData) GetStarterProjects(arg0 common.DevfileOptions) ([]v1alpha2.StarterProject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStarterProjects", arg0)
+	ret0, _ := ret[0].([]v1alpha2.StarterProject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStarterProjects indicates an expected call of GetStarterProjects.
+func (mr *MockDevfileDataMockRecorder) GetStarterProjects(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStarterProjects", reflect.TypeOf((*MockDevfileData)(nil).GetStarterProjects), arg0)
+}
+
+// GetVariables mocks base method.
+func (m *MockDevfileData) GetVariables() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariables")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// GetVariables indicates an expected call of GetVariables.
+func (mr *MockDevfileDataMockRecorder) GetVariables() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariables", reflect.TypeOf((*MockDevfileData)(nil).GetVariables))
+}
+
+// GetVolumeComponents mocks base method.
+func (m *MockDevfileData) GetVolumeComponents(arg0 common.DevfileOptions) ([]v1alpha2.Component, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVolumeComponents", arg0)
+	ret0, _ := ret[0].([]v1alpha2.Component)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVolumeComponents indicates an expected call of GetVolumeComponents.
+func (mr *MockDevfileDataMockRecorder) GetVolumeComponents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolumeComponents", reflect.TypeOf((*MockDevfileData)(nil).GetVolumeComponents), arg0)
+}
+
+// GetVolumeMountPaths mocks base method.
+func (m *MockDevfileData) GetVolumeMountPaths(mountName, containerName string, options ...common.DevfileOptions) ([]string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{mountName, containerName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetVolumeMountPaths", varargs...)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVolumeMountPaths indicates an expected call of GetVolumeMountPaths.
+func (mr *MockDevfileDataMockRecorder) GetVolumeMountPaths(mountName, containerName interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{mountName, containerName}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolumeMountPaths", reflect.TypeOf((*MockDevfileData)(nil).GetVolumeMountPaths), varargs...)
+}
+
+// GetVolumeMounts mocks base method.
+func (m *MockDevfileData) GetVolumeMounts(componentName string, options ...common.DevfileOptions) ([]v1alpha2.VolumeMount, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{componentName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetVolumeMounts", varargs...)
+	ret0, _ := ret[0].([]v1alpha2.VolumeMount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVolumeMounts indicates an expected call of GetVolumeMounts.
+func (mr *MockDevfileDataMockRecorder) GetVolumeMounts(componentName interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{componentName}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolumeMounts", reflect.TypeOf((*MockDevfileData)(nil).GetVolumeMounts), varargs...)
+}
+
+// NormalizeImageReferences mocks base method.
+func (m *MockDevfileData) NormalizeImageReferences(mode common.ImageNormMode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormalizeImageReferences", mode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NormalizeImageReferences indicates an expected call of NormalizeImageReferences.
+func (mr *MockDevfileDataMockRecorder) NormalizeImageReferences(mode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormalizeImageReferences", reflect.TypeOf((*MockDevfileData)(nil).NormalizeImageReferences), mode)
+}
+
+// RemoveContainerEnvVars mocks base method.
+func (m *MockDevfileData) RemoveContainerEnvVars(componentName string, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveContainerEnvVars", componentName, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveContainerEnvVars indicates an expected call of RemoveContainerEnvVars.
+func (mr *MockDevfileDataMockRecorder) RemoveContainerEnvVars(componentName, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContainerEnvVars", reflect.TypeOf((*MockDevfileData)(nil).RemoveContainerEnvVars), componentName, names)
+}
+
+// RemoveEndpoint mocks base method.
+func (m *MockDevfileData) RemoveEndpoint(componentName, endpointName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveEndpoint", componentName, endpointName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveEndpoint indicates an expected call of RemoveEndpoint.
+func (mr *MockDevfileDataMockRecorder) RemoveEndpoint(componentName, endpointName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveEndpoint", reflect.TypeOf((*MockDevfileData)(nil).RemoveEndpoint), componentName, endpointName)
+}
+
+// RemoveEnvVars mocks base method.
+func (m *MockDevfileData) RemoveEnvVars(containerEnvMap map[string][]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveEnvVars", containerEnvMap)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveEnvVars indicates an expected call of RemoveEnvVars.
+func (mr *MockDevfileDataMockRecorder) RemoveEnvVars(containerEnvMap interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveEnvVars", reflect.TypeOf((*MockDevfileData)(nil).RemoveEnvVars), containerEnvMap)
+}
+
+// RemoveEventsByType mocks base method.
+func (m *MockDevfileData) RemoveEventsByType(eventType string, ids ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{eventType}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveEventsByType", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveEventsByType indicates an expected call of RemoveEventsByType.
+func (mr *MockDevfileDataMockRecorder) RemoveEventsByType(eventType interface{}, ids ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{eventType}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveEventsByType", reflect.TypeOf((*MockDevfileData)(nil).RemoveEventsByType), varargs...)
+}
+
+// RemoveMetadataTag mocks base method.
+func (m *MockDevfileData) RemoveMetadataTag(tag string) bool {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "UpdateEvents", postStart, postStop, preStart, preStop)
+	ret := m.ctrl.Call(m, "RemoveMetadataTag", tag)
+	ret0, _ := ret[0].(bool)
+	return ret0
 }
 
-// UpdateEvents indicates an expected call of UpdateEvents.
-func (mr *MockDevfileDataMockRecorder) UpdateEvents(postStart, postStop, preStart, preStop interface{}) *gomock.Call {
+// RemoveMetadataTag indicates an expected call of RemoveMetadataTag.
+func (mr *MockDevfileDataMockRecorder) RemoveMetadataTag(tag interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvents", reflect.TypeOf((*MockDevfileData)(nil).UpdateEvents), postStart, postStop, preStart, preStop)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMetadataTag", reflect.TypeOf((*MockDevfileData)(nil).RemoveMetadataTag), tag)
 }
 
-// UpdateProject mocks base method.
-func (m *MockDevfileData) UpdateProject(project v1alpha2.Project) error {
+// RemovePorts mocks base method.
+func (m *MockDevfileData) RemovePorts(containerPortsMap map[string][]string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProject", project)
+	ret := m.ctrl.Call(m, "RemovePorts", containerPortsMap)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateProject indicates an expected call of UpdateProject.
-func (mr *MockDevfileDataMockRecorder) UpdateProject(project interface{}) *gomock.Call {
+// RemovePorts indicates an expected call of RemovePorts.
+func (mr *MockDevfileDataMockRecorder) RemovePorts(containerPortsMap interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockDevfileData)(nil).UpdateProject), project)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePorts", reflect.TypeOf((*MockDevfileData)(nil).RemovePorts), containerPortsMap)
 }
 
-// UpdateStarterProject mocks base method.
-func (m *MockDevfileData) UpdateStarterProject(project v1alpha2.StarterProject) error {
+// RemoveVariable mocks base method.
+func (m *MockDevfileData) RemoveVariable(name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStarterProject", project)
+	ret := m.ctrl.Call(m, "RemoveVariable", name)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateStarterProject indicates an expected call of UpdateStarterProject.
-func (mr *MockDevfileDataMockRecorder) UpdateStarterProject(project interface{}) *gomock.Call {
+// RemoveVariable indicates an expected call of RemoveVariable.
+func (mr *MockDevfileDataMockRecorder) RemoveVariable(name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStarterProject", reflect.TypeOf((*MockDevfileData)(nil).UpdateStarterProject), project)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVariable", reflect.TypeOf((*MockDevfileData)(nil).RemoveVariable), name)
 }
 
-// RemoveEnvVars mocks base method
-func (m *MockDevfileData) RemoveEnvVars(containerEnvMap map[string][]string) error {
+// RenameVolume mocks base method.
+func (m *MockDevfileData) RenameVolume(oldName, newName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveEnvVars", containerEnvMap)
+	ret := m.ctrl.Call(m, "RenameVolume", oldName, newName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RemoveEnvVars indicates an expected call of RemoveEnvVars
-func (mr *MockDevfileDataMockRecorder) RemoveEnvVars(containerEnvMap interface{}) *gomock.Call {
+// RenameVolume indicates an expected call of RenameVolume.
+func (mr *MockDevfileDataMockRecorder) RenameVolume(oldName, newName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveEnvVars", reflect.TypeOf((*MockDevfileData)(nil).RemoveEnvVars), containerEnvMap)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameVolume", reflect.TypeOf((*MockDevfileData)(nil).RenameVolume), oldName, newName)
+}
+
+// SetAttribute mocks base method.
+func (m *MockDevfileData) SetAttribute(key string, value interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAttribute", key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAttribute indicates an expected call of SetAttribute.
+func (mr *MockDevfileDataMockRecorder) SetAttribute(key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttribute", reflect.TypeOf((*MockDevfileData)(nil).SetAttribute), key, value)
+}
+
+// SetDefaultCommand mocks base method.
+func (m *MockDevfileData) SetDefaultCommand(commandId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefaultCommand", commandId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDefaultCommand indicates an expected call of SetDefaultCommand.
+func (mr *MockDevfileDataMockRecorder) SetDefaultCommand(commandId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultCommand", reflect.TypeOf((*MockDevfileData)(nil).SetDefaultCommand), commandId)
+}
+
+// SetDevfileWorkspaceSpec mocks base method.
+func (m *MockDevfileData) SetDevfileWorkspaceSpec(spec v1alpha2.DevWorkspaceTemplateSpec) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDevfileWorkspaceSpec", spec)
+}
+
+// SetDevfileWorkspaceSpec indicates an expected call of SetDevfileWorkspaceSpec.
+func (mr *MockDevfileDataMockRecorder) SetDevfileWorkspaceSpec(spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDevfileWorkspaceSpec", reflect.TypeOf((*MockDevfileData)(nil).SetDevfileWorkspaceSpec), spec)
+}
+
+// SetDevfileWorkspaceSpecContent mocks base method.
+func (m *MockDevfileData) SetDevfileWorkspaceSpecContent(content v1alpha2.DevWorkspaceTemplateSpecContent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDevfileWorkspaceSpecContent", content)
 }
 
-// SetPorts mocks base method
+// SetDevfileWorkspaceSpecContent indicates an expected call of SetDevfileWorkspaceSpecContent.
+func (mr *MockDevfileDataMockRecorder) SetDevfileWorkspaceSpecContent(content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDevfileWorkspaceSpecContent", reflect.TypeOf((*MockDevfileData)(nil).SetDevfileWorkspaceSpecContent), content)
+}
+
+// SetImagePullPolicy mocks base method.
+func (m *MockDevfileData) SetImagePullPolicy(componentName, policy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetImagePullPolicy", componentName, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetImagePullPolicy indicates an expected call of SetImagePullPolicy.
+func (mr *MockDevfileDataMockRecorder) SetImagePullPolicy(componentName, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetImagePullPolicy", reflect.TypeOf((*MockDevfileData)(nil).SetImagePullPolicy), componentName, policy)
+}
+
+// SetLanguage mocks base method.
+func (m *MockDevfileData) SetLanguage(language string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLanguage", language)
+}
+
+// SetLanguage indicates an expected call of SetLanguage.
+func (mr *MockDevfileDataMockRecorder) SetLanguage(language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLanguage", reflect.TypeOf((*MockDevfileData)(nil).SetLanguage), language)
+}
+
+// SetMetadata mocks base method.
+func (m *MockDevfileData) SetMetadata(metadata devfile.DevfileMetadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMetadata", metadata)
+}
+
+// SetMetadata indicates an expected call of SetMetadata.
+func (mr *MockDevfileDataMockRecorder) SetMetadata(metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMetadata", reflect.TypeOf((*MockDevfileData)(nil).SetMetadata), metadata)
+}
+
+// SetMetadataDisplayName mocks base method.
+func (m *MockDevfileData) SetMetadataDisplayName(displayName string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMetadataDisplayName", displayName)
+}
+
+// SetMetadataDisplayName indicates an expected call of SetMetadataDisplayName.
+func (mr *MockDevfileDataMockRecorder) SetMetadataDisplayName(displayName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMetadataDisplayName", reflect.TypeOf((*MockDevfileData)(nil).SetMetadataDisplayName), displayName)
+}
+
+// SetMetadataName mocks base method.
+func (m *MockDevfileData) SetMetadataName(name string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMetadataName", name)
+}
+
+// SetMetadataName indicates an expected call of SetMetadataName.
+func (mr *MockDevfileDataMockRecorder) SetMetadataName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMetadataName", reflect.TypeOf((*MockDevfileData)(nil).SetMetadataName), name)
+}
+
+// SetMetadataVersion mocks base method.
+func (m *MockDevfileData) SetMetadataVersion(version string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMetadataVersion", version)
+}
+
+// SetMetadataVersion indicates an expected call of SetMetadataVersion.
+func (mr *MockDevfileDataMockRecorder) SetMetadataVersion(version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMetadataVersion", reflect.TypeOf((*MockDevfileData)(nil).SetMetadataVersion), version)
+}
+
+// SetParent mocks base method.
+func (m *MockDevfileData) SetParent(parent *v1alpha2.Parent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetParent", parent)
+}
+
+// SetParent indicates an expected call of SetParent.
+func (mr *MockDevfileDataMockRecorder) SetParent(parent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParent", reflect.TypeOf((*MockDevfileData)(nil).SetParent), parent)
+}
+
+// SetPorts mocks base method.
 func (m *MockDevfileData) SetPorts(containerPortsMap map[string][]string) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetPorts", containerPortsMap)
@@ -573,36 +1255,185 @@ func (m *MockDevfileData) SetPorts(containerPortsMap map[string][]string) error
 	return ret0
 }
 
-// SetPorts indicates an expected call of SetPorts
+// SetPorts indicates an expected call of SetPorts.
 func (mr *MockDevfileDataMockRecorder) SetPorts(containerPortsMap interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPorts", reflect.TypeOf((*MockDevfileData)(nil).SetPorts), containerPortsMap)
 }
 
-// AddEnvVars mocks base method
-func (m *MockDevfileData) AddEnvVars(containerEnvMap map[string][]v1alpha2.EnvVar) error {
+// SetProjectType mocks base method.
+func (m *MockDevfileData) SetProjectType(projectType string) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddEnvVars", containerEnvMap)
+	m.ctrl.Call(m, "SetProjectType", projectType)
+}
+
+// SetProjectType indicates an expected call of SetProjectType.
+func (mr *MockDevfileDataMockRecorder) SetProjectType(projectType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProjectType", reflect.TypeOf((*MockDevfileData)(nil).SetProjectType), projectType)
+}
+
+// SetSchemaVersion mocks base method.
+func (m *MockDevfileData) SetSchemaVersion(version string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSchemaVersion", version)
+}
+
+// SetSchemaVersion indicates an expected call of SetSchemaVersion.
+func (mr *MockDevfileDataMockRecorder) SetSchemaVersion(version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchemaVersion", reflect.TypeOf((*MockDevfileData)(nil).SetSchemaVersion), version)
+}
+
+// StripAttributesByPrefix mocks base method.
+func (m *MockDevfileData) StripAttributesByPrefix(prefix string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StripAttributesByPrefix", prefix)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// StripAttributesByPrefix indicates an expected call of StripAttributesByPrefix.
+func (mr *MockDevfileDataMockRecorder) StripAttributesByPrefix(prefix interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StripAttributesByPrefix", reflect.TypeOf((*MockDevfileData)(nil).StripAttributesByPrefix), prefix)
+}
+
+// Summary mocks base method.
+func (m *MockDevfileData) Summary() common.DevfileSummary {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summary")
+	ret0, _ := ret[0].(common.DevfileSummary)
+	return ret0
+}
+
+// Summary indicates an expected call of Summary.
+func (mr *MockDevfileDataMockRecorder) Summary() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summary", reflect.TypeOf((*MockDevfileData)(nil).Summary))
+}
+
+// ToDevWorkspaceTemplateSpec mocks base method.
+func (m *MockDevfileData) ToDevWorkspaceTemplateSpec() (v1alpha2.DevWorkspaceTemplateSpec, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToDevWorkspaceTemplateSpec")
+	ret0, _ := ret[0].(v1alpha2.DevWorkspaceTemplateSpec)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToDevWorkspaceTemplateSpec indicates an expected call of ToDevWorkspaceTemplateSpec.
+func (mr *MockDevfileDataMockRecorder) ToDevWorkspaceTemplateSpec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToDevWorkspaceTemplateSpec", reflect.TypeOf((*MockDevfileData)(nil).ToDevWorkspaceTemplateSpec))
+}
+
+// UpdateAttributes mocks base method.
+func (m *MockDevfileData) UpdateAttributes(key string, value interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAttributes", key, value)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AddEnvVars indicates an expected call of AddEnvVars
-func (mr *MockDevfileDataMockRecorder) AddEnvVars(containerEnvMap interface{}) *gomock.Call {
+// UpdateAttributes indicates an expected call of UpdateAttributes.
+func (mr *MockDevfileDataMockRecorder) UpdateAttributes(key, value interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEnvVars", reflect.TypeOf((*MockDevfileData)(nil).AddEnvVars), containerEnvMap)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttributes", reflect.TypeOf((*MockDevfileData)(nil).UpdateAttributes), key, value)
 }
 
-// RemovePorts mocks base method
-func (m *MockDevfileData) RemovePorts(containerPortsMap map[string][]string) error {
+// UpdateCommand mocks base method.
+func (m *MockDevfileData) UpdateCommand(command v1alpha2.Command) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemovePorts", containerPortsMap)
+	ret := m.ctrl.Call(m, "UpdateCommand", command)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RemovePorts indicates an expected call of RemovePorts
-func (mr *MockDevfileDataMockRecorder) RemovePorts(containerPortsMap interface{}) *gomock.Call {
+// UpdateCommand indicates an expected call of UpdateCommand.
+func (mr *MockDevfileDataMockRecorder) UpdateCommand(command interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePorts", reflect.TypeOf((*MockDevfileData)(nil).RemovePorts), containerPortsMap)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCommand", reflect.TypeOf((*MockDevfileData)(nil).UpdateCommand), command)
+}
+
+// UpdateComponent mocks base method.
+func (m *MockDevfileData) UpdateComponent(component v1alpha2.Component) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComponent", component)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComponent indicates an expected call of UpdateComponent.
+func (mr *MockDevfileDataMockRecorder) UpdateComponent(component interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComponent", reflect.TypeOf((*MockDevfileData)(nil).UpdateComponent), component)
+}
+
+// UpdateEvents mocks base method.
+func (m *MockDevfileData) UpdateEvents(postStart, postStop, preStart, preStop []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateEvents", postStart, postStop, preStart, preStop)
+}
+
+// UpdateEvents indicates an expected call of UpdateEvents.
+func (mr *MockDevfileDataMockRecorder) UpdateEvents(postStart, postStop, preStart, preStop interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvents", reflect.TypeOf((*MockDevfileData)(nil).UpdateEvents), postStart, postStop, preStart, preStop)
+}
+
+// UpdateProject mocks base method.
+func (m *MockDevfileData) UpdateProject(project v1alpha2.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockDevfileDataMockRecorder) UpdateProject(project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockDevfileData)(nil).UpdateProject), project)
+}
+
+// UpdateStarterProject mocks base method.
+func (m *MockDevfileData) UpdateStarterProject(project v1alpha2.StarterProject) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStarterProject", project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStarterProject indicates an expected call of UpdateStarterProject.
+func (mr *MockDevfileDataMockRecorder) UpdateStarterProject(project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStarterProject", reflect.TypeOf((*MockDevfileData)(nil).UpdateStarterProject), project)
+}
+
+// UpdateVolumeMount mocks base method.
+func (m *MockDevfileData) UpdateVolumeMount(componentName, mountName, newPath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVolumeMount", componentName, mountName, newPath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVolumeMount indicates an expected call of UpdateVolumeMount.
+func (mr *MockDevfileDataMockRecorder) UpdateVolumeMount(componentName, mountName, newPath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVolumeMount", reflect.TypeOf((*MockDevfileData)(nil).UpdateVolumeMount), componentName, mountName, newPath)
+}
+
+// UpgradeSchemaVersion mocks base method.
+func (m *MockDevfileData) UpgradeSchemaVersion(target string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeSchemaVersion", target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpgradeSchemaVersion indicates an expected call of UpgradeSchemaVersion.
+func (mr *MockDevfileDataMockRecorder) UpgradeSchemaVersion(target interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeSchemaVersion", reflect.TypeOf((*MockDevfileData)(nil).UpgradeSchemaVersion), target)
 }
@@ -17,8 +17,12 @@ package v2
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
 )
 
 // GetAttributes gets the devfile top level attributes
@@ -60,8 +64,140 @@ func (d *DevfileV2) AddAttributes(key string, value interface{}) error {
 	case "2.0.0":
 		return fmt.Errorf("top-level attributes is not supported in devfile schema version 2.0.0")
 	default:
+		if d.Attributes == nil {
+			d.Attributes = attributes.Attributes{}
+		}
 		d.Attributes.Put(key, value, &err)
 	}
 
 	return err
 }
+
+// GetAttributeString returns the devfile top level attribute for the key as a string. It returns an
+// attributes.KeyNotFoundError if the key is absent and a common.AttributeTypeError if the value cannot be converted
+func (d *DevfileV2) GetAttributeString(key string) (string, error) {
+	var value string
+	err := d.getTypedAttribute(key, "string", func(topLevelAttributes attributes.Attributes, err *error) {
+		value = topLevelAttributes.GetString(key, err)
+	})
+	return value, err
+}
+
+// GetAttributeBool returns the devfile top level attribute for the key as a boolean. It returns an
+// attributes.KeyNotFoundError if the key is absent and a common.AttributeTypeError if the value cannot be converted
+func (d *DevfileV2) GetAttributeBool(key string) (bool, error) {
+	var value bool
+	err := d.getTypedAttribute(key, "boolean", func(topLevelAttributes attributes.Attributes, err *error) {
+		value = topLevelAttributes.GetBoolean(key, err)
+	})
+	return value, err
+}
+
+// GetAttributeNumber returns the devfile top level attribute for the key as a number. It returns an
+// attributes.KeyNotFoundError if the key is absent and a common.AttributeTypeError if the value cannot be converted
+func (d *DevfileV2) GetAttributeNumber(key string) (float64, error) {
+	var value float64
+	err := d.getTypedAttribute(key, "number", func(topLevelAttributes attributes.Attributes, err *error) {
+		value = topLevelAttributes.GetNumber(key, err)
+	})
+	return value, err
+}
+
+// SetAttribute sets the devfile top level attribute for the key to the JSON encoding of the value,
+// value will be overwritten if key is already present
+func (d *DevfileV2) SetAttribute(key string, value interface{}) error {
+	return d.AddAttributes(key, value)
+}
+
+// getTypedAttribute reads the devfile top level attribute for the key with get, and converts a decoding error
+// of the attribute value to a common.AttributeTypeError
+func (d *DevfileV2) getTypedAttribute(key, typeName string, get func(topLevelAttributes attributes.Attributes, err *error)) error {
+	topLevelAttributes, err := d.GetAttributes()
+	if err != nil {
+		return err
+	}
+	get(topLevelAttributes, &err)
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*attributes.KeyNotFoundError); ok {
+		return err
+	}
+	return &common.AttributeTypeError{
+		Key:  key,
+		Type: typeName,
+		Err:  err,
+	}
+}
+
+// GetAllAttributeKeys returns the sorted and deduplicated attribute keys used
+// by the top-level attributes, the components and the commands of the devfile
+func (d *DevfileV2) GetAllAttributeKeys() []string {
+	keys := make(map[string]bool)
+	for key := range d.Attributes {
+		keys[key] = true
+	}
+	for _, component := range d.Components {
+		for key := range component.Attributes {
+			keys[key] = true
+		}
+	}
+	for _, command := range d.Commands {
+		for key := range command.Attributes {
+			keys[key] = true
+		}
+	}
+
+	allKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		allKeys = append(allKeys, key)
+	}
+	sort.Strings(allKeys)
+	return allKeys
+}
+
+// StripAttributesByPrefix removes the attributes which keys start with the prefix from the top-level attributes
+// and the attributes of the components, endpoints, commands, projects and starter projects of the devfile.
+// It returns the number of removed attributes.
+func (d *DevfileV2) StripAttributesByPrefix(prefix string) int {
+	removed := stripAttributesByPrefix(d.Attributes, prefix)
+	for i := range d.Components {
+		component := &d.Components[i]
+		removed += stripAttributesByPrefix(component.Attributes, prefix)
+
+		var endpoints []v1.Endpoint
+		switch {
+		case component.Container != nil:
+			endpoints = component.Container.Endpoints
+		case component.Kubernetes != nil:
+			endpoints = component.Kubernetes.Endpoints
+		case component.Openshift != nil:
+			endpoints = component.Openshift.Endpoints
+		}
+		for j := range endpoints {
+			removed += stripAttributesByPrefix(endpoints[j].Attributes, prefix)
+		}
+	}
+	for i := range d.Commands {
+		removed += stripAttributesByPrefix(d.Commands[i].Attributes, prefix)
+	}
+	for i := range d.Projects {
+		removed += stripAttributesByPrefix(d.Projects[i].Attributes, prefix)
+	}
+	for i := range d.StarterProjects {
+		removed += stripAttributesByPrefix(d.StarterProjects[i].Attributes, prefix)
+	}
+	return removed
+}
+
+// stripAttributesByPrefix removes the attributes which keys start with the prefix and returns their number
+func stripAttributesByPrefix(attrs attributes.Attributes, prefix string) int {
+	removed := 0
+	for key := range attrs {
+		if strings.HasPrefix(key, prefix) {
+			delete(attrs, key)
+			removed++
+		}
+	}
+	return removed
+}
@@ -269,3 +269,266 @@ func TestAddAttributes(t *testing.T) {
 		})
 	}
 }
+
+func TestTypedAttributes(t *testing.T) {
+	missingKeyErr := `Attribute with key "missing" does not exist`
+	stringTypeErr := "attribute object cannot be converted to a string"
+	boolTypeErr := "attribute name cannot be converted to a boolean"
+	numberTypeErr := "attribute name cannot be converted to a number"
+	schema200NoAttributeErr := "top-level attributes is not supported in devfile schema version 2.0.0"
+
+	newDevfile := func(schemaVersion string) *DevfileV2 {
+		return &DevfileV2{
+			v1alpha2.Devfile{
+				DevfileHeader: devfilepkg.DevfileHeader{
+					SchemaVersion: schemaVersion,
+				},
+			},
+		}
+	}
+
+	d := newDevfile("2.1.0")
+	for key, value := range map[string]interface{}{
+		"name":     "nodejs",
+		"debug":    true,
+		"replicas": 3,
+		"enabled":  "true",
+		"object":   map[string]string{"key": "value"},
+	} {
+		if err := d.SetAttribute(key, value); err != nil {
+			t.Fatalf("TestTypedAttributes() unexpected error while setting attribute %s: %v", key, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		devfilev2 *DevfileV2
+		key       string
+		get       func(d *DevfileV2, key string) (interface{}, error)
+		wantValue interface{}
+		wantErr   *string
+	}{
+		{
+			name:      "string round-trip",
+			devfilev2: d,
+			key:       "name",
+			get: func(d *DevfileV2, key string) (interface{}, error) {
+				return d.GetAttributeString(key)
+			},
+			wantValue: "nodejs",
+		},
+		{
+			name:      "boolean round-trip",
+			devfilev2: d,
+			key:       "debug",
+			get: func(d *DevfileV2, key string) (interface{}, error) {
+				return d.GetAttributeBool(key)
+			},
+			wantValue: true,
+		},
+		{
+			name:      "number round-trip",
+			devfilev2: d,
+			key:       "replicas",
+			get: func(d *DevfileV2, key string) (interface{}, error) {
+				return d.GetAttributeNumber(key)
+			},
+			wantValue: float64(3),
+		},
+		{
+			name:      "string holding a boolean",
+			devfilev2: d,
+			key:       "enabled",
+			get: func(d *DevfileV2, key string) (interface{}, error) {
+				return d.GetAttributeBool(key)
+			},
+			wantValue: true,
+		},
+		{
+			name:      "boolean read as a string",
+			devfilev2: d,
+			key:       "debug",
+			get: func(d *DevfileV2, key string) (interface{}, error) {
+				return d.GetAttributeString(key)
+			},
+			wantValue: "true",
+		},
+		{
+			name:      "object read as a string",
+			devfilev2: d,
+			key:       "object",
+			get: func(d *DevfileV2, key string) (interface{}, error) {
+				return d.GetAttributeString(key)
+			},
+			wantErr: &stringTypeErr,
+		},
+		{
+			name:      "string read as a boolean",
+			devfilev2: d,
+			key:       "name",
+			get: func(d *DevfileV2, key string) (interface{}, error) {
+				return d.GetAttributeBool(key)
+			},
+			wantErr: &boolTypeErr,
+		},
+		{
+			name:      "string read as a number",
+			devfilev2: d,
+			key:       "name",
+			get: func(d *DevfileV2, key string) (interface{}, error) {
+				return d.GetAttributeNumber(key)
+			},
+			wantErr: &numberTypeErr,
+		},
+		{
+			name:      "missing key",
+			devfilev2: d,
+			key:       "missing",
+			get: func(d *DevfileV2, key string) (interface{}, error) {
+				return d.GetAttributeString(key)
+			},
+			wantErr: &missingKeyErr,
+		},
+		{
+			name:      "schema 2.0.0 does not have attributes",
+			devfilev2: newDevfile("2.0.0"),
+			key:       "name",
+			get: func(d *DevfileV2, key string) (interface{}, error) {
+				return d.GetAttributeString(key)
+			},
+			wantErr: &schema200NoAttributeErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.get(tt.devfilev2, tt.key)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestTypedAttributes() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantValue, value, "TestTypedAttributes(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestTypedAttributes(): Error message should match")
+			}
+		})
+	}
+}
+
+func TestGetAllAttributeKeys(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		devfilev2 *DevfileV2
+		wantKeys  []string
+	}{
+		{
+			name: "Attributes on top-level, components and commands",
+			devfilev2: &DevfileV2{
+				v1alpha2.Devfile{
+					DevWorkspaceTemplateSpec: v1alpha2.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1alpha2.DevWorkspaceTemplateSpecContent{
+							Attributes: attributes.Attributes{}.PutString("key2", "value2").PutString("key1", "value1"),
+							Components: []v1alpha2.Component{
+								{
+									Name:       "component1",
+									Attributes: attributes.Attributes{}.PutString("key3", "value3").PutString("key1", "value1"),
+								},
+								{
+									Name: "component2",
+								},
+							},
+							Commands: []v1alpha2.Command{
+								{
+									Id:         "command1",
+									Attributes: attributes.Attributes{}.PutBoolean("key4", true).PutString("key2", "value2"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantKeys: []string{"key1", "key2", "key3", "key4"},
+		},
+		{
+			name: "No attributes",
+			devfilev2: &DevfileV2{
+				v1alpha2.Devfile{
+					DevWorkspaceTemplateSpec: v1alpha2.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1alpha2.DevWorkspaceTemplateSpecContent{
+							Components: []v1alpha2.Component{
+								{
+									Name: "component1",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantKeys: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := tt.devfilev2.GetAllAttributeKeys()
+			assert.Equal(t, tt.wantKeys, keys, "TestGetAllAttributeKeys(): The two values should be the same.")
+		})
+	}
+}
+
+func TestStripAttributesByPrefix(t *testing.T) {
+
+	devfilev2 := &DevfileV2{
+		v1alpha2.Devfile{
+			DevWorkspaceTemplateSpec: v1alpha2.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1alpha2.DevWorkspaceTemplateSpecContent{
+					Attributes: attributes.Attributes{}.PutString("internal.corp/owner", "team").PutString("public", "value"),
+					Components: []v1alpha2.Component{
+						{
+							Name:       "component1",
+							Attributes: attributes.Attributes{}.PutString("internal.corp/cost-center", "42"),
+							ComponentUnion: v1alpha2.ComponentUnion{
+								Container: &v1alpha2.ContainerComponent{
+									Endpoints: []v1alpha2.Endpoint{
+										{
+											Name:       "http",
+											Attributes: attributes.Attributes{}.PutBoolean("internal.corp/audited", true).PutString("protocol", "http"),
+										},
+									},
+								},
+							},
+						},
+						{
+							Name: "component2",
+						},
+					},
+					Commands: []v1alpha2.Command{
+						{
+							Id:         "command1",
+							Attributes: attributes.Attributes{}.PutString("internal.corp/runbook", "url"),
+						},
+					},
+					Projects: []v1alpha2.Project{
+						{
+							Name:       "project1",
+							Attributes: attributes.Attributes{}.PutString("internal.corp/mirror", "url"),
+						},
+					},
+					StarterProjects: []v1alpha2.StarterProject{
+						{
+							Name:       "starter1",
+							Attributes: attributes.Attributes{}.PutString("internal.corp/mirror", "url").PutString("internal", "value"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	removed := devfilev2.StripAttributesByPrefix("internal.corp/")
+	assert.Equal(t, 6, removed, "TestStripAttributesByPrefix(): The two values should be the same.")
+	assert.Equal(t, attributes.Attributes{}.PutString("public", "value"), devfilev2.Attributes, "TestStripAttributesByPrefix(): The two values should be the same.")
+	assert.Equal(t, attributes.Attributes{}, devfilev2.Components[0].Attributes, "TestStripAttributesByPrefix(): The two values should be the same.")
+	assert.Equal(t, attributes.Attributes{}.PutString("protocol", "http"), devfilev2.Components[0].Container.Endpoints[0].Attributes, "TestStripAttributesByPrefix(): The two values should be the same.")
+	assert.Equal(t, attributes.Attributes{}, devfilev2.Commands[0].Attributes, "TestStripAttributesByPrefix(): The two values should be the same.")
+	assert.Equal(t, attributes.Attributes{}, devfilev2.Projects[0].Attributes, "TestStripAttributesByPrefix(): The two values should be the same.")
+	assert.Equal(t, attributes.Attributes{}.PutString("internal", "value"), devfilev2.StarterProjects[0].Attributes, "TestStripAttributesByPrefix(): The two values should be the same.")
+}
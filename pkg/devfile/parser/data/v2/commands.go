@@ -20,6 +20,7 @@ import (
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -66,6 +67,209 @@ func (d *DevfileV2) GetCommands(options common.DevfileOptions) ([]v1.Command, er
 	return commands, nil
 }
 
+// GetCommandByName returns the command with the given id
+func (d *DevfileV2) GetCommandByName(id string) (v1.Command, error) {
+	for _, command := range d.Commands {
+		if command.Id == id {
+			return command, nil
+		}
+	}
+	return v1.Command{}, &common.FieldNotFoundError{
+		Field: "command",
+		Name:  id,
+	}
+}
+
+// GetExecCommands returns the exec commands of the devfile which match the filter options.
+// Any command type set in the options is ignored.
+func (d *DevfileV2) GetExecCommands(options common.DevfileOptions) ([]v1.Command, error) {
+	return d.getCommandsOfType(v1.ExecCommandType, options)
+}
+
+// GetApplyCommands returns the apply commands of the devfile which match the filter options.
+// Any command type set in the options is ignored.
+func (d *DevfileV2) GetApplyCommands(options common.DevfileOptions) ([]v1.Command, error) {
+	return d.getCommandsOfType(v1.ApplyCommandType, options)
+}
+
+// GetCompositeCommands returns the composite commands of the devfile which match the filter options.
+// Any command type set in the options is ignored.
+func (d *DevfileV2) GetCompositeCommands(options common.DevfileOptions) ([]v1.Command, error) {
+	return d.getCommandsOfType(v1.CompositeCommandType, options)
+}
+
+// getCommandsOfType returns the commands of the given type which match the filter options
+func (d *DevfileV2) getCommandsOfType(commandType v1.CommandType, options common.DevfileOptions) ([]v1.Command, error) {
+	options.CommandOptions.CommandType = commandType
+	return d.GetCommands(options)
+}
+
+// GetDefaultCommand returns the default command of the given group kind
+func (d *DevfileV2) GetDefaultCommand(groupKind v1.CommandGroupKind) (v1.Command, error) {
+	for _, command := range d.Commands {
+		group := common.GetGroup(command)
+		if group != nil && group.Kind == groupKind && group.IsDefault != nil && *group.IsDefault {
+			return command, nil
+		}
+	}
+	return v1.Command{}, &common.FieldNotFoundError{
+		Field: "default command of the group",
+		Name:  string(groupKind),
+	}
+}
+
+// SetDefaultCommand makes the specified command the default command of its group kind,
+// isDefault is unset on the other commands of the same group kind since a group kind has a single default command
+func (d *DevfileV2) SetDefaultCommand(commandId string) error {
+	var target *v1.CommandGroup
+	for _, command := range d.Commands {
+		if command.Id != commandId {
+			continue
+		}
+		target = common.GetGroup(command)
+		if target == nil {
+			return &common.FieldNotFoundError{
+				Field: "group of the command",
+				Name:  commandId,
+			}
+		}
+		break
+	}
+	if target == nil {
+		return &common.FieldNotFoundError{
+			Field: "command",
+			Name:  commandId,
+		}
+	}
+
+	for _, command := range d.Commands {
+		group := common.GetGroup(command)
+		if group == nil || group.Kind != target.Kind || group == target {
+			continue
+		}
+		if group.IsDefault != nil && *group.IsDefault {
+			isDefault := false
+			group.IsDefault = &isDefault
+		}
+	}
+	isDefault := true
+	target.IsDefault = &isDefault
+	return nil
+}
+
+// DefaultWorkingDir is the working directory of the exec commands when neither the command
+// nor its container component define one, it is the default source mapping of the containers
+const DefaultWorkingDir = "/projects"
+
+// GetEffectiveWorkingDir returns the working directory of the specified exec command: the command workingDir if set,
+// otherwise the source mapping of the container component the command targets, otherwise DefaultWorkingDir.
+// The command id is matched case-insensitively.
+func (d *DevfileV2) GetEffectiveWorkingDir(commandID string) (string, error) {
+	for _, command := range d.Commands {
+		if strings.ToLower(command.Id) != strings.ToLower(commandID) {
+			continue
+		}
+		if command.Exec == nil {
+			return "", fmt.Errorf("command %s is not an exec command", commandID)
+		}
+		if command.Exec.WorkingDir != "" {
+			return command.Exec.WorkingDir, nil
+		}
+		for _, component := range d.Components {
+			if component.Name == command.Exec.Component && component.Container != nil && component.Container.SourceMapping != "" {
+				return component.Container.SourceMapping, nil
+			}
+		}
+		return DefaultWorkingDir, nil
+	}
+
+	return "", &common.FieldNotFoundError{
+		Field: "command",
+		Name:  commandID,
+	}
+}
+
+const (
+	// CommandShellAttribute is the reserved exec command attribute holding the shell the command line is run with
+	CommandShellAttribute = "library.devfile.io/shell"
+	// DefaultShell is the shell of the exec commands which do not set one
+	DefaultShell = "/bin/sh"
+)
+
+// GetCommandShell returns the shell the command line of the specified exec command is run with: the command
+// CommandShellAttribute if set, otherwise DefaultShell. An empty shell means the command line is run directly.
+func (d *DevfileV2) GetCommandShell(commandID string) (string, error) {
+	for _, command := range d.Commands {
+		if command.Id != commandID {
+			continue
+		}
+		if command.Exec == nil {
+			return "", fmt.Errorf("command %s is not an exec command", commandID)
+		}
+		var err error
+		if command.Attributes.Exists(CommandShellAttribute) {
+			shell := command.Attributes.GetString(CommandShellAttribute, &err)
+			return shell, err
+		}
+		return DefaultShell, nil
+	}
+
+	return "", &common.FieldNotFoundError{
+		Field: "command",
+		Name:  commandID,
+	}
+}
+
+// GetCommandsGroupedByComponent returns the commands of the devfile grouped by the name of the component they target,
+// exec and apply commands target a component, the other commands are grouped under the empty component name
+func (d *DevfileV2) GetCommandsGroupedByComponent() map[string][]v1.Command {
+	commandsByComponent := make(map[string][]v1.Command)
+	for _, command := range d.Commands {
+		var componentName string
+		switch {
+		case command.Exec != nil:
+			componentName = command.Exec.Component
+		case command.Apply != nil:
+			componentName = command.Apply.Component
+		}
+		commandsByComponent[componentName] = append(commandsByComponent[componentName], command)
+	}
+	return commandsByComponent
+}
+
+// GetCommandEnvConflicts returns, for each exec command, the names of the env vars it overrides from the
+// container component it targets. Commands which do not override any container env var are not reported.
+func (d *DevfileV2) GetCommandEnvConflicts() map[string][]string {
+	containerEnvNames := make(map[string]map[string]bool)
+	for _, component := range d.Components {
+		if component.Container == nil {
+			continue
+		}
+		envNames := make(map[string]bool)
+		for _, env := range component.Container.Env {
+			envNames[env.Name] = true
+		}
+		containerEnvNames[component.Name] = envNames
+	}
+
+	envConflicts := make(map[string][]string)
+	for _, command := range d.Commands {
+		if command.Exec == nil {
+			continue
+		}
+		envNames, ok := containerEnvNames[command.Exec.Component]
+		if !ok {
+			continue
+		}
+		for _, env := range command.Exec.Env {
+			if envNames[env.Name] {
+				envConflicts[command.Id] = append(envConflicts[command.Id], env.Name)
+			}
+		}
+	}
+	return envConflicts
+}
+
 // AddCommands adds the slice of Command objects to the Devfile's commands
 // a command is considered as invalid if it is already defined
 // command list passed in will be all processed, and returns a total error of all invalid commands
@@ -117,3 +321,94 @@ func (d *DevfileV2) DeleteCommand(id string) error {
 		Name:  id,
 	}
 }
+
+// GetCommandGraph returns the dependency graph of the devfile commands, with an invokes edge from each composite
+// command to the commands it invokes and a triggered-by edge from each command to the events referencing it.
+// It returns an error if a composite command or an event references a command which is not found.
+func (d *DevfileV2) GetCommandGraph() (common.CommandGraph, error) {
+	var graph common.CommandGraph
+
+	commandIds := make(map[string]string)
+	for _, command := range d.Commands {
+		commandIds[strings.ToLower(command.Id)] = command.Id
+		graph.Nodes = append(graph.Nodes, common.CommandGraphNode{ID: command.Id, Kind: common.CommandGraphCommandNode})
+	}
+
+	for _, command := range d.Commands {
+		if command.Composite == nil {
+			continue
+		}
+		for _, subCommand := range command.Composite.Commands {
+			subCommandId, ok := commandIds[strings.ToLower(subCommand)]
+			if !ok {
+				return common.CommandGraph{}, &common.FieldNotFoundError{Field: "command", Name: subCommand}
+			}
+			graph.Edges = append(graph.Edges, common.CommandGraphEdge{From: command.Id, To: subCommandId, Kind: common.CommandGraphInvokesEdge})
+		}
+	}
+
+	events := d.GetEvents()
+	for _, event := range []struct {
+		name     string
+		commands []string
+	}{
+		{name: "preStart", commands: events.PreStart},
+		{name: "postStart", commands: events.PostStart},
+		{name: "preStop", commands: events.PreStop},
+		{name: "postStop", commands: events.PostStop},
+	} {
+		if len(event.commands) == 0 {
+			continue
+		}
+		graph.Nodes = append(graph.Nodes, common.CommandGraphNode{ID: event.name, Kind: common.CommandGraphEventNode})
+		for _, eventCommand := range event.commands {
+			eventCommandId, ok := commandIds[strings.ToLower(eventCommand)]
+			if !ok {
+				return common.CommandGraph{}, &common.FieldNotFoundError{Field: "command", Name: eventCommand}
+			}
+			graph.Edges = append(graph.Edges, common.CommandGraphEdge{From: eventCommandId, To: event.name, Kind: common.CommandGraphTriggeredByEdge})
+		}
+	}
+
+	return graph, nil
+}
+
+// GetComponentsTouchedByCommand returns the sorted names of the components targeted by the specified command: the
+// component of an exec or apply command, or the components of all the commands a composite command transitively invokes.
+// It returns an error if a command is not found or if the composite commands have a cycle.
+func (d *DevfileV2) GetComponentsTouchedByCommand(commandID string) ([]string, error) {
+	graph, err := d.GetCommandGraph()
+	if err != nil {
+		return nil, err
+	}
+	commands := make(map[string]v1.Command)
+	for _, command := range d.Commands {
+		commands[strings.ToLower(command.Id)] = command
+	}
+	command, ok := commands[strings.ToLower(commandID)]
+	if !ok {
+		return nil, &common.FieldNotFoundError{Field: "command", Name: commandID}
+	}
+	invokedCommands, err := graph.GetInvokedCommands(command.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	componentNames := make(map[string]bool)
+	for _, id := range invokedCommands {
+		invokedCommand := commands[strings.ToLower(id)]
+		switch {
+		case invokedCommand.Exec != nil:
+			componentNames[invokedCommand.Exec.Component] = true
+		case invokedCommand.Apply != nil:
+			componentNames[invokedCommand.Apply.Component] = true
+		}
+	}
+
+	var touchedComponents []string
+	for componentName := range componentNames {
+		touchedComponents = append(touchedComponents, componentName)
+	}
+	sort.Strings(touchedComponents)
+	return touchedComponents, nil
+}
@@ -23,6 +23,7 @@ import (
 
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/api/v2/pkg/attributes"
+	devfilepkg "github.com/devfile/api/v2/pkg/devfile"
 	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
 	"github.com/stretchr/testify/assert"
 )
@@ -291,6 +292,249 @@ func TestDevfile200_GetCommands(t *testing.T) {
 	}
 }
 
+func TestDevfile200_GetTypedCommands(t *testing.T) {
+	missingCommandErr := "command .* is not found in the devfile"
+
+	build := v1.Command{
+		Id:         "build",
+		Attributes: attributes.Attributes{}.FromStringMap(map[string]string{"stage": "dev"}),
+		CommandUnion: v1.CommandUnion{
+			Exec: &v1.ExecCommand{
+				CommandLine: "npm install",
+				Component:   "runtime",
+			},
+		},
+	}
+	run := v1.Command{
+		Id: "run",
+		CommandUnion: v1.CommandUnion{
+			Exec: &v1.ExecCommand{
+				CommandLine: "npm start",
+				Component:   "runtime",
+			},
+		},
+	}
+	deploy := v1.Command{
+		Id:         "deploy",
+		Attributes: attributes.Attributes{}.FromStringMap(map[string]string{"stage": "dev"}),
+		CommandUnion: v1.CommandUnion{
+			Apply: &v1.ApplyCommand{
+				Component: "k8s",
+			},
+		},
+	}
+	buildAndRun := v1.Command{
+		Id: "build-and-run",
+		CommandUnion: v1.CommandUnion{
+			Composite: &v1.CompositeCommand{
+				Commands: []string{"build", "run"},
+			},
+		},
+	}
+
+	d := &DevfileV2{
+		v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Commands: []v1.Command{build, run, deploy, buildAndRun},
+				},
+			},
+		},
+	}
+
+	t.Run("get command by name", func(t *testing.T) {
+		command, err := d.GetCommandByName("deploy")
+		if err != nil {
+			t.Errorf("TestDevfile200_GetTypedCommands() unexpected error: %v", err)
+		} else {
+			assert.Equal(t, deploy, command, "TestDevfile200_GetTypedCommands(): The two values should be the same.")
+		}
+
+		_, err = d.GetCommandByName("debug")
+		if err == nil {
+			t.Errorf("TestDevfile200_GetTypedCommands() expected an error for a missing command")
+		} else {
+			assert.Regexp(t, missingCommandErr, err.Error(), "TestDevfile200_GetTypedCommands(): Error message should match")
+		}
+	})
+
+	devFilter := common.DevfileOptions{
+		Filter: map[string]interface{}{
+			"stage": "dev",
+		},
+	}
+	tests := []struct {
+		name        string
+		getCommands func(common.DevfileOptions) ([]v1.Command, error)
+		options     common.DevfileOptions
+		wantIds     []string
+	}{
+		{
+			name:        "exec commands",
+			getCommands: d.GetExecCommands,
+			wantIds:     []string{"build", "run"},
+		},
+		{
+			name:        "exec commands with attribute filter",
+			getCommands: d.GetExecCommands,
+			options:     devFilter,
+			wantIds:     []string{"build"},
+		},
+		{
+			name:        "apply commands",
+			getCommands: d.GetApplyCommands,
+			wantIds:     []string{"deploy"},
+		},
+		{
+			name:        "composite commands",
+			getCommands: d.GetCompositeCommands,
+			wantIds:     []string{"build-and-run"},
+		},
+		{
+			name:        "composite commands with attribute filter",
+			getCommands: d.GetCompositeCommands,
+			options:     devFilter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := tt.getCommands(tt.options)
+			if err != nil {
+				t.Errorf("TestDevfile200_GetTypedCommands() unexpected error: %v", err)
+				return
+			}
+			var ids []string
+			for _, command := range commands {
+				ids = append(ids, command.Id)
+			}
+			assert.Equal(t, tt.wantIds, ids, "TestDevfile200_GetTypedCommands(): The two values should be the same.")
+		})
+	}
+}
+
+func TestDevfile200_SetDefaultCommand(t *testing.T) {
+	missingCommandErr := "command debug is not found in the devfile"
+	missingGroupErr := "group of the command install is not found in the devfile"
+	missingDefaultErr := "default command of the group test is not found in the devfile"
+
+	isDefault := func(b bool) *bool {
+		return &b
+	}
+	execCommand := func(id string, group *v1.CommandGroup) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					LabeledCommand: v1.LabeledCommand{
+						BaseCommand: v1.BaseCommand{
+							Group: group,
+						},
+					},
+					CommandLine: "echo " + id,
+					Component:   "runtime",
+				},
+			},
+		}
+	}
+	devfileCommands := func() []v1.Command {
+		return []v1.Command{
+			execCommand("build1", &v1.CommandGroup{Kind: v1.BuildCommandGroupKind, IsDefault: isDefault(true)}),
+			execCommand("build2", &v1.CommandGroup{Kind: v1.BuildCommandGroupKind, IsDefault: isDefault(false)}),
+			execCommand("run", &v1.CommandGroup{Kind: v1.RunCommandGroupKind, IsDefault: isDefault(true)}),
+			execCommand("install", nil),
+		}
+	}
+
+	tests := []struct {
+		name            string
+		commandId       string
+		groupKind       v1.CommandGroupKind
+		wantDefaultId   string
+		wantUnsetIds    []string
+		wantErr         *string
+		wantDefaultsErr *string
+	}{
+		{
+			name:          "previous default is unset",
+			commandId:     "build2",
+			groupKind:     v1.BuildCommandGroupKind,
+			wantDefaultId: "build2",
+			wantUnsetIds:  []string{"build1"},
+		},
+		{
+			name:          "already the default command",
+			commandId:     "build1",
+			groupKind:     v1.BuildCommandGroupKind,
+			wantDefaultId: "build1",
+			wantUnsetIds:  []string{"build2"},
+		},
+		{
+			name:      "command not found",
+			commandId: "debug",
+			wantErr:   &missingCommandErr,
+		},
+		{
+			name:      "command without group",
+			commandId: "install",
+			wantErr:   &missingGroupErr,
+		},
+		{
+			name:            "group kind without default command",
+			commandId:       "run",
+			groupKind:       v1.TestCommandGroupKind,
+			wantDefaultsErr: &missingDefaultErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Commands: devfileCommands(),
+						},
+					},
+				},
+			}
+
+			err := d.SetDefaultCommand(tt.commandId)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_SetDefaultCommand() unexpected error: %v, wantErr %v", err, tt.wantErr)
+				return
+			} else if err != nil {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_SetDefaultCommand(): Error message should match")
+				return
+			}
+
+			command, err := d.GetDefaultCommand(tt.groupKind)
+			if (err != nil) != (tt.wantDefaultsErr != nil) {
+				t.Errorf("TestDevfile200_SetDefaultCommand() unexpected error: %v, wantErr %v", err, tt.wantDefaultsErr)
+				return
+			} else if err != nil {
+				assert.Regexp(t, *tt.wantDefaultsErr, err.Error(), "TestDevfile200_SetDefaultCommand(): Error message should match")
+				return
+			}
+			assert.Equal(t, tt.wantDefaultId, command.Id, "TestDevfile200_SetDefaultCommand(): The two values should be the same.")
+
+			for _, id := range tt.wantUnsetIds {
+				unset, err := d.GetCommandByName(id)
+				if err != nil {
+					t.Errorf("TestDevfile200_SetDefaultCommand() unexpected error: %v", err)
+					continue
+				}
+				assert.False(t, *unset.Exec.Group.IsDefault, "TestDevfile200_SetDefaultCommand(): command %s should not be the default", id)
+			}
+
+			run, err := d.GetDefaultCommand(v1.RunCommandGroupKind)
+			if err != nil {
+				t.Errorf("TestDevfile200_SetDefaultCommand() unexpected error: %v", err)
+			} else {
+				assert.Equal(t, "run", run.Id, "TestDevfile200_SetDefaultCommand(): the default of another group kind should be kept")
+			}
+		})
+	}
+}
+
 func TestDevfile200_AddCommands(t *testing.T) {
 	multipleDupError := fmt.Sprintf("%s\n%s", "command command1 already exists in devfile", "command command2 already exists in devfile")
 
@@ -616,3 +860,587 @@ func TestDeleteCommands(t *testing.T) {
 	}
 
 }
+
+func TestDevfile200_GetCommandGraph(t *testing.T) {
+
+	execCommand := func(id string) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					CommandLine: "echo " + id,
+					Component:   "runtime",
+				},
+			},
+		}
+	}
+	compositeCommand := func(id string, commands ...string) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{
+					Commands: commands,
+				},
+			},
+		}
+	}
+
+	missingCommandErr := "command missing is not found in the devfile"
+
+	tests := []struct {
+		name      string
+		commands  []v1.Command
+		events    *v1.Events
+		wantGraph common.CommandGraph
+		wantErr   *string
+	}{
+		{
+			name: "graph with composite commands and events",
+			commands: []v1.Command{
+				execCommand("build"),
+				execCommand("run"),
+				compositeCommand("buildandrun", "build", "Run"),
+			},
+			events: &v1.Events{
+				DevWorkspaceEvents: v1.DevWorkspaceEvents{
+					PostStart: []string{"build"},
+					PreStop:   []string{"buildandrun"},
+				},
+			},
+			wantGraph: common.CommandGraph{
+				Nodes: []common.CommandGraphNode{
+					{ID: "build", Kind: common.CommandGraphCommandNode},
+					{ID: "run", Kind: common.CommandGraphCommandNode},
+					{ID: "buildandrun", Kind: common.CommandGraphCommandNode},
+					{ID: "postStart", Kind: common.CommandGraphEventNode},
+					{ID: "preStop", Kind: common.CommandGraphEventNode},
+				},
+				Edges: []common.CommandGraphEdge{
+					{From: "buildandrun", To: "build", Kind: common.CommandGraphInvokesEdge},
+					{From: "buildandrun", To: "run", Kind: common.CommandGraphInvokesEdge},
+					{From: "build", To: "postStart", Kind: common.CommandGraphTriggeredByEdge},
+					{From: "buildandrun", To: "preStop", Kind: common.CommandGraphTriggeredByEdge},
+				},
+			},
+		},
+		{
+			name: "composite command referencing a missing command",
+			commands: []v1.Command{
+				compositeCommand("buildandrun", "missing"),
+			},
+			wantErr: &missingCommandErr,
+		},
+		{
+			name: "event referencing a missing command",
+			commands: []v1.Command{
+				execCommand("build"),
+			},
+			events: &v1.Events{
+				DevWorkspaceEvents: v1.DevWorkspaceEvents{
+					PreStart: []string{"missing"},
+				},
+			},
+			wantErr: &missingCommandErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Commands: tt.commands,
+							Events:   tt.events,
+						},
+					},
+				},
+			}
+
+			graph, err := d.GetCommandGraph()
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetCommandGraph() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantGraph, graph, "TestDevfile200_GetCommandGraph(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_GetCommandGraph(): Error message should match")
+			}
+		})
+	}
+}
+
+func TestDevfile200_GetCommandsGroupedByComponent(t *testing.T) {
+
+	execCommand := func(id, component string) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					CommandLine: "echo " + id,
+					Component:   component,
+				},
+			},
+		}
+	}
+	applyCommand := v1.Command{
+		Id: "deploy",
+		CommandUnion: v1.CommandUnion{
+			Apply: &v1.ApplyCommand{
+				Component: "kubernetes",
+			},
+		},
+	}
+	compositeCommand := v1.Command{
+		Id: "buildandrun",
+		CommandUnion: v1.CommandUnion{
+			Composite: &v1.CompositeCommand{
+				Commands: []string{"build", "run"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		commands []v1.Command
+		want     map[string][]v1.Command
+	}{
+		{
+			name: "commands grouped by component",
+			commands: []v1.Command{
+				execCommand("build", "runtime"),
+				execCommand("run", "runtime"),
+				execCommand("test", "tools"),
+				applyCommand,
+				compositeCommand,
+			},
+			want: map[string][]v1.Command{
+				"runtime":    {execCommand("build", "runtime"), execCommand("run", "runtime")},
+				"tools":      {execCommand("test", "tools")},
+				"kubernetes": {applyCommand},
+				"":           {compositeCommand},
+			},
+		},
+		{
+			name: "no commands",
+			want: map[string][]v1.Command{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Commands: tt.commands,
+						},
+					},
+				},
+			}
+			assert.Equal(t, tt.want, d.GetCommandsGroupedByComponent(), "TestDevfile200_GetCommandsGroupedByComponent(): The two values should be the same.")
+		})
+	}
+}
+
+func TestDevfile200_GetEffectiveWorkingDir(t *testing.T) {
+
+	execCommand := func(id, component, workingDir string) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					CommandLine: "npm start",
+					Component:   component,
+					WorkingDir:  workingDir,
+				},
+			},
+		}
+	}
+	containerComponent := func(name, sourceMapping string) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						Image:         "image",
+						SourceMapping: sourceMapping,
+					},
+				},
+			},
+		}
+	}
+
+	commands := []v1.Command{
+		execCommand("with-working-dir", "runtime", "${PROJECT_SOURCE}/app"),
+		execCommand("with-source-mapping", "runtime", ""),
+		execCommand("without-source-mapping", "tools", ""),
+		{
+			Id: "composite",
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{
+					Commands: []string{"with-working-dir"},
+				},
+			},
+		},
+	}
+	components := []v1.Component{
+		containerComponent("runtime", "/src"),
+		containerComponent("tools", ""),
+	}
+
+	missingCommandErr := "command missing is not found in the devfile"
+	notExecCommandErr := "command composite is not an exec command"
+
+	tests := []struct {
+		name           string
+		commandID      string
+		wantWorkingDir string
+		wantErr        *string
+	}{
+		{
+			name:           "command with a working directory",
+			commandID:      "with-working-dir",
+			wantWorkingDir: "${PROJECT_SOURCE}/app",
+		},
+		{
+			name:           "command targeting a container with a source mapping",
+			commandID:      "with-source-mapping",
+			wantWorkingDir: "/src",
+		},
+		{
+			name:           "command targeting a container without source mapping",
+			commandID:      "without-source-mapping",
+			wantWorkingDir: DefaultWorkingDir,
+		},
+		{
+			name:           "command id in a different case",
+			commandID:      "With-Working-Dir",
+			wantWorkingDir: "${PROJECT_SOURCE}/app",
+		},
+		{
+			name:      "unknown command",
+			commandID: "missing",
+			wantErr:   &missingCommandErr,
+		},
+		{
+			name:      "command which is not an exec command",
+			commandID: "composite",
+			wantErr:   &notExecCommandErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Commands:   commands,
+							Components: components,
+						},
+					},
+				},
+			}
+
+			workingDir, err := d.GetEffectiveWorkingDir(tt.commandID)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetEffectiveWorkingDir() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantWorkingDir, workingDir, "TestDevfile200_GetEffectiveWorkingDir(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_GetEffectiveWorkingDir(): Error message should match")
+			}
+		})
+	}
+}
+
+func TestDevfile200_GetCommandEnvConflicts(t *testing.T) {
+
+	execCommand := func(id, component string, env []v1.EnvVar) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					CommandLine: "npm start",
+					Component:   component,
+					Env:         env,
+				},
+			},
+		}
+	}
+
+	components := []v1.Component{
+		{
+			Name: "runtime",
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						Image: "quay.io/nodejs-16",
+						Env: []v1.EnvVar{
+							{Name: "PORT", Value: "3000"},
+							{Name: "DEBUG", Value: "false"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "tools",
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						Image: "quay.io/tools",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		commands []v1.Command
+		want     map[string][]string
+	}{
+		{
+			name: "commands overriding container env vars",
+			commands: []v1.Command{
+				execCommand("run", "runtime", []v1.EnvVar{{Name: "PORT", Value: "8080"}}),
+				execCommand("debug", "runtime", []v1.EnvVar{{Name: "DEBUG", Value: "true"}, {Name: "NODE_ENV", Value: "dev"}, {Name: "PORT", Value: "5858"}}),
+			},
+			want: map[string][]string{
+				"run":   {"PORT"},
+				"debug": {"DEBUG", "PORT"},
+			},
+		},
+		{
+			name: "commands not overriding container env vars",
+			commands: []v1.Command{
+				execCommand("build", "runtime", []v1.EnvVar{{Name: "NODE_ENV", Value: "production"}}),
+				execCommand("test", "tools", []v1.EnvVar{{Name: "PORT", Value: "8080"}}),
+				execCommand("missing", "missing", []v1.EnvVar{{Name: "PORT", Value: "8080"}}),
+			},
+			want: map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: components,
+							Commands:   tt.commands,
+						},
+					},
+				},
+			}
+			assert.Equal(t, tt.want, d.GetCommandEnvConflicts(), "TestDevfile200_GetCommandEnvConflicts(): The two values should be the same.")
+		})
+	}
+}
+
+func TestDevfile200_GetComponentsTouchedByCommand(t *testing.T) {
+
+	execCommand := func(id, component string) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					CommandLine: "npm start",
+					Component:   component,
+				},
+			},
+		}
+	}
+	compositeCommand := func(id string, commands ...string) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{
+					Commands: commands,
+				},
+			},
+		}
+	}
+	applyCommand := v1.Command{
+		Id: "deploy",
+		CommandUnion: v1.CommandUnion{
+			Apply: &v1.ApplyCommand{
+				Component: "kubernetes",
+			},
+		},
+	}
+
+	commands := []v1.Command{
+		execCommand("build", "runtime"),
+		execCommand("run", "runtime"),
+		execCommand("test", "tools"),
+		applyCommand,
+		compositeCommand("build-and-run", "build", "run"),
+		compositeCommand("all", "build-and-run", "Test", "deploy", "run"),
+		compositeCommand("loop1", "build", "loop2"),
+		compositeCommand("loop2", "loop1"),
+	}
+
+	missingCommandErr := "command missing is not found in the devfile"
+	cycleErr := "composite command loop1 has a cycle: loop1 -> loop2 -> loop1"
+
+	tests := []struct {
+		name           string
+		commandID      string
+		extraCommands  []v1.Command
+		wantComponents []string
+		wantErr        *string
+	}{
+		{
+			name:           "exec command",
+			commandID:      "test",
+			wantComponents: []string{"tools"},
+		},
+		{
+			name:           "apply command",
+			commandID:      "deploy",
+			wantComponents: []string{"kubernetes"},
+		},
+		{
+			name:           "composite command",
+			commandID:      "build-and-run",
+			wantComponents: []string{"runtime"},
+		},
+		{
+			name:           "nested composite commands",
+			commandID:      "all",
+			wantComponents: []string{"kubernetes", "runtime", "tools"},
+		},
+		{
+			name:      "missing command",
+			commandID: "missing",
+			wantErr:   &missingCommandErr,
+		},
+		{
+			name:          "composite command invoking a missing command",
+			commandID:     "invalid",
+			extraCommands: []v1.Command{compositeCommand("invalid", "build", "missing")},
+			wantErr:       &missingCommandErr,
+		},
+		{
+			name:           "command outside of a cycle",
+			commandID:      "build-and-run",
+			extraCommands:  []v1.Command{compositeCommand("loop3", "loop3")},
+			wantComponents: []string{"runtime"},
+		},
+		{
+			name:      "composite commands with a cycle",
+			commandID: "loop1",
+			wantErr:   &cycleErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Commands: append(append([]v1.Command{}, commands...), tt.extraCommands...),
+						},
+					},
+				},
+			}
+			got, err := d.GetComponentsTouchedByCommand(tt.commandID)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetComponentsTouchedByCommand() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantComponents, got, "TestDevfile200_GetComponentsTouchedByCommand(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_GetComponentsTouchedByCommand(): Error message should match")
+			}
+		})
+	}
+}
+
+func TestDevfile200_GetCommandShell(t *testing.T) {
+
+	execCommand := func(id string, commandAttributes attributes.Attributes) v1.Command {
+		return v1.Command{
+			Id:         id,
+			Attributes: commandAttributes,
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					CommandLine: "npm start",
+					Component:   "runtime",
+				},
+			},
+		}
+	}
+
+	commands := []v1.Command{
+		execCommand("run", nil),
+		execCommand("build", attributes.Attributes{}.PutString(CommandShellAttribute, "/bin/bash")),
+		execCommand("direct", attributes.Attributes{}.PutString(CommandShellAttribute, "")),
+		{
+			Id: "composite",
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{
+					Commands: []string{"run"},
+				},
+			},
+		},
+	}
+
+	missingCommandErr := "command missing is not found in the devfile"
+	notExecCommandErr := "command composite is not an exec command"
+
+	tests := []struct {
+		name      string
+		commandID string
+		wantShell string
+		wantErr   *string
+	}{
+		{
+			name:      "command without shell",
+			commandID: "run",
+			wantShell: DefaultShell,
+		},
+		{
+			name:      "command with shell",
+			commandID: "build",
+			wantShell: "/bin/bash",
+		},
+		{
+			name:      "command run directly",
+			commandID: "direct",
+			wantShell: "",
+		},
+		{
+			name:      "missing command",
+			commandID: "missing",
+			wantErr:   &missingCommandErr,
+		},
+		{
+			name:      "not an exec command",
+			commandID: "composite",
+			wantErr:   &notExecCommandErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevfileHeader: devfilepkg.DevfileHeader{
+						SchemaVersion: "2.2.0",
+					},
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Commands: commands,
+						},
+					},
+				},
+			}
+			shell, err := d.GetCommandShell(tt.commandID)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetCommandShell() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantShell, shell, "TestDevfile200_GetCommandShell(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_GetCommandShell(): Error message should match")
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandGraphNodeKind describes the kind of a node of the command graph
+type CommandGraphNodeKind string
+
+const (
+	// CommandGraphCommandNode is the node of a devfile command, identified by the command id
+	CommandGraphCommandNode CommandGraphNodeKind = "command"
+	// CommandGraphEventNode is the node of a devfile event, identified by the event name, e.g. postStart
+	CommandGraphEventNode CommandGraphNodeKind = "event"
+)
+
+// CommandGraphEdgeKind describes the relationship between the two nodes of an edge of the command graph
+type CommandGraphEdgeKind string
+
+const (
+	// CommandGraphInvokesEdge links a composite command to a command it invokes
+	CommandGraphInvokesEdge CommandGraphEdgeKind = "invokes"
+	// CommandGraphTriggeredByEdge links a command to an event it is triggered by
+	CommandGraphTriggeredByEdge CommandGraphEdgeKind = "triggered-by"
+)
+
+// CommandGraphNode is a node of the command graph
+type CommandGraphNode struct {
+	// ID is the command id or the event name
+	ID string
+	// Kind is the kind of the node
+	Kind CommandGraphNodeKind
+}
+
+// CommandGraphEdge is an edge of the command graph, from a command to the node it depends on
+type CommandGraphEdge struct {
+	// From is the id of the command
+	From string
+	// To is the id of the invoked command or the name of the triggering event
+	To string
+	// Kind is the relationship between the two nodes
+	Kind CommandGraphEdgeKind
+}
+
+// CommandGraph is the dependency graph of the devfile commands, built from the composite commands and the events
+type CommandGraph struct {
+	// Nodes are the commands, followed by the events referencing them
+	Nodes []CommandGraphNode
+	// Edges are the invokes and triggered-by relationships, in the order they are defined in the devfile
+	Edges []CommandGraphEdge
+}
+
+// GetCycle returns the ids of the nodes forming the first cycle found in the graph, the first node being repeated
+// at the end, e.g. [a b a]. It returns nil if the graph has no cycle.
+func (g CommandGraph) GetCycle() []string {
+	cycle, _ := g.walk(g.nodeIDs(), nil)
+	return cycle
+}
+
+// HasCycle returns true if the graph has a cycle
+func (g CommandGraph) HasCycle() bool {
+	return g.GetCycle() != nil
+}
+
+// TopologicalOrder returns the ids of the nodes ordered so that each node comes after the nodes it depends on:
+// commands come after the events triggering them and composite commands come after the commands they invoke.
+// It returns an error if the graph has a cycle.
+func (g CommandGraph) TopologicalOrder() ([]string, error) {
+	cycle, order := g.walk(g.nodeIDs(), nil)
+	if cycle != nil {
+		return nil, fmt.Errorf("command graph has a cycle: %s", strings.Join(cycle, " -> "))
+	}
+	return order, nil
+}
+
+// GetInvokedCommands returns the ids of the commands transitively invoked by the command, the command included,
+// each command coming after the commands it invokes. It returns an error if the invoked commands have a cycle.
+func (g CommandGraph) GetInvokedCommands(id string) ([]string, error) {
+	cycle, order := g.walk([]string{id}, func(edge CommandGraphEdge) bool {
+		return edge.Kind == CommandGraphInvokesEdge
+	})
+	if cycle != nil {
+		return nil, fmt.Errorf("composite command %s has a cycle: %s", id, strings.Join(cycle, " -> "))
+	}
+	return order, nil
+}
+
+// nodeIDs returns the ids of the nodes of the graph, in order
+func (g CommandGraph) nodeIDs() []string {
+	var ids []string
+	for _, node := range g.Nodes {
+		ids = append(ids, node.ID)
+	}
+	return ids
+}
+
+// walk visits the nodes of the graph reachable from the roots depth first, in the order of the roots and edges,
+// following the edges accepted by follow or all the edges if follow is nil. It returns the first cycle found,
+// or the visited nodes in post-order if there is no cycle.
+func (g CommandGraph) walk(roots []string, follow func(CommandGraphEdge) bool) (cycle []string, order []string) {
+	adjacency := make(map[string][]string)
+	for _, edge := range g.Edges {
+		if follow == nil || follow(edge) {
+			adjacency[edge.From] = append(adjacency[edge.From], edge.To)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(id string) bool
+	visit = func(id string) bool {
+		switch state[id] {
+		case visited:
+			return false
+		case visiting:
+			for i, pathID := range path {
+				if pathID == id {
+					cycle = append(append([]string{}, path[i:]...), id)
+					break
+				}
+			}
+			return true
+		}
+		state[id] = visiting
+		path = append(path, id)
+		for _, to := range adjacency[id] {
+			if visit(to) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = visited
+		order = append(order, id)
+		return false
+	}
+
+	for _, root := range roots {
+		if visit(root) {
+			return cycle, nil
+		}
+	}
+	return nil, order
+}
@@ -0,0 +1,148 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandGraph_TopologicalOrder(t *testing.T) {
+
+	commandNode := func(id string) CommandGraphNode {
+		return CommandGraphNode{ID: id, Kind: CommandGraphCommandNode}
+	}
+
+	cycleErr := "command graph has a cycle: outer -> inner -> outer"
+
+	tests := []struct {
+		name      string
+		graph     CommandGraph
+		wantOrder []string
+		wantCycle []string
+		wantErr   *string
+	}{
+		{
+			name: "commands come after the events and the commands they depend on",
+			graph: CommandGraph{
+				Nodes: []CommandGraphNode{
+					commandNode("buildandrun"),
+					commandNode("build"),
+					commandNode("run"),
+					{ID: "postStart", Kind: CommandGraphEventNode},
+				},
+				Edges: []CommandGraphEdge{
+					{From: "buildandrun", To: "build", Kind: CommandGraphInvokesEdge},
+					{From: "buildandrun", To: "run", Kind: CommandGraphInvokesEdge},
+					{From: "buildandrun", To: "postStart", Kind: CommandGraphTriggeredByEdge},
+				},
+			},
+			wantOrder: []string{"build", "run", "postStart", "buildandrun"},
+		},
+		{
+			name:      "empty graph",
+			graph:     CommandGraph{},
+			wantOrder: nil,
+		},
+		{
+			name: "composite commands invoking each other",
+			graph: CommandGraph{
+				Nodes: []CommandGraphNode{
+					commandNode("outer"),
+					commandNode("inner"),
+				},
+				Edges: []CommandGraphEdge{
+					{From: "outer", To: "inner", Kind: CommandGraphInvokesEdge},
+					{From: "inner", To: "outer", Kind: CommandGraphInvokesEdge},
+				},
+			},
+			wantCycle: []string{"outer", "inner", "outer"},
+			wantErr:   &cycleErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantCycle, tt.graph.GetCycle(), "TestCommandGraph_TopologicalOrder(): The two values should be the same.")
+			assert.Equal(t, tt.wantCycle != nil, tt.graph.HasCycle(), "TestCommandGraph_TopologicalOrder(): The two values should be the same.")
+
+			order, err := tt.graph.TopologicalOrder()
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestCommandGraph_TopologicalOrder() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantOrder, order, "TestCommandGraph_TopologicalOrder(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestCommandGraph_TopologicalOrder(): Error message should match")
+			}
+		})
+	}
+}
+
+func TestCommandGraph_GetInvokedCommands(t *testing.T) {
+
+	graph := CommandGraph{
+		Nodes: []CommandGraphNode{
+			{ID: "buildandrun", Kind: CommandGraphCommandNode},
+			{ID: "build", Kind: CommandGraphCommandNode},
+			{ID: "run", Kind: CommandGraphCommandNode},
+			{ID: "loop", Kind: CommandGraphCommandNode},
+			{ID: "postStart", Kind: CommandGraphEventNode},
+		},
+		Edges: []CommandGraphEdge{
+			{From: "buildandrun", To: "build", Kind: CommandGraphInvokesEdge},
+			{From: "buildandrun", To: "run", Kind: CommandGraphInvokesEdge},
+			{From: "buildandrun", To: "postStart", Kind: CommandGraphTriggeredByEdge},
+			{From: "loop", To: "loop", Kind: CommandGraphInvokesEdge},
+		},
+	}
+
+	cycleErr := "composite command loop has a cycle: loop -> loop"
+
+	tests := []struct {
+		name         string
+		id           string
+		wantCommands []string
+		wantErr      *string
+	}{
+		{
+			name:         "composite command",
+			id:           "buildandrun",
+			wantCommands: []string{"build", "run", "buildandrun"},
+		},
+		{
+			name:         "command invoking no command",
+			id:           "run",
+			wantCommands: []string{"run"},
+		},
+		{
+			name:    "composite command invoking itself",
+			id:      "loop",
+			wantErr: &cycleErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := graph.GetInvokedCommands(tt.id)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestCommandGraph_GetInvokedCommands() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantCommands, commands, "TestCommandGraph_GetInvokedCommands(): The two values should be the same.")
+			} else {
+				assert.Equal(t, *tt.wantErr, err.Error(), "TestCommandGraph_GetInvokedCommands(): Error message should match")
+			}
+		})
+	}
+}
@@ -21,6 +21,27 @@ import (
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 )
 
+// CommandEndpointAttribute is the reserved command attribute holding the name of the endpoint the command
+// depends on, e.g. the endpoint of the readiness URL. The value is an endpoint name or a list of endpoint names.
+const CommandEndpointAttribute = "library.devfile.io/endpoint"
+
+// GetCommandEndpointRefs returns the names of the endpoints referenced by the CommandEndpointAttribute of the command
+func GetCommandEndpointRefs(command v1.Command) ([]string, error) {
+	if !command.Attributes.Exists(CommandEndpointAttribute) {
+		return nil, nil
+	}
+
+	var endpointName string
+	if err := command.Attributes.GetInto(CommandEndpointAttribute, &endpointName); err == nil {
+		return []string{endpointName}, nil
+	}
+	var endpointNames []string
+	if err := command.Attributes.GetInto(CommandEndpointAttribute, &endpointNames); err != nil {
+		return nil, fmt.Errorf("attribute %s of command %s should be an endpoint name or a list of endpoint names", CommandEndpointAttribute, command.Id)
+	}
+	return endpointNames, nil
+}
+
 // GetGroup returns the group the command belongs to
 func GetGroup(dc v1.Command) *v1.CommandGroup {
 	switch {
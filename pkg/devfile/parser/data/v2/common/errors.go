@@ -40,3 +40,17 @@ type FieldNotFoundError struct {
 func (e *FieldNotFoundError) Error() string {
 	return fmt.Sprintf("%s %s is not found in the devfile", e.Field, e.Name)
 }
+
+// AttributeTypeError error returned if an attribute value cannot be converted to the requested type
+type AttributeTypeError struct {
+	// attribute key
+	Key string
+	// requested type
+	Type string
+	// conversion error
+	Err error
+}
+
+func (e *AttributeTypeError) Error() string {
+	return fmt.Sprintf("attribute %s cannot be converted to a %s: %v", e.Key, e.Type, e.Err)
+}
@@ -0,0 +1,59 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+)
+
+const (
+	// PreStartEvent is the event type of the commands run before the workspace starts
+	PreStartEvent = "preStart"
+	// PostStartEvent is the event type of the commands run after the workspace starts
+	PostStartEvent = "postStart"
+	// PreStopEvent is the event type of the commands run before the workspace stops
+	PreStopEvent = "preStop"
+	// PostStopEvent is the event type of the commands run after the workspace stops
+	PostStopEvent = "postStop"
+)
+
+// EventTypes are the lifecycle event types of a devfile, in the order of the workspace lifecycle
+var EventTypes = []string{PreStartEvent, PostStartEvent, PreStopEvent, PostStopEvent}
+
+// GetEventCommands returns the command ids bound to the given event type, an empty slice is returned if the event type is unset.
+// It returns an error if the event type is not one of the EventTypes.
+func GetEventCommands(events v1.Events, eventType string) ([]string, error) {
+	var commands []string
+	switch eventType {
+	case PreStartEvent:
+		commands = events.PreStart
+	case PostStartEvent:
+		commands = events.PostStart
+	case PreStopEvent:
+		commands = events.PreStop
+	case PostStopEvent:
+		commands = events.PostStop
+	default:
+		return nil, fmt.Errorf("unknown event type %s, it should be one of %s", eventType, strings.Join(EventTypes, ", "))
+	}
+	if commands == nil {
+		return []string{}, nil
+	}
+	return commands, nil
+}
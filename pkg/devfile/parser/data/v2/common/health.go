@@ -0,0 +1,54 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+// Reserved component attributes holding the health check settings of the component
+const (
+	// StartupTimeoutAttribute is the time in seconds the component is given to start
+	StartupTimeoutAttribute = "library.devfile.io/startup-timeout"
+	// ProbeInitialDelayAttribute is the time in seconds after the component started before it is probed
+	ProbeInitialDelayAttribute = "library.devfile.io/probe-initial-delay"
+	// ProbePeriodAttribute is the time in seconds between two probes of the component
+	ProbePeriodAttribute = "library.devfile.io/probe-period"
+	// ProbeTimeoutAttribute is the time in seconds after which a probe of the component times out
+	ProbeTimeoutAttribute = "library.devfile.io/probe-timeout"
+	// ProbeFailureThresholdAttribute is the number of consecutive failed probes after which the component is unhealthy
+	ProbeFailureThresholdAttribute = "library.devfile.io/probe-failure-threshold"
+)
+
+// Default health check settings of a component which does not set the health check attributes,
+// the probe defaults are the defaults of the Kubernetes probes
+const (
+	DefaultStartupTimeout        int32 = 300
+	DefaultProbeInitialDelay     int32 = 0
+	DefaultProbePeriod           int32 = 10
+	DefaultProbeTimeout          int32 = 1
+	DefaultProbeFailureThreshold int32 = 3
+)
+
+// HealthSettings holds the health check settings of a component, used to build the probes of the component
+type HealthSettings struct {
+	// StartupTimeoutSeconds is the time in seconds the component is given to start
+	StartupTimeoutSeconds int32
+	// InitialDelaySeconds is the time in seconds after the component started before it is probed
+	InitialDelaySeconds int32
+	// PeriodSeconds is the time in seconds between two probes of the component
+	PeriodSeconds int32
+	// TimeoutSeconds is the time in seconds after which a probe of the component times out
+	TimeoutSeconds int32
+	// FailureThreshold is the number of consecutive failed probes after which the component is unhealthy
+	FailureThreshold int32
+}
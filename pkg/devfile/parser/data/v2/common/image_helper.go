@@ -0,0 +1,82 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ImageNormMode describes the canonical form image references are normalized to
+type ImageNormMode string
+
+const (
+	// FullyQualify normalizes the Docker Hub image references to their fully qualified form, e.g. docker.io/library/golang
+	FullyQualify ImageNormMode = "FullyQualify"
+	// Shorten normalizes the Docker Hub image references to their short form, e.g. golang
+	Shorten ImageNormMode = "Shorten"
+)
+
+const (
+	dockerHubDomain       = "docker.io"
+	legacyDockerHubDomain = "index.docker.io"
+	officialRepoPrefix    = "library/"
+)
+
+// NormalizeImageReference returns the image reference in the canonical form of the mode.
+// Only the Docker Hub image references are rewritten, the references of other registries are returned as is.
+func NormalizeImageReference(image string, mode ImageNormMode) (string, error) {
+	if mode != FullyQualify && mode != Shorten {
+		return "", fmt.Errorf("unknown image normalization mode %s", mode)
+	}
+	if image == "" || strings.ContainsAny(image, " \t\n") {
+		return "", fmt.Errorf("invalid image reference %q", image)
+	}
+
+	domain, remainder := splitImageDomain(image)
+	if domain != dockerHubDomain {
+		return image, nil
+	}
+
+	if mode == Shorten {
+		// only the official images, a single path segment under library/, have a short form
+		if official := strings.TrimPrefix(remainder, officialRepoPrefix); official != remainder && !strings.Contains(official, "/") {
+			return official, nil
+		}
+		return remainder, nil
+	}
+	if !strings.Contains(remainder, "/") {
+		remainder = officialRepoPrefix + remainder
+	}
+	return dockerHubDomain + "/" + remainder, nil
+}
+
+// splitImageDomain splits the image reference into its registry domain and the remainder,
+// a reference without domain belongs to Docker Hub
+func splitImageDomain(image string) (string, string) {
+	i := strings.Index(image, "/")
+	if i == -1 {
+		return dockerHubDomain, image
+	}
+	domain := image[:i]
+	if !strings.ContainsAny(domain, ".:") && domain != "localhost" {
+		return dockerHubDomain, image
+	}
+	if domain == legacyDockerHubDomain {
+		domain = dockerHubDomain
+	}
+	return domain, image[i+1:]
+}
@@ -0,0 +1,121 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeImageReference(t *testing.T) {
+
+	unknownModeErr := "unknown image normalization mode Lowercase"
+	invalidImageErr := "invalid image reference \"golang latest\""
+
+	tests := []struct {
+		name      string
+		image     string
+		mode      ImageNormMode
+		wantImage string
+		wantErr   *string
+	}{
+		{
+			name:      "fully qualify an official image",
+			image:     "golang:1.19",
+			mode:      FullyQualify,
+			wantImage: "docker.io/library/golang:1.19",
+		},
+		{
+			name:      "fully qualify an organization image",
+			image:     "devfile/base-developer-image@sha256:abc",
+			mode:      FullyQualify,
+			wantImage: "docker.io/devfile/base-developer-image@sha256:abc",
+		},
+		{
+			name:      "fully qualify a legacy docker hub image",
+			image:     "index.docker.io/golang",
+			mode:      FullyQualify,
+			wantImage: "docker.io/library/golang",
+		},
+		{
+			name:      "fully qualify an image which is already fully qualified",
+			image:     "docker.io/library/golang",
+			mode:      FullyQualify,
+			wantImage: "docker.io/library/golang",
+		},
+		{
+			name:      "shorten an official image",
+			image:     "docker.io/library/golang:1.19",
+			mode:      Shorten,
+			wantImage: "golang:1.19",
+		},
+		{
+			name:      "shorten an organization image",
+			image:     "docker.io/devfile/base-developer-image",
+			mode:      Shorten,
+			wantImage: "devfile/base-developer-image",
+		},
+		{
+			name:      "shorten a nested image under library",
+			image:     "docker.io/library/foo/bar",
+			mode:      Shorten,
+			wantImage: "library/foo/bar",
+		},
+		{
+			name:      "shorten an image which is already short",
+			image:     "golang",
+			mode:      Shorten,
+			wantImage: "golang",
+		},
+		{
+			name:      "images of other registries are not rewritten",
+			image:     "quay.io/devfile/golang:latest",
+			mode:      FullyQualify,
+			wantImage: "quay.io/devfile/golang:latest",
+		},
+		{
+			name:      "images of a registry with a port are not rewritten",
+			image:     "localhost:5000/golang",
+			mode:      Shorten,
+			wantImage: "localhost:5000/golang",
+		},
+		{
+			name:    "unknown mode",
+			image:   "golang",
+			mode:    "Lowercase",
+			wantErr: &unknownModeErr,
+		},
+		{
+			name:    "invalid image reference",
+			image:   "golang latest",
+			mode:    Shorten,
+			wantErr: &invalidImageErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image, err := NormalizeImageReference(tt.image, tt.mode)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestNormalizeImageReference() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantImage, image, "TestNormalizeImageReference(): The two values should be the same.")
+			} else {
+				assert.Equal(t, *tt.wantErr, err.Error(), "TestNormalizeImageReference(): Error message should match")
+			}
+		})
+	}
+}
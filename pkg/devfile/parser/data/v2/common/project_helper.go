@@ -17,6 +17,7 @@ package common
 
 import (
 	"fmt"
+	"path/filepath"
 
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 )
@@ -71,3 +72,21 @@ func GetProjectSourceType(projectSrc v1.ProjectSource) (v1.ProjectSourceType, er
 		return "", fmt.Errorf("unknown project source type")
 	}
 }
+
+// GetProjectClonePath returns the directory the project is cloned to under the projects root directory,
+// the clonePath of the project if set, the project name otherwise
+func GetProjectClonePath(project v1.Project, projectsRoot string) string {
+	if project.ClonePath != "" {
+		return filepath.Join(projectsRoot, project.ClonePath)
+	}
+	return filepath.Join(projectsRoot, project.Name)
+}
+
+// GetStarterProjectPath returns the directory holding the content of the starter project once it is cloned to cloneDir,
+// the subDir of the starter project if set, cloneDir otherwise
+func GetStarterProjectPath(starterProject v1.StarterProject, cloneDir string) string {
+	if starterProject.SubDir != "" {
+		return filepath.Join(cloneDir, starterProject.SubDir)
+	}
+	return cloneDir
+}
@@ -17,6 +17,7 @@ package common
 
 import (
 	"github.com/stretchr/testify/assert"
+	"path/filepath"
 	"testing"
 
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
@@ -185,3 +186,55 @@ func TestGetProjectSrcType(t *testing.T) {
 	}
 
 }
+
+func TestGetProjectClonePath(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		project       v1.Project
+		wantClonePath string
+	}{
+		{
+			name:          "project without clonePath",
+			project:       v1.Project{Name: "nodejs"},
+			wantClonePath: filepath.Join("/projects", "nodejs"),
+		},
+		{
+			name:          "project with clonePath",
+			project:       v1.Project{Name: "nodejs", ClonePath: "src/app"},
+			wantClonePath: filepath.Join("/projects", "src", "app"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clonePath := GetProjectClonePath(tt.project, "/projects")
+			assert.Equal(t, tt.wantClonePath, clonePath, "TestGetProjectClonePath(): The two values should be the same.")
+		})
+	}
+}
+
+func TestGetStarterProjectPath(t *testing.T) {
+
+	tests := []struct {
+		name           string
+		starterProject v1.StarterProject
+		wantPath       string
+	}{
+		{
+			name:           "starter project without subDir",
+			starterProject: v1.StarterProject{Name: "nodejs-starter"},
+			wantPath:       filepath.Join("/tmp", "starter"),
+		},
+		{
+			name:           "starter project with subDir",
+			starterProject: v1.StarterProject{Name: "nodejs-starter", SubDir: "/app/"},
+			wantPath:       filepath.Join("/tmp", "starter", "app"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := GetStarterProjectPath(tt.starterProject, filepath.Join("/tmp", "starter"))
+			assert.Equal(t, tt.wantPath, path, "TestGetStarterProjectPath(): The two values should be the same.")
+		})
+	}
+}
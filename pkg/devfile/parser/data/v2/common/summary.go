@@ -0,0 +1,77 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+)
+
+// DevfileSummary holds the key facts of a parsed devfile
+type DevfileSummary struct {
+	// Name is the name of the devfile metadata
+	Name string
+	// SchemaVersion is the schema version of the devfile
+	SchemaVersion string
+	// ComponentCounts is the number of components by component type
+	ComponentCounts map[v1.ComponentType]int
+	// CommandCounts is the number of commands by command group kind, the commands without a group are not counted
+	CommandCounts map[v1.CommandGroupKind]int
+	// DefaultCommands is the id of the default command by command group kind
+	DefaultCommands map[v1.CommandGroupKind]string
+	// DeclaredVariables are the sorted names of the top-level variables of the devfile
+	DeclaredVariables []string
+	// UsedVariables are the sorted names of the variables referenced as {{name}} in the devfile
+	UsedVariables []string
+	// RemoteReferenceCount is the number of references to remote resources, i.e. the parent, the plugin
+	// components and the kubernetes and openshift components defined by a uri
+	RemoteReferenceCount int
+}
+
+// String returns the summary in a human-readable form, the entries are sorted so the output is stable
+func (s DevfileSummary) String() string {
+	var componentCounts []string
+	for componentType, count := range s.ComponentCounts {
+		componentCounts = append(componentCounts, fmt.Sprintf("%s=%d", componentType, count))
+	}
+	sort.Strings(componentCounts)
+
+	var commandCounts []string
+	for groupKind, count := range s.CommandCounts {
+		commandCounts = append(commandCounts, fmt.Sprintf("%s=%d", groupKind, count))
+	}
+	sort.Strings(commandCounts)
+
+	var defaultCommands []string
+	for groupKind, id := range s.DefaultCommands {
+		defaultCommands = append(defaultCommands, fmt.Sprintf("%s=%s", groupKind, id))
+	}
+	sort.Strings(defaultCommands)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Name: %s\n", s.Name)
+	fmt.Fprintf(&b, "Schema Version: %s\n", s.SchemaVersion)
+	fmt.Fprintf(&b, "Components: %s\n", strings.Join(componentCounts, ", "))
+	fmt.Fprintf(&b, "Commands: %s\n", strings.Join(commandCounts, ", "))
+	fmt.Fprintf(&b, "Default Commands: %s\n", strings.Join(defaultCommands, ", "))
+	fmt.Fprintf(&b, "Declared Variables: %s\n", strings.Join(s.DeclaredVariables, ", "))
+	fmt.Fprintf(&b, "Used Variables: %s\n", strings.Join(s.UsedVariables, ", "))
+	fmt.Fprintf(&b, "Remote References: %d\n", s.RemoteReferenceCount)
+	return b.String()
+}
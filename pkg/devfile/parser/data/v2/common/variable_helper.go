@@ -0,0 +1,48 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// variableReferenceRegex matches the {{variable}} references replaced by the devfile/api variable substitution
+var variableReferenceRegex = regexp.MustCompile(`\{\{\s*(.*?)\s*\}\}`)
+
+// variableNameRegex matches the variable names which can be referenced as {{name}} by the variable substitution
+var variableNameRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// IsValidVariableName returns true if the variable name can be referenced as {{name}} by the variable substitution
+func IsValidVariableName(name string) bool {
+	return variableNameRegex.MatchString(name)
+}
+
+// GetVariableReferences returns the names of the variables referenced by the {{variable}} references of the values,
+// the values are walked through their JSON form
+func GetVariableReferences(values ...interface{}) (map[string]bool, error) {
+	referenced := make(map[string]bool)
+	for _, value := range values {
+		content, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range variableReferenceRegex.FindAllStringSubmatch(string(content), -1) {
+			referenced[match[1]] = true
+		}
+	}
+	return referenced, nil
+}
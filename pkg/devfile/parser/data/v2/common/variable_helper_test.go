@@ -0,0 +1,73 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVariableReferences(t *testing.T) {
+
+	type section struct {
+		Image   string
+		Command []string
+	}
+
+	tests := []struct {
+		name           string
+		values         []interface{}
+		wantReferences map[string]bool
+	}{
+		{
+			name: "references in several values",
+			values: []interface{}{
+				section{Image: "golang:{{ version }}", Command: []string{"go", "{{goflags}}"}},
+				[]string{"{{version}}", "{{ app.name }}"},
+			},
+			wantReferences: map[string]bool{"version": true, "goflags": true, "app.name": true},
+		},
+		{
+			name:           "no reference",
+			values:         []interface{}{section{Image: "golang"}, nil},
+			wantReferences: map[string]bool{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			references, err := GetVariableReferences(tt.values...)
+			if err != nil {
+				t.Errorf("TestGetVariableReferences(): unexpected error: %v", err)
+				return
+			}
+			assert.Equal(t, tt.wantReferences, references, "TestGetVariableReferences(): The two values should be the same.")
+		})
+	}
+}
+
+func TestIsValidVariableName(t *testing.T) {
+	for name, want := range map[string]bool{
+		"version":  true,
+		"app.name": true,
+		"go_flags": true,
+		"":         false,
+		"a b":      false,
+		"a}}":      false,
+	} {
+		assert.Equal(t, want, IsValidVariableName(name), "TestIsValidVariableName(): The two values should be the same for %q.", name)
+	}
+}
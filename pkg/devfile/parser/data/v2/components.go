@@ -16,12 +16,17 @@
 package v2
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog"
 )
 
 // GetComponents returns the slice of Component objects parsed from the Devfile
@@ -58,6 +63,36 @@ func (d *DevfileV2) GetComponents(options common.DevfileOptions) ([]v1.Component
 	return components, nil
 }
 
+// GetContainerComponents returns the container components of the devfile which match the filter options.
+// Any component type set in the options is ignored.
+func (d *DevfileV2) GetContainerComponents(options common.DevfileOptions) ([]v1.Component, error) {
+	return d.getComponentsOfType(v1.ContainerComponentType, options)
+}
+
+// GetVolumeComponents returns the volume components of the devfile which match the filter options.
+// Any component type set in the options is ignored.
+func (d *DevfileV2) GetVolumeComponents(options common.DevfileOptions) ([]v1.Component, error) {
+	return d.getComponentsOfType(v1.VolumeComponentType, options)
+}
+
+// GetKubernetesComponents returns the kubernetes components of the devfile which match the filter options.
+// Any component type set in the options is ignored.
+func (d *DevfileV2) GetKubernetesComponents(options common.DevfileOptions) ([]v1.Component, error) {
+	return d.getComponentsOfType(v1.KubernetesComponentType, options)
+}
+
+// GetImageComponents returns the image components of the devfile which match the filter options.
+// Any component type set in the options is ignored.
+func (d *DevfileV2) GetImageComponents(options common.DevfileOptions) ([]v1.Component, error) {
+	return d.getComponentsOfType(v1.ImageComponentType, options)
+}
+
+// getComponentsOfType returns the components of the given type which match the filter options
+func (d *DevfileV2) getComponentsOfType(componentType v1.ComponentType, options common.DevfileOptions) ([]v1.Component, error) {
+	options.ComponentOptions.ComponentType = componentType
+	return d.GetComponents(options)
+}
+
 // GetDevfileContainerComponents iterates through the components in the devfile and returns a list of devfile container components.
 // Deprecated, use GetComponents() with the DevfileOptions.
 func (d *DevfileV2) GetDevfileContainerComponents(options common.DevfileOptions) ([]v1.Component, error) {
@@ -90,13 +125,237 @@ func (d *DevfileV2) GetDevfileVolumeComponents(options common.DevfileOptions) ([
 	return components, nil
 }
 
+// GetAutoBuildImageComponents returns the image components of the devfile which are built automatically during startup.
+// An image component with an unset autoBuild is not built automatically, as defined by the spec default.
+func (d *DevfileV2) GetAutoBuildImageComponents() []v1.Component {
+	var components []v1.Component
+	for _, comp := range d.Components {
+		if comp.Image != nil && comp.Image.GetAutoBuild() {
+			components = append(components, comp)
+		}
+	}
+	return components
+}
+
+// GetDeployByDefaultComponents returns the kubernetes and openshift components of the devfile which are deployed automatically during startup.
+// A kubernetes or openshift component with an unset deployByDefault is not deployed automatically, as defined by the spec default.
+func (d *DevfileV2) GetDeployByDefaultComponents() []v1.Component {
+	var components []v1.Component
+	for _, comp := range d.Components {
+		if (comp.Kubernetes != nil && comp.Kubernetes.GetDeployByDefault()) || (comp.Openshift != nil && comp.Openshift.GetDeployByDefault()) {
+			components = append(components, comp)
+		}
+	}
+	return components
+}
+
+// GetContainersWithoutEndpoints returns the names of the container components which do not expose any endpoint
+func (d *DevfileV2) GetContainersWithoutEndpoints() []string {
+	var names []string
+	for _, comp := range d.Components {
+		if comp.Container != nil && len(comp.Container.Endpoints) == 0 {
+			names = append(names, comp.Name)
+		}
+	}
+	return names
+}
+
+// GetEndpointAnnotations returns the string attributes of the specified endpoint of a container, kubernetes or openshift
+// component, to be set as the annotations of the ingress or route exposing the endpoint. Non-string attributes are skipped.
+func (d *DevfileV2) GetEndpointAnnotations(componentName, endpointName string) (map[string]string, error) {
+	endpoint, err := d.getEndpoint(componentName, endpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := make(map[string]string)
+	for key, value := range endpoint.Attributes {
+		var annotation string
+		if err := json.Unmarshal(value.Raw, &annotation); err != nil {
+			klog.V(4).Infof("skipping the non-string attribute %s of endpoint %s of component %s", key, endpointName, componentName)
+			continue
+		}
+		annotations[key] = annotation
+	}
+	return annotations, nil
+}
+
+// GetEffectiveExposure returns the exposure of the specified endpoint of a container, kubernetes or openshift component,
+// an endpoint with an unset exposure is public as defined by the spec default
+func (d *DevfileV2) GetEffectiveExposure(componentName, endpointName string) (v1.EndpointExposure, error) {
+	endpoint, err := d.getEndpoint(componentName, endpointName)
+	if err != nil {
+		return "", err
+	}
+	if endpoint.Exposure == "" {
+		return v1.PublicEndpointExposure, nil
+	}
+	return endpoint.Exposure, nil
+}
+
+// getEndpoint returns the specified endpoint of a container, kubernetes or openshift component
+func (d *DevfileV2) getEndpoint(componentName, endpointName string) (v1.Endpoint, error) {
+	for _, component := range d.Components {
+		if component.Name != componentName {
+			continue
+		}
+		for _, endpoint := range getComponentEndpoints(component) {
+			if endpoint.Name == endpointName {
+				return endpoint, nil
+			}
+		}
+		return v1.Endpoint{}, &common.FieldNotFoundError{
+			Field: "endpoint",
+			Name:  endpointName,
+		}
+	}
+
+	return v1.Endpoint{}, &common.FieldNotFoundError{
+		Field: "component",
+		Name:  componentName,
+	}
+}
+
+// getComponentEndpoints returns the endpoints of a container, kubernetes or openshift component
+func getComponentEndpoints(component v1.Component) []v1.Endpoint {
+	switch {
+	case component.Container != nil:
+		return component.Container.Endpoints
+	case component.Kubernetes != nil:
+		return component.Kubernetes.Endpoints
+	case component.Openshift != nil:
+		return component.Openshift.Endpoints
+	}
+	return nil
+}
+
+// GetComponentHealthSettings returns the health check settings of the specified component from its health check
+// attributes, the defaults are returned for the unset attributes. It returns an error if an attribute is not a
+// non-negative integer or a string holding one.
+func (d *DevfileV2) GetComponentHealthSettings(componentName string) (common.HealthSettings, error) {
+	for _, component := range d.Components {
+		if component.Name != componentName {
+			continue
+		}
+		settings := common.HealthSettings{
+			StartupTimeoutSeconds: common.DefaultStartupTimeout,
+			InitialDelaySeconds:   common.DefaultProbeInitialDelay,
+			PeriodSeconds:         common.DefaultProbePeriod,
+			TimeoutSeconds:        common.DefaultProbeTimeout,
+			FailureThreshold:      common.DefaultProbeFailureThreshold,
+		}
+		var errorsList []string
+		for key, setting := range map[string]*int32{
+			common.StartupTimeoutAttribute:        &settings.StartupTimeoutSeconds,
+			common.ProbeInitialDelayAttribute:     &settings.InitialDelaySeconds,
+			common.ProbePeriodAttribute:           &settings.PeriodSeconds,
+			common.ProbeTimeoutAttribute:          &settings.TimeoutSeconds,
+			common.ProbeFailureThresholdAttribute: &settings.FailureThreshold,
+		} {
+			value, ok := component.Attributes[key]
+			if !ok {
+				continue
+			}
+			raw := strings.Trim(string(value.Raw), `"`)
+			parsed, err := strconv.ParseInt(raw, 10, 32)
+			if err != nil || parsed < 0 {
+				errorsList = append(errorsList, fmt.Sprintf("invalid value %s for attribute %s, it should be a non-negative integer", string(value.Raw), key))
+				continue
+			}
+			*setting = int32(parsed)
+		}
+		if len(errorsList) > 0 {
+			sort.Strings(errorsList)
+			return common.HealthSettings{}, fmt.Errorf("errors while getting the health settings of component %s:\n%s", componentName, strings.Join(errorsList, "\n"))
+		}
+		return settings, nil
+	}
+
+	return common.HealthSettings{}, &common.FieldNotFoundError{
+		Field: "component",
+		Name:  componentName,
+	}
+}
+
+// NormalizeImageReferences rewrites the image references of the container and image components, and of the container
+// components overridden by the plugins, to the canonical form of the mode. It returns a total error of all invalid references,
+// the devfile is then left unchanged.
+func (d *DevfileV2) NormalizeImageReferences(mode common.ImageNormMode) error {
+	var errorsList []string
+	var images []*string
+	var normalizedImages []string
+	normalize := func(image *string) {
+		if *image == "" {
+			return
+		}
+		normalizedImage, err := common.NormalizeImageReference(*image, mode)
+		if err != nil {
+			errorsList = append(errorsList, err.Error())
+			return
+		}
+		images = append(images, image)
+		normalizedImages = append(normalizedImages, normalizedImage)
+	}
+
+	for i := range d.Components {
+		component := &d.Components[i]
+		switch {
+		case component.Container != nil:
+			normalize(&component.Container.Image)
+		case component.Image != nil:
+			normalize(&component.Image.ImageName)
+		case component.Plugin != nil:
+			for j := range component.Plugin.Components {
+				if component.Plugin.Components[j].Container != nil {
+					normalize(&component.Plugin.Components[j].Container.Image)
+				}
+			}
+		}
+	}
+
+	if len(errorsList) > 0 {
+		return fmt.Errorf("errors while normalizing image references:\n%s", strings.Join(errorsList, "\n"))
+	}
+	for i, image := range images {
+		*image = normalizedImages[i]
+	}
+	return nil
+}
+
+// GetComponentByName returns the component with the given name,
+// return an error if the component is not found or if several components have the name
+func (d *DevfileV2) GetComponentByName(name string) (v1.Component, error) {
+	var found []v1.Component
+	for _, component := range d.Components {
+		if component.Name == name {
+			found = append(found, component)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return v1.Component{}, &common.FieldNotFoundError{
+			Field: "component",
+			Name:  name,
+		}
+	case 1:
+		return found[0], nil
+	default:
+		return v1.Component{}, fmt.Errorf("component %s is defined %d times in the devfile", name, len(found))
+	}
+}
+
 // AddComponents adds the slice of Component objects to the devfile's components
 // a component is considered as invalid if it is already defined
 // component list passed in will be all processed, and returns a total error of all invalid components
 func (d *DevfileV2) AddComponents(components []v1.Component) error {
 	var errorsList []string
 	for _, component := range components {
-		var err error
+		err := validateVolumeSize(component)
+		if err != nil {
+			errorsList = append(errorsList, err.Error())
+			continue
+		}
 		for _, devfileComponent := range d.Components {
 			if component.Name == devfileComponent.Name {
 				err = &common.FieldAlreadyExistError{Name: component.Name, Field: "component"}
@@ -117,6 +376,9 @@ func (d *DevfileV2) AddComponents(components []v1.Component) error {
 // UpdateComponent updates the component with the given name
 // return an error if the component is not found
 func (d *DevfileV2) UpdateComponent(component v1.Component) error {
+	if err := validateVolumeSize(component); err != nil {
+		return err
+	}
 	for i := range d.Components {
 		if d.Components[i].Name == component.Name {
 			d.Components[i] = component
@@ -126,6 +388,18 @@ func (d *DevfileV2) UpdateComponent(component v1.Component) error {
 	return fmt.Errorf("update component failed: component %s not found", component.Name)
 }
 
+// validateVolumeSize checks that the size of a volume component is a Kubernetes quantity,
+// an empty size is valid and means the default size
+func validateVolumeSize(component v1.Component) error {
+	if component.Volume == nil || component.Volume.Size == "" {
+		return nil
+	}
+	if _, err := resource.ParseQuantity(component.Volume.Size); err != nil {
+		return fmt.Errorf("invalid size %s of volume %s, it should be a Kubernetes quantity such as 1Gi: %v", component.Volume.Size, component.Name, err)
+	}
+	return nil
+}
+
 // DeleteComponent removes the specified component
 func (d *DevfileV2) DeleteComponent(name string) error {
 
@@ -30,6 +30,7 @@ import (
 
 func TestDevfile200_AddComponent(t *testing.T) {
 	multipleDupError := fmt.Sprintf("%s\n%s", "component component1 already exists in devfile", "component component2 already exists in devfile")
+	invalidSizeErr := "invalid size 1 Gig of volume volume1, it should be a Kubernetes quantity such as 1Gi"
 
 	tests := []struct {
 		name              string
@@ -142,6 +143,89 @@ func TestDevfile200_AddComponent(t *testing.T) {
 			},
 			wantErr: &multipleDupError,
 		},
+		{
+			name: "successfully add volumes with valid or empty sizes",
+			newComponents: []v1.Component{
+				{
+					Name: "volume1",
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{
+							Volume: v1.Volume{
+								Size: "512Mi",
+							},
+						},
+					},
+				},
+				{
+					Name: "volume2",
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{
+							Volume: v1.Volume{
+								Size: "2Gi",
+							},
+						},
+					},
+				},
+				{
+					Name: "volume3",
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{
+							Volume: v1.Volume{
+								Size: "",
+							},
+						},
+					},
+				},
+			},
+			wantComponents: []v1.Component{
+				{
+					Name: "volume1",
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{
+							Volume: v1.Volume{
+								Size: "512Mi",
+							},
+						},
+					},
+				},
+				{
+					Name: "volume2",
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{
+							Volume: v1.Volume{
+								Size: "2Gi",
+							},
+						},
+					},
+				},
+				{
+					Name: "volume3",
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{
+							Volume: v1.Volume{
+								Size: "",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "error out on invalid volume size",
+			newComponents: []v1.Component{
+				{
+					Name: "volume1",
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{
+							Volume: v1.Volume{
+								Size: "1 Gig",
+							},
+						},
+					},
+				},
+			},
+			wantErr: &invalidSizeErr,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -172,6 +256,7 @@ func TestDevfile200_AddComponent(t *testing.T) {
 
 func TestDevfile200_UpdateComponent(t *testing.T) {
 	invalidCmpErr := "update component failed: component .* not found"
+	invalidSizeErr := "invalid size 1 Gig of volume volume1, it should be a Kubernetes quantity such as 1Gi"
 
 	tests := []struct {
 		name              string
@@ -236,6 +321,32 @@ func TestDevfile200_UpdateComponent(t *testing.T) {
 			},
 			wantErr: &invalidCmpErr,
 		},
+		{
+			name: "fail to update the volume with an invalid size",
+			currentComponents: []v1.Component{
+				{
+					Name: "volume1",
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{
+							Volume: v1.Volume{
+								Size: "1Gi",
+							},
+						},
+					},
+				},
+			},
+			newComponent: v1.Component{
+				Name: "volume1",
+				ComponentUnion: v1.ComponentUnion{
+					Volume: &v1.VolumeComponent{
+						Volume: v1.Volume{
+							Size: "1 Gig",
+						},
+					},
+				},
+			},
+			wantErr: &invalidSizeErr,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -745,6 +856,700 @@ func TestGetDevfileVolumeComponents(t *testing.T) {
 
 }
 
+func TestGetAutoBuildAndDeployByDefaultComponents(t *testing.T) {
+
+	imageComponent := func(name string, autoBuild *bool) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Image: &v1.ImageComponent{
+					Image: v1.Image{
+						ImageName: "image:latest",
+						ImageUnion: v1.ImageUnion{
+							AutoBuild: autoBuild,
+						},
+					},
+				},
+			},
+		}
+	}
+	kubernetesComponent := func(name string, deployByDefault *bool) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Kubernetes: &v1.KubernetesComponent{
+					K8sLikeComponent: v1.K8sLikeComponent{
+						DeployByDefault: deployByDefault,
+					},
+				},
+			},
+		}
+	}
+	openshiftComponent := func(name string, deployByDefault *bool) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Openshift: &v1.OpenshiftComponent{
+					K8sLikeComponent: v1.K8sLikeComponent{
+						DeployByDefault: deployByDefault,
+					},
+				},
+			},
+		}
+	}
+
+	isTrue := true
+	isFalse := false
+
+	tests := []struct {
+		name                          string
+		components                    []v1.Component
+		wantAutoBuildComponents       []v1.Component
+		wantDeployByDefaultComponents []v1.Component
+	}{
+		{
+			name: "components with the flags set to true are returned",
+			components: []v1.Component{
+				testingutil.GetFakeContainerComponent("container"),
+				imageComponent("image-true", &isTrue),
+				imageComponent("image-false", &isFalse),
+				kubernetesComponent("kubernetes-true", &isTrue),
+				kubernetesComponent("kubernetes-false", &isFalse),
+				openshiftComponent("openshift-true", &isTrue),
+			},
+			wantAutoBuildComponents: []v1.Component{
+				imageComponent("image-true", &isTrue),
+			},
+			wantDeployByDefaultComponents: []v1.Component{
+				kubernetesComponent("kubernetes-true", &isTrue),
+				openshiftComponent("openshift-true", &isTrue),
+			},
+		},
+		{
+			name: "components with unset flags default to false",
+			components: []v1.Component{
+				imageComponent("image", nil),
+				kubernetesComponent("kubernetes", nil),
+				openshiftComponent("openshift", nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: tt.components,
+						},
+					},
+				},
+			}
+			assert.Equal(t, tt.wantAutoBuildComponents, d.GetAutoBuildImageComponents(), "TestGetAutoBuildAndDeployByDefaultComponents(): The two values should be the same.")
+			assert.Equal(t, tt.wantDeployByDefaultComponents, d.GetDeployByDefaultComponents(), "TestGetAutoBuildAndDeployByDefaultComponents(): The two values should be the same.")
+		})
+	}
+}
+
+func TestDevfile200_NormalizeImageReferences(t *testing.T) {
+
+	getComponents := func(containerImage, imageName, pluginImage string) []v1.Component {
+		return []v1.Component{
+			{
+				Name: "runtime",
+				ComponentUnion: v1.ComponentUnion{
+					Container: &v1.ContainerComponent{
+						Container: v1.Container{
+							Image: containerImage,
+						},
+					},
+				},
+			},
+			{
+				Name: "image",
+				ComponentUnion: v1.ComponentUnion{
+					Image: &v1.ImageComponent{
+						Image: v1.Image{
+							ImageName: imageName,
+						},
+					},
+				},
+			},
+			{
+				Name: "plugin",
+				ComponentUnion: v1.ComponentUnion{
+					Plugin: &v1.PluginComponent{
+						PluginOverrides: v1.PluginOverrides{
+							Components: []v1.ComponentPluginOverride{
+								{
+									Name: "tools",
+									ComponentUnionPluginOverride: v1.ComponentUnionPluginOverride{
+										Container: &v1.ContainerComponentPluginOverride{
+											ContainerPluginOverride: v1.ContainerPluginOverride{
+												Image: pluginImage,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			testingutil.GetFakeVolumeComponent("volume", "1Gi"),
+		}
+	}
+
+	invalidImageErr := "errors while normalizing image references:\ninvalid image reference \"golang latest\""
+
+	tests := []struct {
+		name           string
+		components     []v1.Component
+		mode           common.ImageNormMode
+		wantComponents []v1.Component
+		wantErr        *string
+	}{
+		{
+			name:           "fully qualify the image references",
+			components:     getComponents("golang", "docker.io/devfile/app", "quay.io/devfile/tools"),
+			mode:           common.FullyQualify,
+			wantComponents: getComponents("docker.io/library/golang", "docker.io/devfile/app", "quay.io/devfile/tools"),
+		},
+		{
+			name:           "shorten the image references",
+			components:     getComponents("docker.io/library/golang", "index.docker.io/devfile/app", "docker.io/library/tools"),
+			mode:           common.Shorten,
+			wantComponents: getComponents("golang", "devfile/app", "tools"),
+		},
+		{
+			name:           "invalid image reference leaves the valid ones unchanged",
+			components:     getComponents("golang latest", "docker.io/library/app", "docker.io/library/tools"),
+			mode:           common.Shorten,
+			wantComponents: getComponents("golang latest", "docker.io/library/app", "docker.io/library/tools"),
+			wantErr:        &invalidImageErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: tt.components,
+						},
+					},
+				},
+			}
+
+			err := d.NormalizeImageReferences(tt.mode)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_NormalizeImageReferences() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantComponents, d.Components, "TestDevfile200_NormalizeImageReferences(): The two values should be the same.")
+			} else {
+				assert.Equal(t, *tt.wantErr, err.Error(), "TestDevfile200_NormalizeImageReferences(): Error message should match")
+				assert.Equal(t, tt.wantComponents, d.Components, "TestDevfile200_NormalizeImageReferences(): The two values should be the same.")
+			}
+		})
+	}
+}
+
+func TestDevfile200_GetContainersWithoutEndpoints(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		components []v1.Component
+		want       []string
+	}{
+		{
+			name: "containers with and without endpoints",
+			components: []v1.Component{
+				testingutil.GenerateDummyContainerComponent("with-endpoints", nil, []v1.Endpoint{{Name: "http", TargetPort: 8080}}, nil, v1.Annotation{}, nil),
+				{
+					Name: "without-endpoints",
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Container: v1.Container{
+								Image: "image",
+							},
+						},
+					},
+				},
+				testingutil.GetFakeVolumeComponent("volume", "1Gi"),
+			},
+			want: []string{"without-endpoints"},
+		},
+		{
+			name: "no containers",
+			components: []v1.Component{
+				testingutil.GetFakeVolumeComponent("volume", "1Gi"),
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: tt.components,
+						},
+					},
+				},
+			}
+			assert.Equal(t, tt.want, d.GetContainersWithoutEndpoints(), "TestDevfile200_GetContainersWithoutEndpoints(): The two values should be the same.")
+		})
+	}
+}
+
+func TestDevfile200_GetEndpointAnnotations(t *testing.T) {
+
+	endpointAttributes := attributes.Attributes{}.
+		PutString("nginx.ingress.kubernetes.io/rewrite-target", "/").
+		PutString("haproxy.router.openshift.io/timeout", "5m").
+		PutInteger("replicas", 2)
+
+	components := []v1.Component{
+		testingutil.GenerateDummyContainerComponent("runtime", nil, []v1.Endpoint{
+			{Name: "http", TargetPort: 8080, Attributes: endpointAttributes},
+			{Name: "debug", TargetPort: 5858},
+		}, nil, v1.Annotation{}, nil),
+		{
+			Name: "deploy",
+			ComponentUnion: v1.ComponentUnion{
+				Kubernetes: &v1.KubernetesComponent{
+					K8sLikeComponent: v1.K8sLikeComponent{
+						Endpoints: []v1.Endpoint{
+							{Name: "api", TargetPort: 3000, Attributes: attributes.Attributes{}.PutString("cert-manager.io/issuer", "letsencrypt")},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	missingComponentErr := "component missing is not found in the devfile"
+	missingEndpointErr := "endpoint missing is not found in the devfile"
+
+	tests := []struct {
+		name            string
+		componentName   string
+		endpointName    string
+		wantAnnotations map[string]string
+		wantErr         *string
+	}{
+		{
+			name:          "container endpoint with string and non-string attributes",
+			componentName: "runtime",
+			endpointName:  "http",
+			wantAnnotations: map[string]string{
+				"nginx.ingress.kubernetes.io/rewrite-target": "/",
+				"haproxy.router.openshift.io/timeout":        "5m",
+			},
+		},
+		{
+			name:            "container endpoint without attributes",
+			componentName:   "runtime",
+			endpointName:    "debug",
+			wantAnnotations: map[string]string{},
+		},
+		{
+			name:          "kubernetes endpoint",
+			componentName: "deploy",
+			endpointName:  "api",
+			wantAnnotations: map[string]string{
+				"cert-manager.io/issuer": "letsencrypt",
+			},
+		},
+		{
+			name:          "missing component",
+			componentName: "missing",
+			endpointName:  "http",
+			wantErr:       &missingComponentErr,
+		},
+		{
+			name:          "missing endpoint",
+			componentName: "runtime",
+			endpointName:  "missing",
+			wantErr:       &missingEndpointErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: components,
+						},
+					},
+				},
+			}
+			annotations, err := d.GetEndpointAnnotations(tt.componentName, tt.endpointName)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetEndpointAnnotations() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantAnnotations, annotations, "TestDevfile200_GetEndpointAnnotations(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_GetEndpointAnnotations(): Error message should match")
+			}
+		})
+	}
+}
+
+func TestDevfile200_GetEffectiveExposure(t *testing.T) {
+
+	components := []v1.Component{
+		testingutil.GenerateDummyContainerComponent("runtime", nil, []v1.Endpoint{
+			{Name: "http", TargetPort: 8080},
+			{Name: "debug", TargetPort: 5858, Exposure: v1.NoneEndpointExposure},
+			{Name: "metrics", TargetPort: 9090, Exposure: v1.InternalEndpointExposure},
+		}, nil, v1.Annotation{}, nil),
+	}
+
+	missingComponentErr := "component missing is not found in the devfile"
+	missingEndpointErr := "endpoint missing is not found in the devfile"
+
+	tests := []struct {
+		name          string
+		componentName string
+		endpointName  string
+		wantExposure  v1.EndpointExposure
+		wantErr       *string
+	}{
+		{
+			name:          "endpoint with unset exposure",
+			componentName: "runtime",
+			endpointName:  "http",
+			wantExposure:  v1.PublicEndpointExposure,
+		},
+		{
+			name:          "endpoint with none exposure",
+			componentName: "runtime",
+			endpointName:  "debug",
+			wantExposure:  v1.NoneEndpointExposure,
+		},
+		{
+			name:          "endpoint with internal exposure",
+			componentName: "runtime",
+			endpointName:  "metrics",
+			wantExposure:  v1.InternalEndpointExposure,
+		},
+		{
+			name:          "missing component",
+			componentName: "missing",
+			endpointName:  "http",
+			wantErr:       &missingComponentErr,
+		},
+		{
+			name:          "missing endpoint",
+			componentName: "runtime",
+			endpointName:  "missing",
+			wantErr:       &missingEndpointErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: components,
+						},
+					},
+				},
+			}
+			exposure, err := d.GetEffectiveExposure(tt.componentName, tt.endpointName)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetEffectiveExposure() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantExposure, exposure, "TestDevfile200_GetEffectiveExposure(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_GetEffectiveExposure(): Error message should match")
+			}
+		})
+	}
+}
+
+func TestDevfile200_GetComponentHealthSettings(t *testing.T) {
+
+	containerComponent := func(name string, componentAttributes attributes.Attributes) v1.Component {
+		return v1.Component{
+			Name:       name,
+			Attributes: componentAttributes,
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						Image: "image",
+					},
+				},
+			},
+		}
+	}
+
+	components := []v1.Component{
+		containerComponent("defaults", nil),
+		containerComponent("configured", attributes.Attributes{}.
+			PutInteger(common.StartupTimeoutAttribute, 600).
+			PutString(common.ProbeInitialDelayAttribute, "15").
+			PutInteger(common.ProbePeriodAttribute, 20).
+			PutInteger(common.ProbeTimeoutAttribute, 5).
+			PutInteger(common.ProbeFailureThresholdAttribute, 6)),
+		containerComponent("invalid", attributes.Attributes{}.
+			PutString(common.StartupTimeoutAttribute, "10m").
+			PutInteger(common.ProbePeriodAttribute, -1)),
+	}
+
+	invalidErr := "errors while getting the health settings of component invalid:\n" +
+		"invalid value \"10m\" for attribute library.devfile.io/startup-timeout, it should be a non-negative integer\n" +
+		"invalid value -1 for attribute library.devfile.io/probe-period, it should be a non-negative integer"
+	missingComponentErr := "component missing is not found in the devfile"
+
+	tests := []struct {
+		name          string
+		componentName string
+		wantSettings  common.HealthSettings
+		wantErr       *string
+	}{
+		{
+			name:          "component without health attributes",
+			componentName: "defaults",
+			wantSettings: common.HealthSettings{
+				StartupTimeoutSeconds: common.DefaultStartupTimeout,
+				InitialDelaySeconds:   common.DefaultProbeInitialDelay,
+				PeriodSeconds:         common.DefaultProbePeriod,
+				TimeoutSeconds:        common.DefaultProbeTimeout,
+				FailureThreshold:      common.DefaultProbeFailureThreshold,
+			},
+		},
+		{
+			name:          "component with health attributes",
+			componentName: "configured",
+			wantSettings: common.HealthSettings{
+				StartupTimeoutSeconds: 600,
+				InitialDelaySeconds:   15,
+				PeriodSeconds:         20,
+				TimeoutSeconds:        5,
+				FailureThreshold:      6,
+			},
+		},
+		{
+			name:          "component with invalid health attributes",
+			componentName: "invalid",
+			wantErr:       &invalidErr,
+		},
+		{
+			name:          "missing component",
+			componentName: "missing",
+			wantErr:       &missingComponentErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: components,
+						},
+					},
+				},
+			}
+			settings, err := d.GetComponentHealthSettings(tt.componentName)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetComponentHealthSettings() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantSettings, settings, "TestDevfile200_GetComponentHealthSettings(): The two values should be the same.")
+			} else {
+				assert.Equal(t, *tt.wantErr, err.Error(), "TestDevfile200_GetComponentHealthSettings(): Error message should match")
+			}
+		})
+	}
+}
+
+func TestDevfile200_GetComponentByName(t *testing.T) {
+	missingComponentErr := "component .* is not found in the devfile"
+	duplicateComponentErr := "component runtime is defined 2 times in the devfile"
+
+	runtime := v1.Component{
+		Name: "runtime",
+		ComponentUnion: v1.ComponentUnion{
+			Container: &v1.ContainerComponent{
+				Container: v1.Container{
+					Image: "image1",
+				},
+			},
+		},
+	}
+	volume := testingutil.GetFakeVolumeComponent("volume", "1Gi")
+
+	tests := []struct {
+		name              string
+		currentComponents []v1.Component
+		componentName     string
+		wantComponent     v1.Component
+		wantErr           *string
+	}{
+		{
+			name:              "component found",
+			currentComponents: []v1.Component{runtime, volume},
+			componentName:     "volume",
+			wantComponent:     volume,
+		},
+		{
+			name:              "component not found",
+			currentComponents: []v1.Component{runtime, volume},
+			componentName:     "tools",
+			wantErr:           &missingComponentErr,
+		},
+		{
+			name:              "duplicate component names",
+			currentComponents: []v1.Component{runtime, volume, runtime},
+			componentName:     "runtime",
+			wantErr:           &duplicateComponentErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: tt.currentComponents,
+						},
+					},
+				},
+			}
+
+			component, err := d.GetComponentByName(tt.componentName)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetComponentByName() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantComponent, component, "TestDevfile200_GetComponentByName(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_GetComponentByName(): Error message should match")
+			}
+		})
+	}
+}
+
+func TestDevfile200_GetTypedComponents(t *testing.T) {
+	withAttributes := func(component v1.Component, attrs map[string]string) v1.Component {
+		component.Attributes = attributes.Attributes{}.FromStringMap(attrs)
+		return component
+	}
+	kubernetes := func(name string) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Kubernetes: &v1.KubernetesComponent{
+					K8sLikeComponent: v1.K8sLikeComponent{
+						K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+							Inlined: "kind: Service",
+						},
+					},
+				},
+			},
+		}
+	}
+	image := func(name string) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Image: &v1.ImageComponent{
+					Image: v1.Image{
+						ImageName: "quay.io/" + name,
+					},
+				},
+			},
+		}
+	}
+
+	d := &DevfileV2{
+		v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Components: []v1.Component{
+						withAttributes(testingutil.GetFakeContainerComponent("runtime"), map[string]string{"tier": "backend"}),
+						testingutil.GetFakeContainerComponent("tools"),
+						withAttributes(testingutil.GetFakeVolumeComponent("cache", "1Gi"), map[string]string{"tier": "backend"}),
+						testingutil.GetFakeVolumeComponent("data", "1Gi"),
+						withAttributes(kubernetes("service"), map[string]string{"tier": "backend"}),
+						withAttributes(image("frontend"), map[string]string{"tier": "frontend"}),
+						withAttributes(image("backend"), map[string]string{"tier": "backend"}),
+					},
+				},
+			},
+		},
+	}
+	backendFilter := common.DevfileOptions{
+		Filter: map[string]interface{}{
+			"tier": "backend",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		getComponents func(common.DevfileOptions) ([]v1.Component, error)
+		options       common.DevfileOptions
+		wantNames     []string
+	}{
+		{
+			name:          "container components",
+			getComponents: d.GetContainerComponents,
+			wantNames:     []string{"runtime", "tools"},
+		},
+		{
+			name:          "container components with attribute filter",
+			getComponents: d.GetContainerComponents,
+			options:       backendFilter,
+			wantNames:     []string{"runtime"},
+		},
+		{
+			name:          "volume components with attribute filter",
+			getComponents: d.GetVolumeComponents,
+			options:       backendFilter,
+			wantNames:     []string{"cache"},
+		},
+		{
+			name:          "kubernetes components",
+			getComponents: d.GetKubernetesComponents,
+			wantNames:     []string{"service"},
+		},
+		{
+			name:          "image components with attribute filter",
+			getComponents: d.GetImageComponents,
+			options:       backendFilter,
+			wantNames:     []string{"backend"},
+		},
+		{
+			name:          "component type in the options is ignored",
+			getComponents: d.GetImageComponents,
+			options: common.DevfileOptions{
+				ComponentOptions: common.ComponentOptions{
+					ComponentType: v1.ContainerComponentType,
+				},
+			},
+			wantNames: []string{"frontend", "backend"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			components, err := tt.getComponents(tt.options)
+			if err != nil {
+				t.Errorf("TestDevfile200_GetTypedComponents() unexpected error: %v", err)
+				return
+			}
+			var names []string
+			for _, component := range components {
+				names = append(names, component.Name)
+			}
+			assert.Equal(t, tt.wantNames, names, "TestDevfile200_GetTypedComponents(): The two values should be the same.")
+		})
+	}
+}
+
 func TestDeleteComponents(t *testing.T) {
 
 	missingCmpErr := "component .* is not found in the devfile"
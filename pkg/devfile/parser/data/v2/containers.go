@@ -21,10 +21,14 @@ import (
 	"strings"
 
 	v1alpha2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
 	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
 	corev1 "k8s.io/api/core/v1"
 )
 
+// ImagePullPolicyAttribute is the reserved container component attribute holding the image pull policy of the container
+const ImagePullPolicyAttribute = "library.devfile.io/image-pull-policy"
+
 // AddEnvVars accepts a map of container name mapped to an array of the env vars to be set;
 // it adds the envirnoment variables to a given container name of the DevfileV2 object
 // Example of containerEnvMap : {"runtime": {{Name: "Foo", Value: "Bar"}}}
@@ -61,6 +65,44 @@ func (d *DevfileV2) RemoveEnvVars(containerEnvMap map[string][]string) error {
 	return nil
 }
 
+// AddContainerEnvVars adds the env vars to the specified container component, an env var which is already set in
+// the container is overwritten in place. The order of the env vars of the container is preserved.
+func (d *DevfileV2) AddContainerEnvVars(componentName string, envVars []v1alpha2.EnvVar) error {
+	component, err := d.getContainerComponent(componentName)
+	if err != nil {
+		return err
+	}
+	for _, envVar := range envVars {
+		overwritten := false
+		for i := range component.Container.Env {
+			if component.Container.Env[i].Name == envVar.Name {
+				component.Container.Env[i] = envVar
+				overwritten = true
+				break
+			}
+		}
+		if !overwritten {
+			component.Container.Env = append(component.Container.Env, envVar)
+		}
+	}
+	return nil
+}
+
+// RemoveContainerEnvVars removes the env vars with the given names from the specified container component,
+// the order of the other env vars of the container is preserved
+func (d *DevfileV2) RemoveContainerEnvVars(componentName string, names []string) error {
+	component, err := d.getContainerComponent(componentName)
+	if err != nil {
+		return err
+	}
+	env, err := removeEnvVarsFromList(component.Container.Env, names)
+	if err != nil {
+		return err
+	}
+	component.Container.Env = env
+	return nil
+}
+
 // SetPorts accepts a map of container name mapped to an array of port numbers to be set;
 // it converts ports to endpoints, sets the endpoint to a given container name of the DevfileV2 object
 // Example of containerPortsMap: {"runtime": {"8080", "9000"}, "wildfly": {"12956"}}
@@ -104,6 +146,109 @@ func (d *DevfileV2) RemovePorts(containerPortsMap map[string][]string) error {
 	return nil
 }
 
+// AddEndpoints adds the endpoints to the specified container component. An endpoint name must be unique
+// across the devfile, so the endpoints are rejected if one of them is already declared by any component.
+func (d *DevfileV2) AddEndpoints(componentName string, endpoints []v1alpha2.Endpoint) error {
+	component, err := d.getContainerComponent(componentName)
+	if err != nil {
+		return err
+	}
+
+	declaredEndpoints := make(map[string]string)
+	for _, c := range d.Components {
+		for _, endpoint := range getComponentEndpoints(c) {
+			declaredEndpoints[endpoint.Name] = c.Name
+		}
+	}
+	for _, endpoint := range endpoints {
+		if otherComponent, ok := declaredEndpoints[endpoint.Name]; ok {
+			if otherComponent == componentName {
+				return &common.FieldAlreadyExistError{
+					Field: "endpoint",
+					Name:  endpoint.Name,
+				}
+			}
+			return fmt.Errorf("unable to add endpoint %s to the container %s, as it is already declared by the component %s", endpoint.Name, componentName, otherComponent)
+		}
+		declaredEndpoints[endpoint.Name] = componentName
+	}
+
+	component.Container.Endpoints = append(component.Container.Endpoints, endpoints...)
+	return nil
+}
+
+// RemoveEndpoint removes the endpoint with the given name from the specified container component
+func (d *DevfileV2) RemoveEndpoint(componentName, endpointName string) error {
+	component, err := d.getContainerComponent(componentName)
+	if err != nil {
+		return err
+	}
+	for i, endpoint := range component.Container.Endpoints {
+		if endpoint.Name == endpointName {
+			component.Container.Endpoints = append(component.Container.Endpoints[:i], component.Container.Endpoints[i+1:]...)
+			return nil
+		}
+	}
+	return &common.FieldNotFoundError{
+		Field: "endpoint",
+		Name:  endpointName,
+	}
+}
+
+// GetImagePullPolicy returns the image pull policy of the specified container component,
+// an empty string is returned if the container does not set an image pull policy
+func (d *DevfileV2) GetImagePullPolicy(componentName string) (string, error) {
+	component, err := d.getContainerComponent(componentName)
+	if err != nil {
+		return "", err
+	}
+	if !component.Attributes.Exists(ImagePullPolicyAttribute) {
+		return "", nil
+	}
+	policy := component.Attributes.GetString(ImagePullPolicyAttribute, &err)
+	if err != nil {
+		return "", err
+	}
+	return policy, nil
+}
+
+// SetImagePullPolicy sets the image pull policy of the specified container component,
+// the policy must be one of Always, IfNotPresent or Never
+func (d *DevfileV2) SetImagePullPolicy(componentName, policy string) error {
+	switch corev1.PullPolicy(policy) {
+	case corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+	default:
+		return fmt.Errorf("invalid image pull policy %s, must be one of %s, %s or %s", policy, corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever)
+	}
+
+	component, err := d.getContainerComponent(componentName)
+	if err != nil {
+		return err
+	}
+	if component.Attributes == nil {
+		component.Attributes = attributes.Attributes{}
+	}
+	component.Attributes.PutString(ImagePullPolicyAttribute, policy)
+	return nil
+}
+
+// getContainerComponent returns a pointer to the specified container component of the devfile
+func (d *DevfileV2) getContainerComponent(componentName string) (*v1alpha2.Component, error) {
+	for i := range d.Components {
+		if d.Components[i].Name != componentName {
+			continue
+		}
+		if d.Components[i].Container == nil {
+			return nil, fmt.Errorf("component %s is not a container component", componentName)
+		}
+		return &d.Components[i], nil
+	}
+	return nil, &common.FieldNotFoundError{
+		Field: "container component",
+		Name:  componentName,
+	}
+}
+
 // removeEnvVarsFromList removes the env variables based on the keys provided
 // and returns a new EnvVarList
 func removeEnvVarsFromList(envVarList []v1alpha2.EnvVar, keys []string) ([]v1alpha2.EnvVar, error) {
@@ -20,7 +20,9 @@ import (
 	"testing"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAddEnvVars(t *testing.T) {
@@ -319,6 +321,198 @@ func TestRemoveEnvVars(t *testing.T) {
 
 }
 
+func TestAddAndRemoveContainerEnvVars(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		componentName string
+		envToAdd      []v1alpha2.EnvVar
+		envToRemove   []string
+		wantEnv       []v1alpha2.EnvVar
+		wantErr       bool
+	}{
+		{
+			name:          "overwrite an existing env var in place",
+			componentName: "runtime",
+			envToAdd: []v1alpha2.EnvVar{
+				{Name: "DATABASE_PASSWORD", Value: "new-password"},
+			},
+			wantEnv: []v1alpha2.EnvVar{
+				{Name: "DATABASE_PASSWORD", Value: "new-password"},
+			},
+		},
+		{
+			name:          "insert new env vars after the existing ones",
+			componentName: "runtime",
+			envToAdd: []v1alpha2.EnvVar{
+				{Name: "PORT", Value: "3003"},
+				{Name: "DATABASE_PASSWORD", Value: "new-password"},
+				{Name: "HOST", Value: "localhost"},
+			},
+			wantEnv: []v1alpha2.EnvVar{
+				{Name: "DATABASE_PASSWORD", Value: "new-password"},
+				{Name: "PORT", Value: "3003"},
+				{Name: "HOST", Value: "localhost"},
+			},
+		},
+		{
+			name:          "remove env vars by name and preserve the order of the others",
+			componentName: "runtime",
+			envToAdd: []v1alpha2.EnvVar{
+				{Name: "PORT", Value: "3003"},
+				{Name: "HOST", Value: "localhost"},
+				{Name: "DEBUG", Value: "true"},
+			},
+			envToRemove: []string{"PORT", "DATABASE_PASSWORD"},
+			wantEnv: []v1alpha2.EnvVar{
+				{Name: "HOST", Value: "localhost"},
+				{Name: "DEBUG", Value: "true"},
+			},
+		},
+		{
+			name:          "add env vars to a missing container",
+			componentName: "invalid",
+			envToAdd: []v1alpha2.EnvVar{
+				{Name: "PORT", Value: "3003"},
+			},
+			wantErr: true,
+		},
+		{
+			name:          "remove env vars from a missing container",
+			componentName: "invalid",
+			envToRemove:   []string{"DATABASE_PASSWORD"},
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testDevfileData()
+
+			var err error
+			if tt.envToAdd != nil {
+				err = d.AddContainerEnvVars(tt.componentName, tt.envToAdd)
+			}
+			if err == nil && tt.envToRemove != nil {
+				err = d.RemoveContainerEnvVars(tt.componentName, tt.envToRemove)
+			}
+
+			if tt.wantErr {
+				assert.Error(t, err, "TestAddAndRemoveContainerEnvVars(): expected an error")
+				return
+			}
+			if err != nil {
+				t.Errorf("TestAddAndRemoveContainerEnvVars() unexpected error: %v", err)
+				return
+			}
+
+			component, err := d.GetComponentByName(tt.componentName)
+			if err != nil {
+				t.Errorf("TestAddAndRemoveContainerEnvVars() unexpected error: %v", err)
+				return
+			}
+			assert.Equal(t, tt.wantEnv, component.Container.Env, "TestAddAndRemoveContainerEnvVars(): The two values should be the same.")
+		})
+	}
+}
+
+func TestAddAndRemoveEndpoints(t *testing.T) {
+	existingEndpointErr := "endpoint port-3030 already exists in devfile"
+	globalEndpointErr := "unable to add endpoint port-3030 to the container loadbalancer, as it is already declared by the component runtime"
+	duplicateEndpointErr := "endpoint http already exists in devfile"
+	missingEndpointErr := "endpoint http is not found in the devfile"
+	missingContainerErr := "container component invalid is not found in the devfile"
+
+	tests := []struct {
+		name             string
+		componentName    string
+		endpointsToAdd   []v1alpha2.Endpoint
+		endpointToRemove string
+		wantEndpoints    []v1alpha2.Endpoint
+		wantErr          *string
+	}{
+		{
+			name:          "add endpoints",
+			componentName: "loadbalancer",
+			endpointsToAdd: []v1alpha2.Endpoint{
+				{Name: "http", TargetPort: 8080},
+				{Name: "https", TargetPort: 8443},
+			},
+			wantEndpoints: []v1alpha2.Endpoint{
+				{Name: "http", TargetPort: 8080},
+				{Name: "https", TargetPort: 8443},
+			},
+		},
+		{
+			name:             "add and remove an endpoint",
+			componentName:    "runtime",
+			endpointsToAdd:   []v1alpha2.Endpoint{{Name: "http", TargetPort: 8080}},
+			endpointToRemove: "port-3030",
+			wantEndpoints:    []v1alpha2.Endpoint{{Name: "http", TargetPort: 8080}},
+		},
+		{
+			name:           "endpoint already exists in the container",
+			componentName:  "runtime",
+			endpointsToAdd: []v1alpha2.Endpoint{{Name: "port-3030", TargetPort: 3000}},
+			wantErr:        &existingEndpointErr,
+		},
+		{
+			name:           "endpoint declared by another component",
+			componentName:  "loadbalancer",
+			endpointsToAdd: []v1alpha2.Endpoint{{Name: "port-3030", TargetPort: 3000}},
+			wantErr:        &globalEndpointErr,
+		},
+		{
+			name:          "endpoint added twice",
+			componentName: "loadbalancer",
+			endpointsToAdd: []v1alpha2.Endpoint{
+				{Name: "http", TargetPort: 8080},
+				{Name: "http", TargetPort: 8081},
+			},
+			wantErr: &duplicateEndpointErr,
+		},
+		{
+			name:             "remove a missing endpoint",
+			componentName:    "runtime",
+			endpointToRemove: "http",
+			wantErr:          &missingEndpointErr,
+		},
+		{
+			name:           "missing container",
+			componentName:  "invalid",
+			endpointsToAdd: []v1alpha2.Endpoint{{Name: "http", TargetPort: 8080}},
+			wantErr:        &missingContainerErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testDevfileData()
+
+			var err error
+			if tt.endpointsToAdd != nil {
+				err = d.AddEndpoints(tt.componentName, tt.endpointsToAdd)
+			}
+			if err == nil && tt.endpointToRemove != "" {
+				err = d.RemoveEndpoint(tt.componentName, tt.endpointToRemove)
+			}
+
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestAddAndRemoveEndpoints() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				component, err := d.GetComponentByName(tt.componentName)
+				if err != nil {
+					t.Errorf("TestAddAndRemoveEndpoints() unexpected error: %v", err)
+					return
+				}
+				assert.Equal(t, tt.wantEndpoints, component.Container.Endpoints, "TestAddAndRemoveEndpoints(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestAddAndRemoveEndpoints(): Error message should match")
+			}
+		})
+	}
+}
+
 func TestSetPorts(t *testing.T) {
 
 	tests := []struct {
@@ -675,3 +869,111 @@ func testDevfileData() *DevfileV2 {
 		},
 	}
 }
+
+func TestImagePullPolicy(t *testing.T) {
+
+	components := func() []v1alpha2.Component {
+		return []v1alpha2.Component{
+			{
+				Name: "runtime",
+				ComponentUnion: v1alpha2.ComponentUnion{
+					Container: &v1alpha2.ContainerComponent{},
+				},
+			},
+			{
+				Name:       "tools",
+				Attributes: attributes.Attributes{}.PutString(ImagePullPolicyAttribute, "Never"),
+				ComponentUnion: v1alpha2.ComponentUnion{
+					Container: &v1alpha2.ContainerComponent{},
+				},
+			},
+			{
+				Name: "volume",
+				ComponentUnion: v1alpha2.ComponentUnion{
+					Volume: &v1alpha2.VolumeComponent{},
+				},
+			},
+		}
+	}
+
+	invalidPolicyErr := "invalid image pull policy Sometimes, must be one of Always, IfNotPresent or Never"
+	missingContainerErr := "container component missing is not found in the devfile"
+	notContainerErr := "component volume is not a container component"
+
+	tests := []struct {
+		name          string
+		componentName string
+		policy        string
+		wantPolicy    string
+		wantErr       *string
+	}{
+		{
+			name:          "get the policy of a container without policy",
+			componentName: "runtime",
+			wantPolicy:    "",
+		},
+		{
+			name:          "get the policy of a container with a policy",
+			componentName: "tools",
+			wantPolicy:    "Never",
+		},
+		{
+			name:          "set the policy of a container without policy",
+			componentName: "runtime",
+			policy:        "IfNotPresent",
+			wantPolicy:    "IfNotPresent",
+		},
+		{
+			name:          "override the policy of a container",
+			componentName: "tools",
+			policy:        "Always",
+			wantPolicy:    "Always",
+		},
+		{
+			name:          "set an invalid policy",
+			componentName: "runtime",
+			policy:        "Sometimes",
+			wantErr:       &invalidPolicyErr,
+		},
+		{
+			name:          "missing container",
+			componentName: "missing",
+			wantErr:       &missingContainerErr,
+		},
+		{
+			name:          "component which is not a container",
+			componentName: "volume",
+			policy:        "Always",
+			wantErr:       &notContainerErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1alpha2.Devfile{
+					DevWorkspaceTemplateSpec: v1alpha2.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1alpha2.DevWorkspaceTemplateSpecContent{
+							Components: components(),
+						},
+					},
+				},
+			}
+
+			var err error
+			if tt.policy != "" {
+				err = d.SetImagePullPolicy(tt.componentName, tt.policy)
+			}
+			var policy string
+			if err == nil {
+				policy, err = d.GetImagePullPolicy(tt.componentName)
+			}
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestImagePullPolicy() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantPolicy, policy, "TestImagePullPolicy(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestImagePullPolicy(): Error message should match")
+			}
+		})
+	}
+}
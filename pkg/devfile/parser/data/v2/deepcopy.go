@@ -0,0 +1,58 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"github.com/devfile/api/v2/pkg/attributes"
+	devfilepkg "github.com/devfile/api/v2/pkg/devfile"
+)
+
+// DeepCopy creates an independent copy of the devfile, the slices and maps of the copy
+// can be mutated without affecting the receiver.
+func (d *DevfileV2) DeepCopy() *DevfileV2 {
+	if d == nil {
+		return nil
+	}
+	out := new(DevfileV2)
+	d.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. d must be non-nil.
+func (d *DevfileV2) DeepCopyInto(out *DevfileV2) {
+	*out = *d
+	deepCopyHeaderInto(&d.DevfileHeader, &out.DevfileHeader)
+	d.DevWorkspaceTemplateSpec.DeepCopyInto(&out.DevWorkspaceTemplateSpec)
+}
+
+// deepCopyHeaderInto copies the devfile header into out, devfile/api does not generate a deepcopy for it
+func deepCopyHeaderInto(in *devfilepkg.DevfileHeader, out *devfilepkg.DevfileHeader) {
+	*out = *in
+	if in.Metadata.Attributes != nil {
+		out.Metadata.Attributes = make(attributes.Attributes, len(in.Metadata.Attributes))
+		for key, val := range in.Metadata.Attributes {
+			out.Metadata.Attributes[key] = *val.DeepCopy()
+		}
+	}
+	if in.Metadata.Tags != nil {
+		out.Metadata.Tags = make([]string, len(in.Metadata.Tags))
+		copy(out.Metadata.Tags, in.Metadata.Tags)
+	}
+	if in.Metadata.Architectures != nil {
+		out.Metadata.Architectures = make([]devfilepkg.Architecture, len(in.Metadata.Architectures))
+		copy(out.Metadata.Architectures, in.Metadata.Architectures)
+	}
+}
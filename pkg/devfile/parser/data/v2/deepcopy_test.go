@@ -0,0 +1,92 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	devfilepkg "github.com/devfile/api/v2/pkg/devfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevfile200_DeepCopy(t *testing.T) {
+	newDevfile := func() *DevfileV2 {
+		return &DevfileV2{
+			v1.Devfile{
+				DevfileHeader: devfilepkg.DevfileHeader{
+					SchemaVersion: "2.2.0",
+					Metadata: devfilepkg.DevfileMetadata{
+						Name:          "nodejs",
+						Tags:          []string{"NodeJS", "Express"},
+						Architectures: []devfilepkg.Architecture{devfilepkg.AMD64},
+						Attributes:    attributes.Attributes{}.PutString("alpha.build-context", "."),
+					},
+				},
+				DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+					DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+						Variables: map[string]string{"version": "16"},
+						Components: []v1.Component{
+							{
+								Name: "runtime",
+								ComponentUnion: v1.ComponentUnion{
+									Container: &v1.ContainerComponent{
+										Container: v1.Container{
+											Image: "quay.io/nodejs-16",
+											VolumeMounts: []v1.VolumeMount{
+												{Name: "cache", Path: "/cache"},
+											},
+										},
+									},
+								},
+							},
+						},
+						Commands: []v1.Command{
+							{
+								Id: "run",
+								CommandUnion: v1.CommandUnion{
+									Exec: &v1.ExecCommand{
+										CommandLine: "npm start",
+										Component:   "runtime",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	original := newDevfile()
+	devfileCopy := original.DeepCopy()
+	assert.Equal(t, original, devfileCopy, "TestDevfile200_DeepCopy(): The two values should be the same.")
+
+	devfileCopy.Metadata.Tags[0] = "Java"
+	devfileCopy.Metadata.Architectures[0] = devfilepkg.ARM64
+	devfileCopy.Metadata.Attributes.PutString("alpha.build-context", "src")
+	devfileCopy.Variables["version"] = "18"
+	devfileCopy.Components[0].Container.Image = "quay.io/nodejs-18"
+	devfileCopy.Components[0].Container.VolumeMounts[0].Path = "/tmp"
+	devfileCopy.Components = append(devfileCopy.Components, v1.Component{Name: "tools"})
+	devfileCopy.Commands[0].Exec.CommandLine = "npm run debug"
+
+	assert.Equal(t, newDevfile(), original, "TestDevfile200_DeepCopy(): The original devfile should not be mutated.")
+
+	var nilDevfile *DevfileV2
+	assert.Nil(t, nilDevfile.DeepCopy(), "TestDevfile200_DeepCopy(): The copy of a nil devfile should be nil.")
+}
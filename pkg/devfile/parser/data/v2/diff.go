@@ -0,0 +1,173 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+)
+
+// keyedElement is an element of a devfile list which is identified by its name or id
+type keyedElement struct {
+	key   string
+	value interface{}
+}
+
+// Equal checks if the two devfiles are semantically equal, see Diff for the comparison rules.
+func (d *DevfileV2) Equal(other *DevfileV2) bool {
+	return len(d.Diff(other)) == 0
+}
+
+// Diff returns the human-readable list of the elements added, removed or modified from the devfile to the other devfile.
+//
+// The components, commands, projects and starter projects are compared by name (id for the commands), the
+// metadata tags and architectures as sets, so their ordering is not significant. Any other list is order-sensitive,
+// in particular the events, the commands of a composite command and the env, endpoints and volume mounts of a container.
+func (d *DevfileV2) Diff(other *DevfileV2) []string {
+	if d == nil || other == nil {
+		if d == other {
+			return nil
+		}
+		return []string{"devfile modified"}
+	}
+
+	var diff []string
+	if d.SchemaVersion != other.SchemaVersion {
+		diff = append(diff, fmt.Sprintf("schemaVersion modified from %s to %s", d.SchemaVersion, other.SchemaVersion))
+	}
+	if !reflect.DeepEqual(normalizedMetadata(d), normalizedMetadata(other)) {
+		diff = append(diff, "metadata modified")
+	}
+	if !reflect.DeepEqual(d.Parent, other.Parent) {
+		diff = append(diff, "parent modified")
+	}
+	if !reflect.DeepEqual(d.Events, other.Events) {
+		diff = append(diff, "events modified")
+	}
+
+	diff = append(diff, diffKeyedElements("variable", variableElements(d.Variables), variableElements(other.Variables))...)
+	diff = append(diff, diffKeyedElements("attribute", attributeElements(d.Attributes), attributeElements(other.Attributes))...)
+	diff = append(diff, diffKeyedElements("component", componentElements(d.Components), componentElements(other.Components))...)
+	diff = append(diff, diffKeyedElements("command", commandElements(d.Commands), commandElements(other.Commands))...)
+	diff = append(diff, diffKeyedElements("project", projectElements(d.Projects), projectElements(other.Projects))...)
+	diff = append(diff, diffKeyedElements("starter project", starterProjectElements(d.StarterProjects), starterProjectElements(other.StarterProjects))...)
+
+	return diff
+}
+
+// diffKeyedElements compares the elements by key, the removed and modified elements are listed in the order
+// of from, the added elements in the order of to
+func diffKeyedElements(kind string, from, to []keyedElement) []string {
+	var diff []string
+	toValues := make(map[string]interface{}, len(to))
+	for _, element := range to {
+		toValues[element.key] = element.value
+	}
+	fromKeys := make(map[string]bool, len(from))
+	for _, element := range from {
+		fromKeys[element.key] = true
+		toValue, ok := toValues[element.key]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("%s %s removed", kind, element.key))
+		} else if !reflect.DeepEqual(element.value, toValue) {
+			diff = append(diff, fmt.Sprintf("%s %s modified", kind, element.key))
+		}
+	}
+	for _, element := range to {
+		if !fromKeys[element.key] {
+			diff = append(diff, fmt.Sprintf("%s %s added", kind, element.key))
+		}
+	}
+	return diff
+}
+
+// normalizedMetadata returns a copy of the devfile metadata with the tags and architectures sorted
+func normalizedMetadata(d *DevfileV2) interface{} {
+	header := d.DeepCopy().DevfileHeader
+	if len(header.Metadata.Tags) == 0 {
+		header.Metadata.Tags = nil
+	}
+	if len(header.Metadata.Architectures) == 0 {
+		header.Metadata.Architectures = nil
+	}
+	sort.Strings(header.Metadata.Tags)
+	sort.Slice(header.Metadata.Architectures, func(i, j int) bool {
+		return header.Metadata.Architectures[i] < header.Metadata.Architectures[j]
+	})
+	return header.Metadata
+}
+
+func variableElements(variables map[string]string) []keyedElement {
+	keys := make([]string, 0, len(variables))
+	for key := range variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	elements := make([]keyedElement, 0, len(keys))
+	for _, key := range keys {
+		elements = append(elements, keyedElement{key: key, value: variables[key]})
+	}
+	return elements
+}
+
+func attributeElements(attrs attributes.Attributes) []keyedElement {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	elements := make([]keyedElement, 0, len(keys))
+	for _, key := range keys {
+		elements = append(elements, keyedElement{key: key, value: attrs[key]})
+	}
+	return elements
+}
+
+func componentElements(components []v1.Component) []keyedElement {
+	elements := make([]keyedElement, 0, len(components))
+	for _, component := range components {
+		elements = append(elements, keyedElement{key: component.Name, value: component})
+	}
+	return elements
+}
+
+func commandElements(commands []v1.Command) []keyedElement {
+	elements := make([]keyedElement, 0, len(commands))
+	for _, command := range commands {
+		elements = append(elements, keyedElement{key: command.Id, value: command})
+	}
+	return elements
+}
+
+func projectElements(projects []v1.Project) []keyedElement {
+	elements := make([]keyedElement, 0, len(projects))
+	for _, project := range projects {
+		elements = append(elements, keyedElement{key: project.Name, value: project})
+	}
+	return elements
+}
+
+func starterProjectElements(starterProjects []v1.StarterProject) []keyedElement {
+	elements := make([]keyedElement, 0, len(starterProjects))
+	for _, starterProject := range starterProjects {
+		elements = append(elements, keyedElement{key: starterProject.Name, value: starterProject})
+	}
+	return elements
+}
@@ -0,0 +1,138 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	devfilepkg "github.com/devfile/api/v2/pkg/devfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevfile200_Diff(t *testing.T) {
+	container := func(name, image string) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{Image: image},
+				},
+			},
+		}
+	}
+	exec := func(id, commandLine string) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{CommandLine: commandLine, Component: "runtime"},
+			},
+		}
+	}
+	composite := func(id string, commands ...string) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{Commands: commands},
+			},
+		}
+	}
+	newDevfile := func(tags []string, components []v1.Component, commands []v1.Command) *DevfileV2 {
+		return &DevfileV2{
+			v1.Devfile{
+				DevfileHeader: devfilepkg.DevfileHeader{
+					SchemaVersion: "2.2.0",
+					Metadata: devfilepkg.DevfileMetadata{
+						Name: "nodejs",
+						Tags: tags,
+					},
+				},
+				DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+					DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+						Variables:  map[string]string{"version": "16"},
+						Components: components,
+						Commands:   commands,
+					},
+				},
+			},
+		}
+	}
+
+	original := newDevfile(
+		[]string{"NodeJS", "Express"},
+		[]v1.Component{container("runtime", "quay.io/nodejs-16"), container("tools", "quay.io/tools")},
+		[]v1.Command{exec("install", "npm install"), exec("run", "npm start"), composite("all", "install", "run")},
+	)
+
+	tests := []struct {
+		name  string
+		other *DevfileV2
+		want  []string
+	}{
+		{
+			name:  "same devfile",
+			other: original.DeepCopy(),
+		},
+		{
+			name: "reordered tags, components and commands",
+			other: newDevfile(
+				[]string{"Express", "NodeJS"},
+				[]v1.Component{container("tools", "quay.io/tools"), container("runtime", "quay.io/nodejs-16")},
+				[]v1.Command{composite("all", "install", "run"), exec("run", "npm start"), exec("install", "npm install")},
+			),
+		},
+		{
+			name: "single field change",
+			other: newDevfile(
+				[]string{"NodeJS", "Express"},
+				[]v1.Component{container("runtime", "quay.io/nodejs-18"), container("tools", "quay.io/tools")},
+				[]v1.Command{exec("install", "npm install"), exec("run", "npm start"), composite("all", "install", "run")},
+			),
+			want: []string{"component runtime modified"},
+		},
+		{
+			name: "reordered composite sub-commands",
+			other: newDevfile(
+				[]string{"NodeJS", "Express"},
+				[]v1.Component{container("runtime", "quay.io/nodejs-16"), container("tools", "quay.io/tools")},
+				[]v1.Command{exec("install", "npm install"), exec("run", "npm start"), composite("all", "run", "install")},
+			),
+			want: []string{"command all modified"},
+		},
+		{
+			name: "added and removed elements",
+			other: newDevfile(
+				[]string{"NodeJS"},
+				[]v1.Component{container("runtime", "quay.io/nodejs-16"), container("debug", "quay.io/debug")},
+				[]v1.Command{exec("install", "npm install"), exec("run", "npm start"), composite("all", "install", "run")},
+			),
+			want: []string{"metadata modified", "component tools removed", "component debug added"},
+		},
+		{
+			name:  "nil devfile",
+			other: nil,
+			want:  []string{"devfile modified"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := original.Diff(tt.other)
+			assert.Equal(t, tt.want, diff, "TestDevfile200_Diff(): The two values should be the same.")
+			assert.Equal(t, len(tt.want) == 0, original.Equal(tt.other), "TestDevfile200_Diff(): Equal should match the diff.")
+		})
+	}
+}
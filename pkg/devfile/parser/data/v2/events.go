@@ -30,6 +30,84 @@ func (d *DevfileV2) GetEvents() v1.Events {
 	return v1.Events{}
 }
 
+// GetEventsByType returns the command ids bound to the given event type, see common.EventTypes for the known event types.
+// An empty slice is returned if the event type is unset.
+func (d *DevfileV2) GetEventsByType(eventType string) ([]string, error) {
+	return common.GetEventCommands(d.GetEvents(), eventType)
+}
+
+// AddEventsByType binds the commands to the given event type, see common.EventTypes for the known event types.
+// The commands already bound to the event are skipped. It returns an error if a command is not found in the devfile.
+func (d *DevfileV2) AddEventsByType(eventType string, ids ...string) error {
+	if _, err := common.GetEventCommands(d.GetEvents(), eventType); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if _, err := d.GetCommandByName(id); err != nil {
+			return err
+		}
+	}
+
+	if d.Events == nil {
+		d.Events = &v1.Events{}
+	}
+	eventCommands := d.getEventCommands(eventType)
+	for _, id := range ids {
+		if !containsEventCommand(*eventCommands, id) {
+			*eventCommands = append(*eventCommands, id)
+		}
+	}
+	return nil
+}
+
+// RemoveEventsByType unbinds the commands from the given event type, see common.EventTypes for the known event types.
+// It returns an error if a command is not bound to the event.
+func (d *DevfileV2) RemoveEventsByType(eventType string, ids ...string) error {
+	commands, err := common.GetEventCommands(d.GetEvents(), eventType)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if !containsEventCommand(commands, id) {
+			return fmt.Errorf("command %s is not bound to the %s event", id, eventType)
+		}
+	}
+
+	eventCommands := d.getEventCommands(eventType)
+	var remaining []string
+	for _, command := range *eventCommands {
+		if !containsEventCommand(ids, command) {
+			remaining = append(remaining, command)
+		}
+	}
+	*eventCommands = remaining
+	return nil
+}
+
+// getEventCommands returns a pointer to the commands of the given event type, the devfile events must be set
+func (d *DevfileV2) getEventCommands(eventType string) *[]string {
+	switch eventType {
+	case common.PreStartEvent:
+		return &d.Events.PreStart
+	case common.PostStartEvent:
+		return &d.Events.PostStart
+	case common.PreStopEvent:
+		return &d.Events.PreStop
+	default:
+		return &d.Events.PostStop
+	}
+}
+
+// containsEventCommand checks if the command id is in the list, command ids are case insensitive
+func containsEventCommand(commands []string, id string) bool {
+	for _, command := range commands {
+		if strings.EqualFold(command, id) {
+			return true
+		}
+	}
+	return false
+}
+
 // AddEvents adds the Events Object to the devfile's events
 // an event field is considered as invalid if it is already defined
 // all event fields will be checked and processed, and returns a total error of all event fields
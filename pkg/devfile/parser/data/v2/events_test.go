@@ -25,6 +25,198 @@ import (
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 )
 
+func TestDevfile200_GetEventsByType(t *testing.T) {
+	unknownEventErr := "unknown event type preBuild, it should be one of preStart, postStart, preStop, postStop"
+
+	events := &v1.Events{
+		DevWorkspaceEvents: v1.DevWorkspaceEvents{
+			PreStart:  []string{"init-volume"},
+			PostStart: []string{"install", "start-db"},
+			PreStop:   []string{"flush"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		events         *v1.Events
+		eventType      string
+		wantCommandIds []string
+		wantErr        *string
+	}{
+		{
+			name:           "preStart event",
+			events:         events,
+			eventType:      "preStart",
+			wantCommandIds: []string{"init-volume"},
+		},
+		{
+			name:           "postStart event",
+			events:         events,
+			eventType:      "postStart",
+			wantCommandIds: []string{"install", "start-db"},
+		},
+		{
+			name:           "preStop event",
+			events:         events,
+			eventType:      "preStop",
+			wantCommandIds: []string{"flush"},
+		},
+		{
+			name:           "unset postStop event",
+			events:         events,
+			eventType:      "postStop",
+			wantCommandIds: []string{},
+		},
+		{
+			name:           "devfile without events",
+			eventType:      "postStart",
+			wantCommandIds: []string{},
+		},
+		{
+			name:      "unknown event type",
+			events:    events,
+			eventType: "preBuild",
+			wantErr:   &unknownEventErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Events: tt.events,
+						},
+					},
+				},
+			}
+
+			commandIds, err := d.GetEventsByType(tt.eventType)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetEventsByType() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantCommandIds, commandIds, "TestDevfile200_GetEventsByType(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_GetEventsByType(): Error message should match")
+			}
+		})
+	}
+}
+
+func TestDevfile200_AddAndRemoveEventsByType(t *testing.T) {
+	unknownEventErr := "unknown event type preBuild"
+	missingCommandErr := "command start is not found in the devfile"
+	unboundCommandErr := "command test is not bound to the postStart event"
+
+	commands := []v1.Command{
+		{Id: "install", CommandUnion: v1.CommandUnion{Exec: &v1.ExecCommand{CommandLine: "npm install"}}},
+		{Id: "build", CommandUnion: v1.CommandUnion{Exec: &v1.ExecCommand{CommandLine: "npm run build"}}},
+		{Id: "test", CommandUnion: v1.CommandUnion{Exec: &v1.ExecCommand{CommandLine: "npm test"}}},
+	}
+
+	tests := []struct {
+		name           string
+		events         *v1.Events
+		eventType      string
+		idsToAdd       []string
+		idsToRemove    []string
+		wantCommandIds []string
+		wantErr        *string
+	}{
+		{
+			name:           "add commands to a devfile without events",
+			eventType:      "postStart",
+			idsToAdd:       []string{"install", "build"},
+			wantCommandIds: []string{"install", "build"},
+		},
+		{
+			name: "commands already bound are skipped",
+			events: &v1.Events{
+				DevWorkspaceEvents: v1.DevWorkspaceEvents{
+					PostStart: []string{"install"},
+				},
+			},
+			eventType:      "postStart",
+			idsToAdd:       []string{"build", "install", "build"},
+			wantCommandIds: []string{"install", "build"},
+		},
+		{
+			name: "remove commands",
+			events: &v1.Events{
+				DevWorkspaceEvents: v1.DevWorkspaceEvents{
+					PreStop: []string{"install", "build", "test"},
+				},
+			},
+			eventType:      "preStop",
+			idsToRemove:    []string{"install", "test"},
+			wantCommandIds: []string{"build"},
+		},
+		{
+			name:      "add a missing command",
+			eventType: "postStart",
+			idsToAdd:  []string{"install", "start"},
+			wantErr:   &missingCommandErr,
+		},
+		{
+			name: "remove a command which is not bound",
+			events: &v1.Events{
+				DevWorkspaceEvents: v1.DevWorkspaceEvents{
+					PostStart: []string{"install"},
+				},
+			},
+			eventType:   "postStart",
+			idsToRemove: []string{"test"},
+			wantErr:     &unboundCommandErr,
+		},
+		{
+			name:      "add to an unknown event type",
+			eventType: "preBuild",
+			idsToAdd:  []string{"install"},
+			wantErr:   &unknownEventErr,
+		},
+		{
+			name:        "remove from an unknown event type",
+			eventType:   "preBuild",
+			idsToRemove: []string{"install"},
+			wantErr:     &unknownEventErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Commands: commands,
+							Events:   tt.events,
+						},
+					},
+				},
+			}
+
+			var err error
+			if tt.idsToAdd != nil {
+				err = d.AddEventsByType(tt.eventType, tt.idsToAdd...)
+			}
+			if err == nil && tt.idsToRemove != nil {
+				err = d.RemoveEventsByType(tt.eventType, tt.idsToRemove...)
+			}
+
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_AddAndRemoveEventsByType() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				commandIds, err := d.GetEventsByType(tt.eventType)
+				if err != nil {
+					t.Errorf("TestDevfile200_AddAndRemoveEventsByType() unexpected error: %v", err)
+				}
+				assert.Equal(t, tt.wantCommandIds, commandIds, "TestDevfile200_AddAndRemoveEventsByType(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_AddAndRemoveEventsByType(): Error message should match")
+			}
+		})
+	}
+}
+
 func TestDevfile200_AddEvents(t *testing.T) {
 	multipleDupError := fmt.Sprintf("%s\n%s", "event field pre start already exists in devfile", "event field post stop already exists in devfile")
 
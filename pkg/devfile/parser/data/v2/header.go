@@ -38,3 +38,59 @@ func (d *DevfileV2) GetMetadata() devfilepkg.DevfileMetadata {
 func (d *DevfileV2) SetMetadata(metadata devfilepkg.DevfileMetadata) {
 	d.Metadata = metadata
 }
+
+// SetMetadataName sets the name in the devfile metadata
+func (d *DevfileV2) SetMetadataName(name string) {
+	d.Metadata.Name = name
+}
+
+// SetMetadataVersion sets the version in the devfile metadata
+func (d *DevfileV2) SetMetadataVersion(version string) {
+	d.Metadata.Version = version
+}
+
+// SetMetadataDisplayName sets the display name in the devfile metadata
+func (d *DevfileV2) SetMetadataDisplayName(displayName string) {
+	d.Metadata.DisplayName = displayName
+}
+
+// SetProjectType sets the project type in the devfile metadata
+func (d *DevfileV2) SetProjectType(projectType string) {
+	d.Metadata.ProjectType = projectType
+}
+
+// SetLanguage sets the language in the devfile metadata
+func (d *DevfileV2) SetLanguage(language string) {
+	d.Metadata.Language = language
+}
+
+// GetMetadataTags returns a copy of the tags of the devfile metadata
+func (d *DevfileV2) GetMetadataTags() []string {
+	if d.Metadata.Tags == nil {
+		return nil
+	}
+	tags := make([]string, len(d.Metadata.Tags))
+	copy(tags, d.Metadata.Tags)
+	return tags
+}
+
+// AddMetadataTag adds the tag to the devfile metadata, a tag which is already present is not added twice
+func (d *DevfileV2) AddMetadataTag(tag string) {
+	for _, existingTag := range d.Metadata.Tags {
+		if existingTag == tag {
+			return
+		}
+	}
+	d.Metadata.Tags = append(d.Metadata.Tags, tag)
+}
+
+// RemoveMetadataTag removes the tag from the devfile metadata, it returns false if the tag is not present
+func (d *DevfileV2) RemoveMetadataTag(tag string) bool {
+	for i, existingTag := range d.Metadata.Tags {
+		if existingTag == tag {
+			d.Metadata.Tags = append(d.Metadata.Tags[:i], d.Metadata.Tags[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -239,3 +239,95 @@ func TestDevfile200_SetSetMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestDevfile200_SetMetadataFields(t *testing.T) {
+	d := &DevfileV2{
+		v1.Devfile{
+			DevfileHeader: devfilepkg.DevfileHeader{
+				Metadata: devfilepkg.DevfileMetadata{
+					Name:        "nodejs",
+					Version:     "1.0.0",
+					Description: "Node.js runtime",
+					Tags:        []string{"Node.js"},
+				},
+			},
+		},
+	}
+
+	d.SetMetadataName("nodejs-express")
+	d.SetMetadataVersion("1.1.0")
+	d.SetMetadataDisplayName("Node.js Express")
+	d.SetProjectType("Node.js")
+	d.SetLanguage("JavaScript")
+
+	expectedMetadata := devfilepkg.DevfileMetadata{
+		Name:        "nodejs-express",
+		Version:     "1.1.0",
+		DisplayName: "Node.js Express",
+		Description: "Node.js runtime",
+		ProjectType: "Node.js",
+		Language:    "JavaScript",
+		Tags:        []string{"Node.js"},
+	}
+	if !reflect.DeepEqual(d.GetMetadata(), expectedMetadata) {
+		t.Errorf("TestDevfile200_SetMetadataFields() error: expected %v, got %v", expectedMetadata, d.GetMetadata())
+	}
+}
+
+func TestDevfile200_MetadataTags(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		tags         []string
+		tagsToAdd    []string
+		tagsToRemove []string
+		wantRemoved  []bool
+		expectedTags []string
+	}{
+		{
+			name:         "add tags to a devfile without tags",
+			tagsToAdd:    []string{"Node.js", "Express"},
+			expectedTags: []string{"Node.js", "Express"},
+		},
+		{
+			name:         "adding a duplicate tag is a no-op",
+			tags:         []string{"Node.js", "Express"},
+			tagsToAdd:    []string{"Express", "NPM", "NPM"},
+			expectedTags: []string{"Node.js", "Express", "NPM"},
+		},
+		{
+			name:         "remove tags",
+			tags:         []string{"Node.js", "Express", "NPM"},
+			tagsToRemove: []string{"Express", "Java"},
+			wantRemoved:  []bool{true, false},
+			expectedTags: []string{"Node.js", "NPM"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevfileHeader: devfilepkg.DevfileHeader{
+						Metadata: devfilepkg.DevfileMetadata{
+							Tags: tt.tags,
+						},
+					},
+				},
+			}
+
+			for _, tag := range tt.tagsToAdd {
+				d.AddMetadataTag(tag)
+			}
+			for i, tag := range tt.tagsToRemove {
+				if removed := d.RemoveMetadataTag(tag); removed != tt.wantRemoved[i] {
+					t.Errorf("TestDevfile200_MetadataTags() error: removing tag %s returned %v, expected %v", tag, removed, tt.wantRemoved[i])
+				}
+			}
+
+			tags := d.GetMetadataTags()
+			if !reflect.DeepEqual(tags, tt.expectedTags) {
+				t.Errorf("TestDevfile200_MetadataTags() error: expected %v, got %v", tt.expectedTags, tags)
+			}
+		})
+	}
+}
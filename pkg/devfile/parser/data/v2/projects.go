@@ -80,7 +80,7 @@ func (d *DevfileV2) AddProjects(projects []v1.Project) error {
 	return nil
 }
 
-// UpdateProject updates the slice of Devfile projects parsed from the Devfile
+// UpdateProject replaces the project with the same name in the Devfile projects, keeping its position
 // return an error if the project is not found
 func (d *DevfileV2) UpdateProject(project v1.Project) error {
 	for i := range d.Projects {
@@ -89,7 +89,10 @@ func (d *DevfileV2) UpdateProject(project v1.Project) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("update project failed: project %s not found", project.Name)
+	return &common.FieldNotFoundError{
+		Field: "project",
+		Name:  project.Name,
+	}
 }
 
 // DeleteProject removes the specified project
@@ -142,6 +145,19 @@ func (d *DevfileV2) GetStarterProjects(options common.DevfileOptions) ([]v1.Star
 	return starterProjects, nil
 }
 
+// GetStarterProjectByName returns the starter project with the given name
+func (d *DevfileV2) GetStarterProjectByName(name string) (v1.StarterProject, error) {
+	for _, starterProject := range d.StarterProjects {
+		if starterProject.Name == name {
+			return starterProject, nil
+		}
+	}
+	return v1.StarterProject{}, &common.FieldNotFoundError{
+		Field: "starter project",
+		Name:  name,
+	}
+}
+
 // AddStarterProjects adds the slice of Devfile starter projects to the Devfile's starter project list
 // a starterProject is considered as invalid if it is already defined
 // starterProject list passed in will be all processed, and returns a total error of all invalid starterProjects
@@ -174,7 +190,10 @@ func (d *DevfileV2) UpdateStarterProject(project v1.StarterProject) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("update starter project failed: starter project %s not found", project.Name)
+	return &common.FieldNotFoundError{
+		Field: "starter project",
+		Name:  project.Name,
+	}
 }
 
 // DeleteStarterProject removes the specified starter project
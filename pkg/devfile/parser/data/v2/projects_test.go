@@ -329,7 +329,7 @@ func TestDevfile200_AddProjects(t *testing.T) {
 
 func TestDevfile200_UpdateProject(t *testing.T) {
 
-	missingProjectErr := "update project failed: project .* not found"
+	missingProjectErr := "project .* is not found in the devfile"
 
 	tests := []struct {
 		name              string
@@ -381,6 +381,57 @@ func TestDevfile200_UpdateProject(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "It should keep the position of the updated project",
+			args: v1.Project{
+				Name:      "java",
+				ClonePath: "/test",
+			},
+			devfilev2: &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Projects: []v1.Project{
+								{
+									Name:      "nodejs",
+									ClonePath: "/project",
+								},
+								{
+									Name:      "java",
+									ClonePath: "/project",
+								},
+								{
+									Name:      "python",
+									ClonePath: "/project",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedDevfilev2: &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Projects: []v1.Project{
+								{
+									Name:      "nodejs",
+									ClonePath: "/project",
+								},
+								{
+									Name:      "java",
+									ClonePath: "/test",
+								},
+								{
+									Name:      "python",
+									ClonePath: "/project",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "It should fail to update project for non existing project",
 			args: v1.Project{
@@ -684,6 +735,66 @@ func TestDevfile200_GetStarterProjects(t *testing.T) {
 	}
 }
 
+func TestDevfile200_GetStarterProjectByName(t *testing.T) {
+	missingStarterProjectErr := "starter project .* is not found in the devfile"
+
+	starterProjects := []v1.StarterProject{
+		{
+			Name: "nodejs-starter",
+			ProjectSource: v1.ProjectSource{
+				Git: &v1.GitProjectSource{},
+			},
+		},
+		{
+			Name:   "java-starter",
+			SubDir: "app",
+			ProjectSource: v1.ProjectSource{
+				Zip: &v1.ZipProjectSource{},
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		starterProjectName string
+		wantStarterProject v1.StarterProject
+		wantErr            *string
+	}{
+		{
+			name:               "starter project found",
+			starterProjectName: "java-starter",
+			wantStarterProject: starterProjects[1],
+		},
+		{
+			name:               "starter project not found",
+			starterProjectName: "go-starter",
+			wantErr:            &missingStarterProjectErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							StarterProjects: starterProjects,
+						},
+					},
+				},
+			}
+
+			starterProject, err := d.GetStarterProjectByName(tt.starterProjectName)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetStarterProjectByName() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantStarterProject, starterProject, "TestDevfile200_GetStarterProjectByName(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_GetStarterProjectByName(): Error message should match")
+			}
+		})
+	}
+}
+
 func TestDevfile200_AddStarterProjects(t *testing.T) {
 	currentProject := []v1.StarterProject{
 		{
@@ -763,7 +874,7 @@ func TestDevfile200_AddStarterProjects(t *testing.T) {
 
 func TestDevfile200_UpdateStarterProject(t *testing.T) {
 
-	missingStarterProjectErr := "update starter project failed: starter project .* not found"
+	missingStarterProjectErr := "starter project .* is not found in the devfile"
 
 	tests := []struct {
 		name              string
@@ -815,6 +926,57 @@ func TestDevfile200_UpdateStarterProject(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "It should keep the position of the updated starter project",
+			args: v1.StarterProject{
+				Name:   "java",
+				SubDir: "/test",
+			},
+			devfilev2: &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							StarterProjects: []v1.StarterProject{
+								{
+									Name:   "nodejs",
+									SubDir: "/project",
+								},
+								{
+									Name:   "java",
+									SubDir: "/project",
+								},
+								{
+									Name:   "python",
+									SubDir: "/project",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedDevfilev2: &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							StarterProjects: []v1.StarterProject{
+								{
+									Name:   "nodejs",
+									SubDir: "/project",
+								},
+								{
+									Name:   "java",
+									SubDir: "/test",
+								},
+								{
+									Name:   "python",
+									SubDir: "/project",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "It should fail to update project for non existing project",
 			args: v1.StarterProject{
@@ -0,0 +1,87 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"sort"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"k8s.io/klog"
+)
+
+// Summary returns the key facts of the devfile. The used variables are only reported for a devfile
+// which variables have not been substituted yet.
+func (d *DevfileV2) Summary() common.DevfileSummary {
+	summary := common.DevfileSummary{
+		Name:            d.Metadata.Name,
+		SchemaVersion:   d.SchemaVersion,
+		ComponentCounts: map[v1.ComponentType]int{},
+		CommandCounts:   map[v1.CommandGroupKind]int{},
+		DefaultCommands: map[v1.CommandGroupKind]string{},
+	}
+
+	if d.Parent != nil && (d.Parent.Uri != "" || d.Parent.Id != "" || d.Parent.Kubernetes != nil) {
+		summary.RemoteReferenceCount++
+	}
+
+	for _, component := range d.Components {
+		componentType, err := common.GetComponentType(component)
+		if err != nil {
+			klog.V(4).Infof("component %s is not counted: %v", component.Name, err)
+			continue
+		}
+		summary.ComponentCounts[componentType]++
+		switch {
+		case component.Plugin != nil:
+			summary.RemoteReferenceCount++
+		case component.Kubernetes != nil && component.Kubernetes.Uri != "":
+			summary.RemoteReferenceCount++
+		case component.Openshift != nil && component.Openshift.Uri != "":
+			summary.RemoteReferenceCount++
+		}
+	}
+
+	for _, command := range d.Commands {
+		group := common.GetGroup(command)
+		if group == nil {
+			continue
+		}
+		summary.CommandCounts[group.Kind]++
+		if group.IsDefault != nil && *group.IsDefault {
+			summary.DefaultCommands[group.Kind] = command.Id
+		}
+	}
+
+	for name := range d.Variables {
+		summary.DeclaredVariables = append(summary.DeclaredVariables, name)
+	}
+	sort.Strings(summary.DeclaredVariables)
+
+	// the variables map is left out as it does not reference variables
+	content := d.DevWorkspaceTemplateSpecContent
+	content.Variables = nil
+	if usedVariables, err := common.GetVariableReferences(content); err == nil {
+		for name := range usedVariables {
+			summary.UsedVariables = append(summary.UsedVariables, name)
+		}
+		sort.Strings(summary.UsedVariables)
+	} else {
+		klog.V(4).Infof("the used variables are not reported: %v", err)
+	}
+
+	return summary
+}
@@ -0,0 +1,173 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	devfilepkg "github.com/devfile/api/v2/pkg/devfile"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevfile200_Summary(t *testing.T) {
+	isDefault := true
+
+	d := &DevfileV2{
+		v1.Devfile{
+			DevfileHeader: devfilepkg.DevfileHeader{
+				SchemaVersion: "2.2.0",
+				Metadata: devfilepkg.DevfileMetadata{
+					Name: "nodejs",
+				},
+			},
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				Parent: &v1.Parent{
+					ImportReference: v1.ImportReference{
+						ImportReferenceUnion: v1.ImportReferenceUnion{
+							Id: "nodejs-base",
+						},
+					},
+				},
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Variables: map[string]string{
+						"version": "16",
+						"unused":  "value",
+					},
+					Components: []v1.Component{
+						{
+							Name: "runtime",
+							ComponentUnion: v1.ComponentUnion{
+								Container: &v1.ContainerComponent{
+									Container: v1.Container{
+										Image: "quay.io/nodejs-{{version}}",
+									},
+								},
+							},
+						},
+						{
+							Name: "tools",
+							ComponentUnion: v1.ComponentUnion{
+								Container: &v1.ContainerComponent{
+									Container: v1.Container{
+										Image: "quay.io/tools",
+									},
+								},
+							},
+						},
+						{
+							Name: "cache",
+							ComponentUnion: v1.ComponentUnion{
+								Volume: &v1.VolumeComponent{},
+							},
+						},
+						{
+							Name: "deploy",
+							ComponentUnion: v1.ComponentUnion{
+								Kubernetes: &v1.KubernetesComponent{
+									K8sLikeComponent: v1.K8sLikeComponent{
+										K8sLikeComponentLocation: v1.K8sLikeComponentLocation{
+											Uri: "https://example.com/deploy.yaml",
+										},
+									},
+								},
+							},
+						},
+					},
+					Commands: []v1.Command{
+						{
+							Id: "install",
+							CommandUnion: v1.CommandUnion{
+								Exec: &v1.ExecCommand{
+									CommandLine: "npm install --prefix {{ projectDir }}",
+									LabeledCommand: v1.LabeledCommand{
+										BaseCommand: v1.BaseCommand{
+											Group: &v1.CommandGroup{Kind: v1.BuildCommandGroupKind, IsDefault: &isDefault},
+										},
+									},
+								},
+							},
+						},
+						{
+							Id: "run",
+							CommandUnion: v1.CommandUnion{
+								Exec: &v1.ExecCommand{
+									LabeledCommand: v1.LabeledCommand{
+										BaseCommand: v1.BaseCommand{
+											Group: &v1.CommandGroup{Kind: v1.RunCommandGroupKind},
+										},
+									},
+								},
+							},
+						},
+						{
+							Id: "debug",
+							CommandUnion: v1.CommandUnion{
+								Exec: &v1.ExecCommand{
+									LabeledCommand: v1.LabeledCommand{
+										BaseCommand: v1.BaseCommand{
+											Group: &v1.CommandGroup{Kind: v1.RunCommandGroupKind},
+										},
+									},
+								},
+							},
+						},
+						{
+							Id: "ungrouped",
+							CommandUnion: v1.CommandUnion{
+								Exec: &v1.ExecCommand{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	wantSummary := common.DevfileSummary{
+		Name:          "nodejs",
+		SchemaVersion: "2.2.0",
+		ComponentCounts: map[v1.ComponentType]int{
+			v1.ContainerComponentType:  2,
+			v1.VolumeComponentType:     1,
+			v1.KubernetesComponentType: 1,
+		},
+		CommandCounts: map[v1.CommandGroupKind]int{
+			v1.BuildCommandGroupKind: 1,
+			v1.RunCommandGroupKind:   2,
+		},
+		DefaultCommands: map[v1.CommandGroupKind]string{
+			v1.BuildCommandGroupKind: "install",
+		},
+		DeclaredVariables:    []string{"unused", "version"},
+		UsedVariables:        []string{"projectDir", "version"},
+		RemoteReferenceCount: 2,
+	}
+	wantString := `Name: nodejs
+Schema Version: 2.2.0
+Components: Container=2, Kubernetes=1, Volume=1
+Commands: build=1, run=2
+Default Commands: build=install
+Declared Variables: unused, version
+Used Variables: projectDir, version
+Remote References: 2
+`
+
+	summary := d.Summary()
+	assert.Equal(t, wantSummary, summary, "TestDevfile200_Summary(): The two values should be the same.")
+	assert.Equal(t, wantString, summary.String(), "TestDevfile200_Summary(): The two values should be the same.")
+}
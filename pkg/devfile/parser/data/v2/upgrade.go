@@ -0,0 +1,78 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// schemaVersionUpgrades are the schema versions a devfile can be upgraded to, in ascending order, with the migration
+// applied to a devfile of the previous schema version to upgrade it to the schema version
+var schemaVersionUpgrades = []struct {
+	schemaVersion string
+	migrate       func(d *DevfileV2) error
+}{
+	{schemaVersion: "2.0.0"},
+	{schemaVersion: "2.1.0", migrate: migrateToSchemaVersion210},
+	{schemaVersion: "2.2.0"},
+}
+
+// UpgradeSchemaVersion upgrades the devfile to the target schema version, migrating the fields which changed
+// between the schema versions. It returns an error if the target version is unknown or older than the devfile schema
+// version, or if the devfile holds elements which are not supported by the target version.
+func (d *DevfileV2) UpgradeSchemaVersion(target string) error {
+	var knownVersions []string
+	targetIndex, currentIndex := -1, -1
+	for i, upgrade := range schemaVersionUpgrades {
+		knownVersions = append(knownVersions, upgrade.schemaVersion)
+		if upgrade.schemaVersion == target {
+			targetIndex = i
+		}
+		if upgrade.schemaVersion == d.SchemaVersion {
+			currentIndex = i
+		}
+	}
+	if targetIndex == -1 {
+		return fmt.Errorf("unknown target schema version %s, it should be one of %s", target, strings.Join(knownVersions, ", "))
+	}
+	if currentIndex == -1 {
+		return fmt.Errorf("unable to upgrade the devfile schema version %s, it should be one of %s", d.SchemaVersion, strings.Join(knownVersions, ", "))
+	}
+	if targetIndex < currentIndex {
+		return fmt.Errorf("unable to downgrade the devfile schema version %s to %s", d.SchemaVersion, target)
+	}
+
+	for _, upgrade := range schemaVersionUpgrades[currentIndex+1 : targetIndex+1] {
+		if upgrade.migrate != nil {
+			if err := upgrade.migrate(d); err != nil {
+				return err
+			}
+		}
+	}
+	d.SchemaVersion = target
+	return nil
+}
+
+// migrateToSchemaVersion210 migrates a 2.0.0 devfile to 2.1.0, the plugin components are removed in 2.1.0
+func migrateToSchemaVersion210(d *DevfileV2) error {
+	for _, component := range d.Components {
+		if component.Plugin != nil {
+			return fmt.Errorf("unable to upgrade the devfile to schema version 2.1.0, the plugin component %s is not supported by 2.1.0", component.Name)
+		}
+	}
+	return nil
+}
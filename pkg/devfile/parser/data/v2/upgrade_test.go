@@ -0,0 +1,161 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"encoding/json"
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	devfilepkg "github.com/devfile/api/v2/pkg/devfile"
+	v210 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2/2.1.0"
+	v220 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2/2.2.0"
+	"github.com/stretchr/testify/assert"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+func TestDevfile200_UpgradeSchemaVersion(t *testing.T) {
+	unknownVersionErr := "unknown target schema version 2.3.0, it should be one of 2.0.0, 2.1.0, 2.2.0"
+	unknownCurrentVersionErr := "unable to upgrade the devfile schema version 1.0.0"
+	downgradeErr := "unable to downgrade the devfile schema version 2.2.0 to 2.0.0"
+	pluginErr := "the plugin component theia is not supported by 2.1.0"
+
+	newDevfile := func(schemaVersion string, components ...v1.Component) *DevfileV2 {
+		return &DevfileV2{
+			v1.Devfile{
+				DevfileHeader: devfilepkg.DevfileHeader{
+					SchemaVersion: schemaVersion,
+					Metadata: devfilepkg.DevfileMetadata{
+						Name: "nodejs",
+					},
+				},
+				DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+					DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+						Components: components,
+						Commands: []v1.Command{
+							{
+								Id: "run",
+								CommandUnion: v1.CommandUnion{
+									Exec: &v1.ExecCommand{
+										CommandLine: "npm start",
+										Component:   "runtime",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	runtime := v1.Component{
+		Name: "runtime",
+		ComponentUnion: v1.ComponentUnion{
+			Container: &v1.ContainerComponent{
+				Container: v1.Container{
+					Image: "quay.io/nodejs-16",
+				},
+			},
+		},
+	}
+	plugin := v1.Component{
+		Name: "theia",
+		ComponentUnion: v1.ComponentUnion{
+			Plugin: &v1.PluginComponent{
+				ImportReference: v1.ImportReference{
+					ImportReferenceUnion: v1.ImportReferenceUnion{
+						Id: "eclipse/che-theia/latest",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		devfilev2  *DevfileV2
+		target     string
+		jsonSchema string
+		wantErr    *string
+	}{
+		{
+			name:       "upgrade 2.0.0 to 2.2.0",
+			devfilev2:  newDevfile("2.0.0", runtime),
+			target:     "2.2.0",
+			jsonSchema: v220.JsonSchema220,
+		},
+		{
+			name:       "upgrade 2.0.0 to 2.1.0",
+			devfilev2:  newDevfile("2.0.0", runtime),
+			target:     "2.1.0",
+			jsonSchema: v210.JsonSchema210,
+		},
+		{
+			name:       "same schema version",
+			devfilev2:  newDevfile("2.2.0", runtime),
+			target:     "2.2.0",
+			jsonSchema: v220.JsonSchema220,
+		},
+		{
+			name:      "unknown target version",
+			devfilev2: newDevfile("2.0.0", runtime),
+			target:    "2.3.0",
+			wantErr:   &unknownVersionErr,
+		},
+		{
+			name:      "unknown devfile schema version",
+			devfilev2: newDevfile("1.0.0", runtime),
+			target:    "2.2.0",
+			wantErr:   &unknownCurrentVersionErr,
+		},
+		{
+			name:      "downgrade",
+			devfilev2: newDevfile("2.2.0", runtime),
+			target:    "2.0.0",
+			wantErr:   &downgradeErr,
+		},
+		{
+			name:      "plugin components are not supported after 2.0.0",
+			devfilev2: newDevfile("2.0.0", runtime, plugin),
+			target:    "2.2.0",
+			wantErr:   &pluginErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.devfilev2.UpgradeSchemaVersion(tt.target)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_UpgradeSchemaVersion() unexpected error: %v, wantErr %v", err, tt.wantErr)
+				return
+			} else if err != nil {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_UpgradeSchemaVersion(): Error message should match")
+				return
+			}
+
+			assert.Equal(t, tt.target, tt.devfilev2.GetSchemaVersion(), "TestDevfile200_UpgradeSchemaVersion(): The two values should be the same.")
+
+			content, err := json.Marshal(tt.devfilev2)
+			if err != nil {
+				t.Fatalf("TestDevfile200_UpgradeSchemaVersion() unexpected error: %v", err)
+			}
+			result, err := gojsonschema.Validate(gojsonschema.NewStringLoader(tt.jsonSchema), gojsonschema.NewBytesLoader(content))
+			if err != nil {
+				t.Fatalf("TestDevfile200_UpgradeSchemaVersion() unexpected error: %v", err)
+			}
+			assert.True(t, result.Valid(), "TestDevfile200_UpgradeSchemaVersion(): the upgraded devfile should be valid against the target schema: %v", result.Errors())
+		})
+	}
+}
@@ -0,0 +1,68 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"fmt"
+
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+)
+
+// GetVariables returns a copy of the devfile top-level variables
+func (d *DevfileV2) GetVariables() map[string]string {
+	if d.Variables == nil {
+		return nil
+	}
+	variables := make(map[string]string, len(d.Variables))
+	for name, value := range d.Variables {
+		variables[name] = value
+	}
+	return variables
+}
+
+// AddVariable adds the devfile top-level variable, an existing variable is overwritten only if overwrite is true.
+// It returns an error if the name cannot be referenced by the variable substitution.
+func (d *DevfileV2) AddVariable(name, value string, overwrite bool) error {
+	if d.SchemaVersion == "2.0.0" {
+		return fmt.Errorf("top-level variables is not supported in devfile schema version 2.0.0")
+	}
+	if !common.IsValidVariableName(name) {
+		return fmt.Errorf("invalid variable name %q, it should only contain alphanumeric characters, '_', '-' or '.'", name)
+	}
+	if _, ok := d.Variables[name]; ok && !overwrite {
+		return &common.FieldAlreadyExistError{
+			Field: "variable",
+			Name:  name,
+		}
+	}
+	if d.Variables == nil {
+		d.Variables = map[string]string{}
+	}
+	d.Variables[name] = value
+	return nil
+}
+
+// RemoveVariable removes the devfile top-level variable
+func (d *DevfileV2) RemoveVariable(name string) error {
+	if _, ok := d.Variables[name]; !ok {
+		return &common.FieldNotFoundError{
+			Field: "variable",
+			Name:  name,
+		}
+	}
+	delete(d.Variables, name)
+	return nil
+}
@@ -0,0 +1,137 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	devfilepkg "github.com/devfile/api/v2/pkg/devfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevfile200_AddAndRemoveVariables(t *testing.T) {
+	invalidNameErr := "invalid variable name .*, it should only contain alphanumeric characters"
+	existingVariableErr := "variable version already exists in devfile"
+	missingVariableErr := "variable tag is not found in the devfile"
+	schema200Err := "top-level variables is not supported in devfile schema version 2.0.0"
+
+	type variable struct {
+		name      string
+		value     string
+		overwrite bool
+	}
+	tests := []struct {
+		name              string
+		schemaVersion     string
+		variablesToAdd    []variable
+		variablesToRemove []string
+		wantVariables     map[string]string
+		wantErr           *string
+	}{
+		{
+			name:           "add variables",
+			schemaVersion:  "2.2.0",
+			variablesToAdd: []variable{{name: "registry", value: "quay.io"}, {name: "node_version-1.x", value: "16"}},
+			wantVariables:  map[string]string{"version": "1.0.0", "registry": "quay.io", "node_version-1.x": "16"},
+		},
+		{
+			name:           "overwrite a variable",
+			schemaVersion:  "2.2.0",
+			variablesToAdd: []variable{{name: "version", value: "2.0.0", overwrite: true}},
+			wantVariables:  map[string]string{"version": "2.0.0"},
+		},
+		{
+			name:           "overwrite protection",
+			schemaVersion:  "2.2.0",
+			variablesToAdd: []variable{{name: "version", value: "2.0.0"}},
+			wantErr:        &existingVariableErr,
+		},
+		{
+			name:           "name with a space",
+			schemaVersion:  "2.2.0",
+			variablesToAdd: []variable{{name: "my version", value: "2.0.0"}},
+			wantErr:        &invalidNameErr,
+		},
+		{
+			name:           "name with braces",
+			schemaVersion:  "2.2.0",
+			variablesToAdd: []variable{{name: "{{version}}", value: "2.0.0"}},
+			wantErr:        &invalidNameErr,
+		},
+		{
+			name:           "empty name",
+			schemaVersion:  "2.2.0",
+			variablesToAdd: []variable{{name: "", value: "2.0.0"}},
+			wantErr:        &invalidNameErr,
+		},
+		{
+			name:              "remove a variable",
+			schemaVersion:     "2.2.0",
+			variablesToRemove: []string{"version"},
+			wantVariables:     map[string]string{},
+		},
+		{
+			name:              "remove a missing variable",
+			schemaVersion:     "2.2.0",
+			variablesToRemove: []string{"tag"},
+			wantErr:           &missingVariableErr,
+		},
+		{
+			name:           "schema 2.0.0 does not have variables",
+			schemaVersion:  "2.0.0",
+			variablesToAdd: []variable{{name: "registry", value: "quay.io"}},
+			wantErr:        &schema200Err,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevfileHeader: devfilepkg.DevfileHeader{
+						SchemaVersion: tt.schemaVersion,
+					},
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Variables: map[string]string{"version": "1.0.0"},
+						},
+					},
+				},
+			}
+
+			var err error
+			for _, variable := range tt.variablesToAdd {
+				if err = d.AddVariable(variable.name, variable.value, variable.overwrite); err != nil {
+					break
+				}
+			}
+			for _, name := range tt.variablesToRemove {
+				if err != nil {
+					break
+				}
+				err = d.RemoveVariable(name)
+			}
+
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_AddAndRemoveVariables() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantVariables, d.GetVariables(), "TestDevfile200_AddAndRemoveVariables(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_AddAndRemoveVariables(): Error message should match")
+			}
+		})
+	}
+}
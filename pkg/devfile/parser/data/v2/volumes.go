@@ -57,11 +57,46 @@ func (d *DevfileV2) AddVolumeMounts(containerName string, volumeMounts []v1.Volu
 	return nil
 }
 
+// UpdateVolumeMount updates the path of the specified volume mount of the specified container component in place.
+// If the volume is mounted at different paths in the container, the first volume mount is updated.
+func (d *DevfileV2) UpdateVolumeMount(componentName, mountName, newPath string) error {
+	for _, component := range d.Components {
+		if component.Container == nil || component.Name != componentName {
+			continue
+		}
+		mountIndex := -1
+		for i, volumeMount := range component.Container.VolumeMounts {
+			if volumeMount.Name == mountName {
+				mountIndex = i
+				break
+			}
+		}
+		if mountIndex == -1 {
+			return &common.FieldNotFoundError{
+				Field: "volume mount",
+				Name:  mountName,
+			}
+		}
+		for i, volumeMount := range component.Container.VolumeMounts {
+			if i != mountIndex && volumeMount.Path == newPath {
+				return fmt.Errorf("unable to update volume mount %s, as another volume %s is mounted to the same path %s in the container %s", mountName, volumeMount.Name, newPath, component.Name)
+			}
+		}
+		component.Container.VolumeMounts[mountIndex].Path = newPath
+		return nil
+	}
+
+	return &common.FieldNotFoundError{
+		Field: "container component",
+		Name:  componentName,
+	}
+}
+
 // DeleteVolumeMount deletes the volume mount from container components
 func (d *DevfileV2) DeleteVolumeMount(name string) error {
 	found := false
 	for i := range d.Components {
-		if d.Components[i].Container != nil && d.Components[i].Name != name {
+		if d.Components[i].Container != nil {
 			// Volume Mounts can have multiple instances of a volume mounted at different paths
 			// As arrays are rearraged/shifted for deletion, we lose one element every time there is a match
 			// Looping backward is efficient, otherwise we would have to manually decrement counter
@@ -86,12 +121,17 @@ func (d *DevfileV2) DeleteVolumeMount(name string) error {
 }
 
 // GetVolumeMountPaths gets all the mount paths of the specified volume mount from the specified container component.
-// A container can mount at different paths for a given volume.
-func (d *DevfileV2) GetVolumeMountPaths(mountName, containerName string) ([]string, error) {
+// A container can mount at different paths for a given volume. If options are passed, the container component
+// is looked up among the components filtered with the options, as GetComponents does.
+func (d *DevfileV2) GetVolumeMountPaths(mountName, containerName string, options ...common.DevfileOptions) ([]string, error) {
 	componentFound := false
 	var mountPaths []string
 
-	for _, component := range d.Components {
+	components, err := d.getComponentsWithOptions(options)
+	if err != nil {
+		return mountPaths, err
+	}
+	for _, component := range components {
 		if component.Container != nil && component.Name == containerName {
 			componentFound = true
 			for _, volumeMount := range component.Container.VolumeMounts {
@@ -115,3 +155,69 @@ func (d *DevfileV2) GetVolumeMountPaths(mountName, containerName string) ([]stri
 
 	return mountPaths, nil
 }
+
+// GetVolumeMounts returns a copy of the volume mounts of the specified container component. If options are passed,
+// the container component is looked up among the components filtered with the options, as GetComponents does.
+func (d *DevfileV2) GetVolumeMounts(componentName string, options ...common.DevfileOptions) ([]v1.VolumeMount, error) {
+	components, err := d.getComponentsWithOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	for _, component := range components {
+		if component.Container != nil && component.Name == componentName {
+			volumeMounts := make([]v1.VolumeMount, len(component.Container.VolumeMounts))
+			copy(volumeMounts, component.Container.VolumeMounts)
+			return volumeMounts, nil
+		}
+	}
+
+	return nil, &common.FieldNotFoundError{
+		Field: "container component",
+		Name:  componentName,
+	}
+}
+
+// RenameVolume renames the specified volume component and the volume mounts of the container components referencing it
+func (d *DevfileV2) RenameVolume(oldName, newName string) error {
+	volumeIndex := -1
+	for i, component := range d.Components {
+		if component.Name == newName {
+			return fmt.Errorf("unable to rename volume %s, as a component %s already exists", oldName, newName)
+		}
+		if component.Volume != nil && component.Name == oldName {
+			volumeIndex = i
+		}
+	}
+	if volumeIndex == -1 {
+		return &common.FieldNotFoundError{
+			Field: "volume component",
+			Name:  oldName,
+		}
+	}
+
+	d.Components[volumeIndex].Name = newName
+	for _, component := range d.Components {
+		if component.Container == nil {
+			continue
+		}
+		for i := range component.Container.VolumeMounts {
+			if component.Container.VolumeMounts[i].Name == oldName {
+				component.Container.VolumeMounts[i].Name = newName
+			}
+		}
+	}
+
+	return nil
+}
+
+// getComponentsWithOptions returns the components filtered with the options, at most one options is expected
+func (d *DevfileV2) getComponentsWithOptions(options []common.DevfileOptions) ([]v1.Component, error) {
+	switch len(options) {
+	case 0:
+		return d.Components, nil
+	case 1:
+		return d.GetComponents(options[0])
+	default:
+		return nil, fmt.Errorf("at most one devfile options is expected, got %d", len(options))
+	}
+}
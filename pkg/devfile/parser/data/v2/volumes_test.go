@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
 	"github.com/devfile/library/v2/pkg/testingutil"
 	"github.com/stretchr/testify/assert"
 )
@@ -189,6 +191,146 @@ func TestDevfile200_AddVolumeMount(t *testing.T) {
 	}
 }
 
+func TestDevfile200_UpdateVolumeMount(t *testing.T) {
+	image0 := "some-image-0"
+
+	container0 := "container0"
+	container1 := "container1"
+
+	volume0 := "volume0"
+	volume1 := "volume1"
+
+	samePathPresentErr := "unable to update volume mount .*, as another volume .* is mounted to the same path .* in the container .*"
+	missingContainerErr := "container component .* is not found in the devfile"
+	missingMountErr := "volume mount .* is not found in the devfile"
+
+	currentComponents := func() []v1.Component {
+		return []v1.Component{
+			{
+				Name: container0,
+				ComponentUnion: v1.ComponentUnion{
+					Container: &v1.ContainerComponent{
+						Container: v1.Container{
+							Image: image0,
+							VolumeMounts: []v1.VolumeMount{
+								testingutil.GetFakeVolumeMount(volume0, "/path0"),
+								testingutil.GetFakeVolumeMount(volume1, "/path1"),
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: volume0,
+				ComponentUnion: v1.ComponentUnion{
+					Volume: &v1.VolumeComponent{},
+				},
+			},
+		}
+	}
+
+	type args struct {
+		componentName string
+		mountName     string
+		newPath       string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantComponents []v1.Component
+		wantErr        *string
+	}{
+		{
+			name: "update the path of the volume mount in place",
+			args: args{
+				componentName: container0,
+				mountName:     volume0,
+				newPath:       "/data",
+			},
+			wantComponents: []v1.Component{
+				{
+					Name: container0,
+					ComponentUnion: v1.ComponentUnion{
+						Container: &v1.ContainerComponent{
+							Container: v1.Container{
+								Image: image0,
+								VolumeMounts: []v1.VolumeMount{
+									testingutil.GetFakeVolumeMount(volume0, "/data"),
+									testingutil.GetFakeVolumeMount(volume1, "/path1"),
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: volume0,
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{},
+					},
+				},
+			},
+		},
+		{
+			name: "error out when another volume is mounted to the new path",
+			args: args{
+				componentName: container0,
+				mountName:     volume0,
+				newPath:       "/path1",
+			},
+			wantErr: &samePathPresentErr,
+		},
+		{
+			name: "error out when the specified container is not found",
+			args: args{
+				componentName: container1,
+				mountName:     volume0,
+				newPath:       "/data",
+			},
+			wantErr: &missingContainerErr,
+		},
+		{
+			name: "error out when the specified component is not a container",
+			args: args{
+				componentName: volume0,
+				mountName:     volume0,
+				newPath:       "/data",
+			},
+			wantErr: &missingContainerErr,
+		},
+		{
+			name: "error out when the specified volume mount is not found",
+			args: args{
+				componentName: container0,
+				mountName:     "volume2",
+				newPath:       "/data",
+			},
+			wantErr: &missingMountErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: currentComponents(),
+						},
+					},
+				},
+			}
+
+			err := d.UpdateVolumeMount(tt.args.componentName, tt.args.mountName, tt.args.newPath)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_UpdateVolumeMount() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantComponents, d.Components, "TestDevfile200_UpdateVolumeMount(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_UpdateVolumeMount(): Error message should match")
+			}
+		})
+	}
+}
+
 func TestDevfile200_DeleteVolumeMounts(t *testing.T) {
 
 	d := &DevfileV2{
@@ -286,6 +428,43 @@ func TestDevfile200_DeleteVolumeMounts(t *testing.T) {
 
 }
 
+func TestDevfile200_DeleteVolumeMountNamedAsContainer(t *testing.T) {
+
+	d := &DevfileV2{
+		v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Components: []v1.Component{
+						{
+							Name: "foo",
+							ComponentUnion: v1.ComponentUnion{
+								Container: &v1.ContainerComponent{
+									Container: v1.Container{
+										VolumeMounts: []v1.VolumeMount{
+											testingutil.GetFakeVolumeMount("foo", "/foo"),
+											testingutil.GetFakeVolumeMount("bar", "/bar"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	wantVolumeMounts := []v1.VolumeMount{
+		testingutil.GetFakeVolumeMount("bar", "/bar"),
+	}
+
+	err := d.DeleteVolumeMount("foo")
+	if err != nil {
+		t.Errorf("TestDevfile200_DeleteVolumeMountNamedAsContainer() unexpected error: %v", err)
+	}
+	assert.Equal(t, wantVolumeMounts, d.Components[0].Container.VolumeMounts, "TestDevfile200_DeleteVolumeMountNamedAsContainer(): The two values should be the same.")
+}
+
 func TestDevfile200_GetVolumeMountPaths(t *testing.T) {
 
 	volumeNotMountedErr := "volume .* not mounted to component .*"
@@ -398,3 +577,258 @@ func TestDevfile200_GetVolumeMountPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestDevfile200_GetVolumeMounts(t *testing.T) {
+
+	missingContainerErr := "container component .* is not found in the devfile"
+
+	d := &DevfileV2{
+		v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Components: []v1.Component{
+						{
+							Name: "component1",
+							ComponentUnion: v1.ComponentUnion{
+								Container: &v1.ContainerComponent{
+									Container: v1.Container{
+										VolumeMounts: []v1.VolumeMount{
+											testingutil.GetFakeVolumeMount("volume1", "/path"),
+											testingutil.GetFakeVolumeMount("volume1", "/path2"),
+											testingutil.GetFakeVolumeMount("volume2", "/data"),
+										},
+									},
+								},
+							},
+						},
+						{
+							Name: "component2",
+							ComponentUnion: v1.ComponentUnion{
+								Container: &v1.ContainerComponent{},
+							},
+						},
+						testingutil.GetFakeVolumeComponent("volume1", "1Gi"),
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		componentName    string
+		wantVolumeMounts []v1.VolumeMount
+		wantErr          *string
+	}{
+		{
+			name:          "container with multiple mounts",
+			componentName: "component1",
+			wantVolumeMounts: []v1.VolumeMount{
+				testingutil.GetFakeVolumeMount("volume1", "/path"),
+				testingutil.GetFakeVolumeMount("volume1", "/path2"),
+				testingutil.GetFakeVolumeMount("volume2", "/data"),
+			},
+		},
+		{
+			name:             "container without mounts",
+			componentName:    "component2",
+			wantVolumeMounts: []v1.VolumeMount{},
+		},
+		{
+			name:          "component which is not a container",
+			componentName: "volume1",
+			wantErr:       &missingContainerErr,
+		},
+		{
+			name:          "missing component",
+			componentName: "component3",
+			wantErr:       &missingContainerErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			volumeMounts, err := d.GetVolumeMounts(tt.componentName)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetVolumeMounts() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantVolumeMounts, volumeMounts, "TestDevfile200_GetVolumeMounts(): The two values should be the same.")
+				if len(volumeMounts) > 0 {
+					// the returned volume mounts are a copy
+					volumeMounts[0].Path = "/altered"
+					assert.NotEqual(t, "/altered", d.Components[0].Container.VolumeMounts[0].Path, "TestDevfile200_GetVolumeMounts(): The volume mounts should not be altered.")
+				}
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_GetVolumeMounts(): Error message should match")
+			}
+		})
+	}
+}
+
+func TestDevfile200_RenameVolume(t *testing.T) {
+
+	existingComponentErr := "unable to rename volume .*, as a component .* already exists"
+	missingVolumeErr := "volume component .* is not found in the devfile"
+
+	containerComponent := func(name string, volumeMounts ...v1.VolumeMount) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						VolumeMounts: volumeMounts,
+					},
+				},
+			},
+		}
+	}
+	currentComponents := func() []v1.Component {
+		return []v1.Component{
+			containerComponent("runtime", testingutil.GetFakeVolumeMount("data", "/data"), testingutil.GetFakeVolumeMount("cache", "/cache")),
+			containerComponent("tools", testingutil.GetFakeVolumeMount("data", "/data"), testingutil.GetFakeVolumeMount("data", "/data2")),
+			testingutil.GetFakeVolumeComponent("data", "1Gi"),
+			testingutil.GetFakeVolumeComponent("cache", "1Gi"),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		oldName        string
+		newName        string
+		wantComponents []v1.Component
+		wantErr        *string
+	}{
+		{
+			name:    "rename the volume mounted by two containers",
+			oldName: "data",
+			newName: "storage",
+			wantComponents: []v1.Component{
+				containerComponent("runtime", testingutil.GetFakeVolumeMount("storage", "/data"), testingutil.GetFakeVolumeMount("cache", "/cache")),
+				containerComponent("tools", testingutil.GetFakeVolumeMount("storage", "/data"), testingutil.GetFakeVolumeMount("storage", "/data2")),
+				testingutil.GetFakeVolumeComponent("storage", "1Gi"),
+				testingutil.GetFakeVolumeComponent("cache", "1Gi"),
+			},
+		},
+		{
+			name:    "error out when the new name is another volume",
+			oldName: "data",
+			newName: "cache",
+			wantErr: &existingComponentErr,
+		},
+		{
+			name:    "error out when the volume is not found",
+			oldName: "runtime",
+			newName: "storage",
+			wantErr: &missingVolumeErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{
+				v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: currentComponents(),
+						},
+					},
+				},
+			}
+
+			err := d.RenameVolume(tt.oldName, tt.newName)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_RenameVolume() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantComponents, d.Components, "TestDevfile200_RenameVolume(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_RenameVolume(): Error message should match")
+				assert.Equal(t, currentComponents(), d.Components, "TestDevfile200_RenameVolume(): The components should not be altered.")
+			}
+		})
+	}
+}
+
+func TestDevfile200_GetVolumeMountsWithOptions(t *testing.T) {
+
+	missingContainerErr := "container component .* is not found in the devfile"
+
+	d := &DevfileV2{
+		v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Components: []v1.Component{
+						{
+							Name:       "component1",
+							Attributes: attributes.Attributes{}.PutString("stage", "dev"),
+							ComponentUnion: v1.ComponentUnion{
+								Container: &v1.ContainerComponent{
+									Container: v1.Container{
+										VolumeMounts: []v1.VolumeMount{
+											testingutil.GetFakeVolumeMount("volume1", "/path"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		options          []common.DevfileOptions
+		wantVolumeMounts []v1.VolumeMount
+		wantPaths        []string
+		wantErr          *string
+	}{
+		{
+			name: "no options",
+			wantVolumeMounts: []v1.VolumeMount{
+				testingutil.GetFakeVolumeMount("volume1", "/path"),
+			},
+			wantPaths: []string{"/path"},
+		},
+		{
+			name: "filter including the container",
+			options: []common.DevfileOptions{
+				{
+					Filter: map[string]interface{}{"stage": "dev"},
+				},
+			},
+			wantVolumeMounts: []v1.VolumeMount{
+				testingutil.GetFakeVolumeMount("volume1", "/path"),
+			},
+			wantPaths: []string{"/path"},
+		},
+		{
+			name: "filter excluding the container",
+			options: []common.DevfileOptions{
+				{
+					Filter: map[string]interface{}{"stage": "prod"},
+				},
+			},
+			wantErr: &missingContainerErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			volumeMounts, err := d.GetVolumeMounts("component1", tt.options...)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetVolumeMountsWithOptions() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantVolumeMounts, volumeMounts, "TestDevfile200_GetVolumeMountsWithOptions(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_GetVolumeMountsWithOptions(): Error message should match")
+			}
+
+			paths, err := d.GetVolumeMountPaths("volume1", "component1", tt.options...)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_GetVolumeMountsWithOptions() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantPaths, paths, "TestDevfile200_GetVolumeMountsWithOptions(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_GetVolumeMountsWithOptions(): Error message should match")
+			}
+		})
+	}
+}
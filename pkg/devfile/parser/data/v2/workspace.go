@@ -16,6 +16,8 @@
 package v2
 
 import (
+	"fmt"
+
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 )
 
@@ -38,3 +40,28 @@ func (d *DevfileV2) GetDevfileWorkspaceSpec() *v1.DevWorkspaceTemplateSpec {
 func (d *DevfileV2) SetDevfileWorkspaceSpec(spec v1.DevWorkspaceTemplateSpec) {
 	d.DevWorkspaceTemplateSpec = spec
 }
+
+// ToDevWorkspaceTemplateSpec maps the components, commands, events, projects and starter projects of the devfile
+// into the template spec embedded by the DevWorkspace custom resources. The devfile must be flattened, an error
+// is returned if its parent or a plugin component is not resolved.
+func (d *DevfileV2) ToDevWorkspaceTemplateSpec() (v1.DevWorkspaceTemplateSpec, error) {
+	if d.Parent != nil {
+		return v1.DevWorkspaceTemplateSpec{}, fmt.Errorf("unable to convert the devfile to a DevWorkspace template spec, the parent is not resolved")
+	}
+	for _, component := range d.Components {
+		if component.Plugin != nil {
+			return v1.DevWorkspaceTemplateSpec{}, fmt.Errorf("unable to convert the devfile to a DevWorkspace template spec, the plugin component %s is not resolved", component.Name)
+		}
+	}
+
+	content := d.DevWorkspaceTemplateSpecContent.DeepCopy()
+	return v1.DevWorkspaceTemplateSpec{
+		DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+			Components:      content.Components,
+			Commands:        content.Commands,
+			Events:          content.Events,
+			Projects:        content.Projects,
+			StarterProjects: content.StarterProjects,
+		},
+	}, nil
+}
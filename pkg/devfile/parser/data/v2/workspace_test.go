@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
 )
 
 var devworkspaceContent = v1.DevWorkspaceTemplateSpecContent{
@@ -120,3 +121,114 @@ func TestDevfile200_SetDevfileWorkspaceSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestDevfile200_ToDevWorkspaceTemplateSpec(t *testing.T) {
+
+	commands := []v1.Command{
+		{
+			Id: "run",
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					CommandLine: "npm start",
+					Component:   "component1",
+				},
+			},
+		},
+	}
+	events := &v1.Events{
+		DevWorkspaceEvents: v1.DevWorkspaceEvents{
+			PostStart: []string{"run"},
+		},
+	}
+	projects := []v1.Project{
+		{
+			Name: "nodejs-starter",
+			ProjectSource: v1.ProjectSource{
+				Git: &v1.GitProjectSource{
+					GitLikeProjectSource: v1.GitLikeProjectSource{
+						Remotes: map[string]string{"origin": "https://github.com/odo-devfiles/nodejs-ex.git"},
+					},
+				},
+			},
+		},
+	}
+
+	unresolvedParentErr := "unable to convert the devfile to a DevWorkspace template spec, the parent is not resolved"
+	unresolvedPluginErr := "unable to convert the devfile to a DevWorkspace template spec, the plugin component plugin1 is not resolved"
+
+	tests := []struct {
+		name    string
+		devfile v1.Devfile
+		want    v1.DevWorkspaceTemplateSpec
+		wantErr *string
+	}{
+		{
+			name: "flattened devfile",
+			devfile: v1.Devfile{
+				DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+					DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+						Components: devworkspaceContent.Components,
+						Commands:   commands,
+						Events:     events,
+						Projects:   projects,
+						Variables:  map[string]string{"version": "16"},
+					},
+				},
+			},
+			want: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Components: devworkspaceContent.Components,
+					Commands:   commands,
+					Events:     events,
+					Projects:   projects,
+				},
+			},
+		},
+		{
+			name: "devfile with an unresolved parent",
+			devfile: v1.Devfile{
+				DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+					Parent: &v1.Parent{
+						ImportReference: v1.ImportReference{
+							ImportReferenceUnion: v1.ImportReferenceUnion{
+								Uri: "https://example.com/devfile.yaml",
+							},
+						},
+					},
+				},
+			},
+			wantErr: &unresolvedParentErr,
+		},
+		{
+			name: "devfile with an unresolved plugin",
+			devfile: v1.Devfile{
+				DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+					DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+						Components: []v1.Component{
+							{
+								Name: "plugin1",
+								ComponentUnion: v1.ComponentUnion{
+									Plugin: &v1.PluginComponent{},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: &unresolvedPluginErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DevfileV2{tt.devfile}
+			got, err := d.ToDevWorkspaceTemplateSpec()
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestDevfile200_ToDevWorkspaceTemplateSpec() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.want, got, "TestDevfile200_ToDevWorkspaceTemplateSpec(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "TestDevfile200_ToDevWorkspaceTemplateSpec(): Error message should match")
+			}
+		})
+	}
+}
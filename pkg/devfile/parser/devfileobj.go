@@ -16,8 +16,14 @@
 package parser
 
 import (
+	"encoding/json"
+	"fmt"
+
 	devfileCtx "github.com/devfile/library/v2/pkg/devfile/parser/context"
 	"github.com/devfile/library/v2/pkg/devfile/parser/data"
+	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/pkg/errors"
 )
 
 // Default filenames for create devfile
@@ -35,3 +41,60 @@ type DevfileObj struct {
 	// Data has the devfile data
 	Data data.DevfileData
 }
+
+// Flatten returns a new devfile with the parent and plugins of the devfile merged into it, the devfile data is not
+// modified. The parent and plugins are resolved with the resolution options of args, e.g. RegistryURLs, K8sClient or
+// HTTPClient, the devfile source of args is ignored.
+//
+// The devfile is merged following the devfile override semantics:
+//   - the parent overrides patch the elements of the parent, and the plugin overrides patch the elements of the plugin.
+//     The elements of a list are matched by their key, i.e. the name of the components, projects and starter projects
+//     and the id of the commands. An element of the overrides which does not match an element of the parent or plugin
+//     is an error.
+//   - the elements of the devfile, the overridden parent and the overridden plugins are then merged into the flattened
+//     devfile. An element is only added once, an element of the devfile which has the same key as an element of the
+//     parent or a plugin is an error, the elements are not replaced.
+func (d DevfileObj) Flatten(args ParserArgs) (*v2.DevfileV2, error) {
+	devfileV2, ok := d.Data.(*v2.DevfileV2)
+	if !ok {
+		return nil, fmt.Errorf("unable to flatten the devfile data of type %T", d.Data)
+	}
+	content, err := json.Marshal(devfileV2)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to copy the devfile data")
+	}
+	flattened := &v2.DevfileV2{}
+	if err = json.Unmarshal(content, flattened); err != nil {
+		return nil, errors.Wrap(err, "failed to copy the devfile data")
+	}
+
+	err = parseParentAndPlugin(DevfileObj{Ctx: d.Ctx, Data: flattened}, &resolutionContextTree{}, newResolverTools(args))
+	if err != nil {
+		return nil, err
+	}
+	return flattened, nil
+}
+
+// GetCommandShell returns the shell the command line of the specified exec command is run with: the shell set by the
+// command, otherwise the default shell of the devfile context if set, otherwise v2.DefaultShell.
+// An empty shell means the command line is run directly.
+func (d DevfileObj) GetCommandShell(commandID string) (string, error) {
+	shell, err := d.Data.GetCommandShell(commandID)
+	if err != nil {
+		return "", err
+	}
+	defaultShell, ok := d.Ctx.GetDefaultShell()
+	if !ok {
+		return shell, nil
+	}
+	commands, err := d.Data.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, command := range commands {
+		if command.Id == commandID && !command.Attributes.Exists(v2.CommandShellAttribute) {
+			return defaultShell, nil
+		}
+	}
+	return shell, nil
+}
@@ -0,0 +1,230 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevfileObj_Flatten(t *testing.T) {
+	const parentDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: parent
+components:
+- name: parent-runtime
+  container:
+    image: quay.io/nodejs-14
+`
+	const pluginDevfile = `schemaVersion: 2.0.0
+metadata:
+  name: plugin
+components:
+- name: plugin-tool
+  container:
+    image: quay.io/tool
+`
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var content string
+		switch r.URL.Path {
+		case "/parent.yaml":
+			content = parentDevfile
+		case "/plugin.yaml":
+			content = pluginDevfile
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Errorf("unexpected error while writing yaml: %v", err)
+		}
+	}))
+	defer testServer.Close()
+
+	tests := []struct {
+		name           string
+		devfileContent string
+		wantImages     map[string]string
+	}{
+		{
+			name: "parent adding a component",
+			devfileContent: fmt.Sprintf(`schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+parent:
+  uri: %s/parent.yaml
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+`, testServer.URL),
+			wantImages: map[string]string{
+				"parent-runtime": "quay.io/nodejs-14",
+				"runtime":        "quay.io/nodejs-16",
+			},
+		},
+		{
+			name: "parent overrides patching a parent component",
+			devfileContent: fmt.Sprintf(`schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+parent:
+  uri: %s/parent.yaml
+  components:
+  - name: parent-runtime
+    container:
+      image: quay.io/nodejs-18
+`, testServer.URL),
+			wantImages: map[string]string{
+				"parent-runtime": "quay.io/nodejs-18",
+			},
+		},
+		{
+			name: "plugin contributing a component",
+			devfileContent: fmt.Sprintf(`schemaVersion: 2.0.0
+metadata:
+  name: nodejs
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+- name: tools
+  plugin:
+    uri: %s/plugin.yaml
+`, testServer.URL),
+			wantImages: map[string]string{
+				"runtime":     "quay.io/nodejs-16",
+				"plugin-tool": "quay.io/tool",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDevfile(ParserArgs{
+				Data:             []byte(tt.devfileContent),
+				FlattenedDevfile: &isFalse,
+			})
+			if err != nil {
+				t.Fatalf("TestDevfileObj_Flatten() unexpected error: %v", err)
+			}
+			originalComponents, err := d.Data.GetComponents(common.DevfileOptions{})
+			if err != nil {
+				t.Fatalf("TestDevfileObj_Flatten() unexpected error: %v", err)
+			}
+			originalParent := d.Data.GetParent()
+
+			flattened, err := d.Flatten(ParserArgs{})
+			if err != nil {
+				t.Fatalf("TestDevfileObj_Flatten() unexpected error: %v", err)
+			}
+
+			images := map[string]string{}
+			for _, component := range flattened.Components {
+				if assert.NotNil(t, component.Container, "TestDevfileObj_Flatten(): The component %s should be a container", component.Name) {
+					images[component.Name] = component.Container.Image
+				}
+			}
+			assert.Equal(t, tt.wantImages, images, "TestDevfileObj_Flatten(): The two values should be the same.")
+			assert.Nil(t, flattened.Parent, "TestDevfileObj_Flatten(): The parent should be removed from the flattened devfile.")
+
+			components, err := d.Data.GetComponents(common.DevfileOptions{})
+			if err != nil {
+				t.Fatalf("TestDevfileObj_Flatten() unexpected error: %v", err)
+			}
+			assert.Equal(t, originalComponents, components, "TestDevfileObj_Flatten(): The devfile components should not be modified.")
+			assert.Equal(t, originalParent, d.Data.GetParent(), "TestDevfileObj_Flatten(): The devfile parent should not be modified.")
+		})
+	}
+}
+
+func TestDevfileObj_GetCommandShell(t *testing.T) {
+	const devfileContent = `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+commands:
+- id: run
+  exec:
+    commandLine: npm start
+    component: runtime
+- id: build
+  attributes:
+    library.devfile.io/shell: /bin/bash
+  exec:
+    commandLine: npm install
+    component: runtime
+`
+
+	zshShell := "/bin/zsh"
+	directShell := ""
+
+	tests := []struct {
+		name         string
+		defaultShell *string
+		commandID    string
+		wantShell    string
+	}{
+		{
+			name:      "command without shell",
+			commandID: "run",
+			wantShell: "/bin/sh",
+		},
+		{
+			name:         "command without shell and default shell",
+			defaultShell: &zshShell,
+			commandID:    "run",
+			wantShell:    "/bin/zsh",
+		},
+		{
+			name:         "command without shell and commands run directly by default",
+			defaultShell: &directShell,
+			commandID:    "run",
+			wantShell:    "",
+		},
+		{
+			name:         "command with shell and default shell",
+			defaultShell: &zshShell,
+			commandID:    "build",
+			wantShell:    "/bin/bash",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDevfile(ParserArgs{
+				Data:         []byte(devfileContent),
+				DefaultShell: tt.defaultShell,
+			})
+			if err != nil {
+				t.Errorf("TestDevfileObj_GetCommandShell(): unexpected error: %v", err)
+				return
+			}
+			shell, err := d.GetCommandShell(tt.commandID)
+			if err != nil {
+				t.Errorf("TestDevfileObj_GetCommandShell(): unexpected error: %v", err)
+				return
+			}
+			assert.Equal(t, tt.wantShell, shell, "TestDevfileObj_GetCommandShell(): The two values should be the same.")
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -33,7 +34,9 @@ import (
 	devfileCtx "github.com/devfile/library/v2/pkg/devfile/parser/context"
 	"github.com/devfile/library/v2/pkg/devfile/parser/data"
 	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/devfile/library/v2/pkg/testingutil/filesystem"
 	"github.com/devfile/library/v2/pkg/util"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog"
@@ -75,6 +78,23 @@ func parseDevfile(d DevfileObj, resolveCtx *resolutionContextTree, tool resolver
 		}
 	}
 
+	err = tool.budget.checkParsedDevfile(d)
+	if err != nil {
+		return d, err
+	}
+
+	// Decode the top-level attributes with a registered type
+	if d.Ctx.HasAttributeTypes() {
+		devfileAttributes, err := d.Data.GetAttributes()
+		if err != nil {
+			return d, err
+		}
+		err = d.Ctx.SetTypedAttributes(devfileAttributes)
+		if err != nil {
+			return d, errors.Wrapf(err, "failed to decode devfile attributes")
+		}
+	}
+
 	// Successful
 	return d, nil
 }
@@ -88,6 +108,8 @@ type ParserArgs struct {
 	URL string
 	// Data is the devfile content in []byte format.
 	Data []byte
+	// DataName is an optional name of the devfile content in Data, e.g. its filename, which identifies the devfile in the error messages.
+	DataName string
 	// FlattenedDevfile defines if the returned devfileObj is flattened content (true) or raw content (false).
 	// The value is default to be true.
 	FlattenedDevfile *bool
@@ -96,11 +118,14 @@ type ParserArgs struct {
 	ConvertKubernetesContentInUri *bool
 	// RegistryURLs is a list of registry hosts which parser should pull parent devfile from.
 	// If registryUrl is defined in devfile, this list will be ignored.
+	// The registries are tried in order, a registry which is down or does not serve the devfile is skipped for the next
+	// one, and the parse fails with the error of every registry if none of them serves the devfile.
 	RegistryURLs []string
 	// DefaultNamespace is the default namespace to use
 	// If namespace is defined under devfile's parent kubernetes object, this namespace will be ignored.
 	DefaultNamespace string
-	// Context is the context used for making Kubernetes requests
+	// Context is the context used for making Kubernetes requests and downloading the devfile URL, the parent and plugin
+	// references and the kubernetes and openshift component URIs. The parse is aborted when the context is done.
 	Context context.Context
 	// K8sClient is the Kubernetes client instance used for interacting with a cluster
 	K8sClient client.Client
@@ -108,13 +133,73 @@ type ParserArgs struct {
 	ExternalVariables map[string]string
 	// HTTPTimeout overrides the request and response timeout values for reading a parent devfile reference from the registry.  If a negative value is specified, the default timeout will be used.
 	HTTPTimeout *int
+	// AnnotateProvenance defines if the elements of the flattened devfile are annotated with the parent or plugin they
+	// come from, or "local" if they are defined in the devfile itself. The annotations are added as attributes under
+	// the reserved ProvenanceAttributePrefix namespace. The value is default to be false, and is ignored if the devfile is not flattened.
+	AnnotateProvenance *bool
+	// AttributeTypes maps devfile top-level attribute keys to a value or a pointer of the Go type the attribute is decoded into.
+	// The decoded attributes are retrievable with DevfileObj.Ctx.GetTypedAttribute().
+	AttributeTypes map[string]interface{}
+	// RedactedEnvNames are the names of the env vars whose values are replaced with *** in the errors returned by the parser.
+	// The parsed env var values are not altered.
+	RedactedEnvNames []string
+	// RequireComponents defines if parsing fails when the devfile has no components after its parent and plugins are resolved.
+	// The value is default to be false.
+	RequireComponents *bool
+	// ResourceBudget defines the limits the devfile and its parents and plugins must comply with, parsing is aborted
+	// with a BudgetExceededError as soon as a limit is exceeded. Only the DefaultMaxImports limit of the total number
+	// of imported parents and plugins is enforced by default.
+	ResourceBudget *ResourceBudget
+	// URLRewriter rewrites every remote URL before it is fetched: the devfile URL, the parent and plugin URIs and
+	// registry URLs, and the kubernetes and openshift component URIs. The original URLs are kept in the devfile and
+	// used to detect import cycles. No URL is rewritten by default.
+	URLRewriter devfileCtx.URLRewriter
+	// ParentOptional defines if a parent which cannot be fetched, e.g. its URL or registry is unavailable or does not serve it,
+	// is skipped instead of failing the parsing. The failure is recorded as a warning retrievable with DevfileObj.Ctx.GetWarnings().
+	// An exceeded resource budget, an import cycle or an invalid parent still fail the parsing. The value is default to be false.
+	ParentOptional *bool
+	// ConvertV1Devfile defines if a legacy devfile 1.x is converted to devfile 2.x instead of being rejected. The fields
+	// which cannot be converted are recorded as warnings retrievable with DevfileObj.Ctx.GetWarnings().
+	// The value is default to be false.
+	ConvertV1Devfile *bool
+	// AdditionalParents are resolved and merged in order after the parent of the devfile, the elements of a parent replace
+	// the elements of the previous parents with the same name or id. The merged parents are then merged with the devfile.
+	AdditionalParents []v1.Parent
+	// ComponentValidator is called on each component of the devfile by devfile.ParseDevfileAndValidate, after the
+	// devfile is flattened and its variables are substituted. The errors it returns fail the validation of the devfile.
+	ComponentValidator func(component v1.Component) error
+	// ErrorOnUnresolvedVariable defines if devfile.ParseDevfileAndValidate fails when the devfile references variables which
+	// are neither defined in the devfile nor in ExternalVariables. The value is default to be false, the unresolved
+	// references are then left as is and returned as a variable warning.
+	ErrorOnUnresolvedVariable *bool
+	// ContentCache caches the content downloaded from the remote URLs of the devfile and its parents and plugins,
+	// keyed by the URL the content is fetched from, digest included. The content of the authenticated downloads is not
+	// cached and the least recently used content is evicted past the cache size. It can be shared by several parses. If it is not
+	// set, the content is cached for the parse only: a URI imported N times by the devfile and its parents and plugins
+	// is downloaded once instead of N times.
+	ContentCache *devfileCtx.ContentCache
+	// DisableContentCache defines if the content downloaded from the remote URLs is not cached, ContentCache is then
+	// ignored. The value is default to be false.
+	DisableContentCache *bool
+	// SchemaCache caches the compiled devfile json schemas, it can be shared by several parses.
+	// A package-level cache shared by all the parses is used by default.
+	SchemaCache *devfileCtx.SchemaCache
+	// HTTPClient downloads the devfile URL, the parent and plugin URIs and the kubernetes and openshift component URIs.
+	// A default client is used if it is not set.
+	HTTPClient *http.Client
+	// RetryPolicy defines how the downloads of the devfile URL, the parent and plugin URIs and the kubernetes and openshift
+	// component URIs are retried when they fail with a transient error. The downloads are not retried by default.
+	RetryPolicy *devfileCtx.RetryPolicy
+	// DefaultShell is the shell of the exec commands which do not set one, returned by DevfileObj.GetCommandShell().
+	// v2.DefaultShell is used if it is not set.
+	DefaultShell *string
 }
 
 // ParseDevfile func populates the devfile data, parses and validates the devfile integrity.
 // Creates devfile context and runtime objects
 func ParseDevfile(args ParserArgs) (d DevfileObj, err error) {
 	if args.Data != nil {
-		d.Ctx, err = devfileCtx.NewByteContentDevfileCtx(args.Data)
+		d.Ctx, err = devfileCtx.NewRawDevfileCtx(args.Data, args.DataName)
 		if err != nil {
 			return d, errors.Wrap(err, "failed to set devfile content from bytes")
 		}
@@ -126,14 +211,24 @@ func ParseDevfile(args ParserArgs) (d DevfileObj, err error) {
 		return d, errors.Wrap(err, "the devfile source is not provided")
 	}
 
-	tool := resolverTools{
-		defaultNamespace: args.DefaultNamespace,
-		registryURLs:     args.RegistryURLs,
-		context:          args.Context,
-		k8sClient:        args.K8sClient,
-		httpTimeout:      args.HTTPTimeout,
+	if args.ConvertV1Devfile != nil {
+		d.Ctx.SetConvertV1(*args.ConvertV1Devfile)
 	}
 
+	if len(args.AdditionalParents) > 0 {
+		d.Ctx.SetAdditionalParents(args.AdditionalParents)
+	}
+
+	if args.DefaultShell != nil {
+		d.Ctx.SetDefaultShell(*args.DefaultShell)
+	}
+
+	for key, proto := range args.AttributeTypes {
+		d.Ctx.RegisterAttributeType(key, proto)
+	}
+
+	tool := newResolverTools(args)
+
 	flattenedDevfile := true
 	if args.FlattenedDevfile != nil {
 		flattenedDevfile = *args.FlattenedDevfile
@@ -141,8 +236,24 @@ func ParseDevfile(args ParserArgs) (d DevfileObj, err error) {
 
 	d, err = populateAndParseDevfile(d, &resolutionContextTree{}, tool, flattenedDevfile)
 	if err != nil {
+		if redactedMsg := d.Ctx.RedactEnvValues(err.Error()); redactedMsg != err.Error() {
+			err = errors.New(redactedMsg)
+		}
 		return d, errors.Wrap(err, "failed to populateAndParseDevfile")
 	}
+	if len(*tool.warnings) > 0 {
+		d.Ctx.SetWarnings(append(d.Ctx.GetWarnings(), *tool.warnings...))
+	}
+
+	if args.RequireComponents != nil && *args.RequireComponents {
+		components, err := d.Data.GetComponents(common.DevfileOptions{})
+		if err != nil {
+			return d, err
+		}
+		if len(components) == 0 {
+			return d, fmt.Errorf("the devfile has no components, at least one component is required")
+		}
+	}
 
 	//set defaults only if we are flattening parent and parsing succeeded
 	if flattenedDevfile && err == nil {
@@ -150,6 +261,10 @@ func ParseDevfile(args ParserArgs) (d DevfileObj, err error) {
 		if err != nil {
 			return d, errors.Wrap(err, "failed to setDefaults")
 		}
+
+		if args.AnnotateProvenance != nil && *args.AnnotateProvenance {
+			addProvenanceAttributes(d.Data.GetDevfileWorkspaceSpecContent())
+		}
 	}
 
 	convertUriToInlined := true
@@ -181,6 +296,51 @@ type resolverTools struct {
 	k8sClient client.Client
 	// httpTimeout is the timeout value in seconds passed in from the client.
 	httpTimeout *int
+	// redactedEnvNames are the names of the env vars whose values are redacted in the errors of the devfile and its parents and plugins
+	redactedEnvNames []string
+	// budget enforces the resource budget across the devfile and its parents and plugins
+	budget *budgetTracker
+	// urlRewriter rewrites the remote URLs of the devfile and its parents and plugins before they are fetched
+	urlRewriter devfileCtx.URLRewriter
+	// parentOptional defines if a parent which fails to be resolved is skipped with a warning instead of failing the parsing
+	parentOptional bool
+	// warnings collects the warnings raised while resolving the devfile and its parents and plugins
+	warnings *[]string
+	// contentCache caches the content downloaded from the remote URLs
+	contentCache *devfileCtx.ContentCache
+	// schemaCache caches the compiled devfile json schemas
+	schemaCache *devfileCtx.SchemaCache
+	// httpClient downloads the remote URLs
+	httpClient *http.Client
+	// retryPolicy retries the downloads of the remote URLs
+	retryPolicy *devfileCtx.RetryPolicy
+}
+
+// newResolverTools returns the tools resolving the parents and plugins of the devfile with the options of the parser args
+func newResolverTools(args ParserArgs) resolverTools {
+	tool := resolverTools{
+		defaultNamespace: args.DefaultNamespace,
+		registryURLs:     args.RegistryURLs,
+		context:          args.Context,
+		k8sClient:        args.K8sClient,
+		httpTimeout:      args.HTTPTimeout,
+		redactedEnvNames: args.RedactedEnvNames,
+		budget:           newBudgetTracker(args.ResourceBudget),
+		urlRewriter:      args.URLRewriter,
+		parentOptional:   args.ParentOptional != nil && *args.ParentOptional,
+		schemaCache:      args.SchemaCache,
+		httpClient:       args.HTTPClient,
+		retryPolicy:      args.RetryPolicy,
+		warnings:         &[]string{},
+	}
+
+	if args.DisableContentCache == nil || !*args.DisableContentCache {
+		tool.contentCache = args.ContentCache
+		if tool.contentCache == nil {
+			tool.contentCache = devfileCtx.NewContentCache()
+		}
+	}
+	return tool
 }
 
 func populateAndParseDevfile(d DevfileObj, resolveCtx *resolutionContextTree, tool resolverTools, flattenedDevfile bool) (DevfileObj, error) {
@@ -188,15 +348,48 @@ func populateAndParseDevfile(d DevfileObj, resolveCtx *resolutionContextTree, to
 	if err = resolveCtx.hasCycle(); err != nil {
 		return DevfileObj{}, err
 	}
+	if len(tool.redactedEnvNames) > 0 {
+		d.Ctx.SetRedactedEnvNames(tool.redactedEnvNames)
+	}
+	if tool.urlRewriter != nil {
+		d.Ctx.SetURLRewriter(tool.urlRewriter)
+	}
+	if tool.contentCache != nil {
+		d.Ctx.SetContentCache(tool.contentCache)
+	}
+	if tool.schemaCache != nil {
+		d.Ctx.SetSchemaCache(tool.schemaCache)
+	}
+	if tool.httpClient != nil {
+		d.Ctx.SetHTTPClient(tool.httpClient)
+	}
+	if tool.retryPolicy != nil {
+		d.Ctx.SetRetryPolicy(*tool.retryPolicy)
+	}
 	// Fill the fields of DevfileCtx struct
-	if d.Ctx.GetURL() != "" {
+	if tool.context != nil {
+		err = d.Ctx.PopulateWithContext(tool.context)
+	} else if d.Ctx.GetURL() != "" {
 		err = d.Ctx.PopulateFromURL()
-	} else if d.Ctx.GetDevfileContent() != nil {
+	} else if d.Ctx.GetDevfileSourceContent() != nil {
 		err = d.Ctx.PopulateFromRaw()
 	} else {
 		err = d.Ctx.Populate()
 	}
 	if err != nil {
+		if d.Ctx.GetDevfileSourceContent() == nil && resolveCtx.parentNode != nil {
+			// the imported devfile could not be read
+			return d, newImportUnavailableError(resolveCtx.parentNode, err)
+		}
+		return d, err
+	}
+
+	if d.Ctx.GetURL() != "" {
+		if err = tool.budget.addDownloadedBytes(len(d.Ctx.GetDevfileSourceContent())); err != nil {
+			return d, err
+		}
+	}
+	if err = tool.budget.checkPopulatedDevfile(d, resolveCtx); err != nil {
 		return d, err
 	}
 
@@ -221,6 +414,19 @@ func ParseRawDevfile(path string) (d DevfileObj, err error) {
 	return populateAndParseDevfile(d, &resolutionContextTree{}, resolverTools{}, false)
 }
 
+// ParseRawBytes parses the devfile content held in memory, the devfile is not read from the filesystem or a URL.
+// name is optional, e.g. the filename of the devfile, it identifies the devfile in the error messages. The parent
+// and plugins of the devfile are only resolved if their uri is an absolute URL.
+func ParseRawBytes(data []byte, name string) (DevfileObj, error) {
+	if len(data) == 0 {
+		return DevfileObj{}, fmt.Errorf("the devfile content is empty")
+	}
+	return ParseDevfile(ParserArgs{
+		Data:     data,
+		DataName: name,
+	})
+}
+
 // ParseFromURL func parses and validates the devfile integrity.
 // Creates devfile context and runtime objects
 // Deprecated, use ParseDevfile() instead
@@ -242,7 +448,7 @@ func ParseFromData(data []byte) (d DevfileObj, err error) {
 
 func parseParentAndPlugin(d DevfileObj, resolveCtx *resolutionContextTree, tool resolverTools) (err error) {
 	flattenedParent := &v1.DevWorkspaceTemplateSpecContent{}
-	var mainDevfileVersion, parentDevfileVerson, pluginDevfileVerson *versionpkg.Version
+	var mainDevfileVersion, pluginDevfileVerson *versionpkg.Version
 	var devfileVersion string
 	if devfileVersion = d.Ctx.GetApiVersion(); devfileVersion == "" {
 		devfileVersion = d.Data.GetSchemaVersion()
@@ -254,60 +460,31 @@ func parseParentAndPlugin(d DevfileObj, resolveCtx *resolutionContextTree, tool
 			return fmt.Errorf("fail to parse version of the main devfile")
 		}
 	}
-	parent := d.Data.GetParent()
-	if parent != nil {
-		if !reflect.DeepEqual(parent, &v1.Parent{}) {
-
-			var parentDevfileObj DevfileObj
-			switch {
-			case parent.Uri != "":
-				parentDevfileObj, err = parseFromURI(parent.ImportReference, d.Ctx, resolveCtx, tool)
-			case parent.Id != "":
-				parentDevfileObj, err = parseFromRegistry(parent.ImportReference, resolveCtx, tool)
-			case parent.Kubernetes != nil:
-				parentDevfileObj, err = parseFromKubeCRD(parent.ImportReference, resolveCtx, tool)
-			default:
-				return fmt.Errorf("devfile parent does not define any resources")
-			}
-			if err != nil {
-				return err
-			}
-			var devfileVersion string
-			if devfileVersion = parentDevfileObj.Ctx.GetApiVersion(); devfileVersion == "" {
-				devfileVersion = parentDevfileObj.Data.GetSchemaVersion()
-			}
-
-			if devfileVersion != "" {
-				parentDevfileVerson, err = versionpkg.NewVersion(devfileVersion)
-				if err != nil {
-					return fmt.Errorf("fail to parse version of parent devfile from: %v", resolveImportReference(parent.ImportReference))
-				}
-				if parentDevfileVerson.GreaterThan(mainDevfileVersion) {
-					return fmt.Errorf("the parent devfile version from %v is greater than the child devfile version from %v", resolveImportReference(parent.ImportReference), resolveImportReference(resolveCtx.importReference))
-				}
-			}
-			parentWorkspaceContent := parentDevfileObj.Data.GetDevfileWorkspaceSpecContent()
-			// add attribute to parent elements
-			err = addSourceAttributesForOverrideAndMerge(parent.ImportReference, parentWorkspaceContent)
-			if err != nil {
-				return err
-			}
-			if !reflect.DeepEqual(parent.ParentOverrides, v1.ParentOverrides{}) {
-				// add attribute to parentOverrides elements
-				curNodeImportReference := resolveCtx.importReference
-				err = addSourceAttributesForOverrideAndMerge(curNodeImportReference, &parent.ParentOverrides)
-				if err != nil {
-					return err
-				}
-				flattenedParent, err = apiOverride.OverrideDevWorkspaceTemplateSpec(parentWorkspaceContent, parent.ParentOverrides)
-				if err != nil {
-					return err
-				}
-			} else {
-				flattenedParent = parentWorkspaceContent
-			}
-
-			klog.V(4).Infof("adding data of devfile with URI: %v", parent.Uri)
+	var parents []*v1.Parent
+	if parent := d.Data.GetParent(); parent != nil && !reflect.DeepEqual(parent, &v1.Parent{}) {
+		parents = append(parents, parent)
+	}
+	for _, additionalParent := range d.Ctx.GetAdditionalParents() {
+		parents = append(parents, additionalParent.DeepCopy())
+	}
+	hasParentContent := false
+	for _, parent := range parents {
+		if err := tool.budget.addImport(); err != nil {
+			return err
+		}
+		parentContent, err := parseParent(parent, d, mainDevfileVersion, resolveCtx, tool)
+		if err != nil {
+			return err
+		}
+		// the parent content is nil if the parent is optional and failed to be resolved
+		if parentContent == nil {
+			continue
+		}
+		if hasParentContent {
+			mergeParentContent(flattenedParent, parentContent)
+		} else {
+			flattenedParent = parentContent
+			hasParentContent = true
 		}
 	}
 
@@ -318,6 +495,9 @@ func parseParentAndPlugin(d DevfileObj, resolveCtx *resolutionContextTree, tool
 	}
 	for _, component := range components {
 		if component.Plugin != nil && !reflect.DeepEqual(component.Plugin, &v1.PluginComponent{}) {
+			if err := tool.budget.addImport(); err != nil {
+				return err
+			}
 			plugin := component.Plugin
 			var pluginDevfileObj DevfileObj
 			switch {
@@ -381,6 +561,202 @@ func parseParentAndPlugin(d DevfileObj, resolveCtx *resolutionContextTree, tool
 	return nil
 }
 
+// parseParent resolves the parent of the devfile and returns its content flattened with the parent overrides.
+// It returns a nil content if the parent is optional and failed to be resolved.
+func parseParent(parent *v1.Parent, d DevfileObj, mainDevfileVersion *versionpkg.Version, resolveCtx *resolutionContextTree, tool resolverTools) (*v1.DevWorkspaceTemplateSpecContent, error) {
+	var parentDevfileObj DevfileObj
+	var err error
+	switch {
+	case parent.Uri != "":
+		parentDevfileObj, err = parseFromURI(parent.ImportReference, d.Ctx, resolveCtx, tool)
+	case parent.Id != "":
+		parentDevfileObj, err = parseFromRegistry(parent.ImportReference, resolveCtx, tool)
+	case parent.Kubernetes != nil:
+		parentDevfileObj, err = parseFromKubeCRD(parent.ImportReference, resolveCtx, tool)
+	default:
+		return nil, fmt.Errorf("devfile parent does not define any resources")
+	}
+	if err != nil {
+		// only a parent which cannot be fetched is skipped, the other failures still fail the parsing
+		var unavailableErr *importUnavailableError
+		if !tool.parentOptional || !errors.As(err, &unavailableErr) || unavailableErr.importingNode != resolveCtx {
+			return nil, err
+		}
+		tool.addWarning(&d.Ctx, fmt.Sprintf("failed to resolve the optional parent %s, the devfile is parsed without it: %v", resolveImportReference(parent.ImportReference), err))
+		return nil, nil
+	}
+
+	var devfileVersion string
+	if devfileVersion = parentDevfileObj.Ctx.GetApiVersion(); devfileVersion == "" {
+		devfileVersion = parentDevfileObj.Data.GetSchemaVersion()
+	}
+
+	if devfileVersion != "" {
+		parentDevfileVerson, err := versionpkg.NewVersion(devfileVersion)
+		if err != nil {
+			return nil, fmt.Errorf("fail to parse version of parent devfile from: %v", resolveImportReference(parent.ImportReference))
+		}
+		if parentDevfileVerson.GreaterThan(mainDevfileVersion) {
+			return nil, fmt.Errorf("the parent devfile version from %v is greater than the child devfile version from %v", resolveImportReference(parent.ImportReference), resolveImportReference(resolveCtx.importReference))
+		}
+	}
+	parentWorkspaceContent := parentDevfileObj.Data.GetDevfileWorkspaceSpecContent()
+	// add attribute to parent elements
+	err = addSourceAttributesForOverrideAndMerge(parent.ImportReference, parentWorkspaceContent)
+	if err != nil {
+		return nil, err
+	}
+	flattenedParent := parentWorkspaceContent
+	if !reflect.DeepEqual(parent.ParentOverrides, v1.ParentOverrides{}) {
+		// add attribute to parentOverrides elements
+		curNodeImportReference := resolveCtx.importReference
+		err = addSourceAttributesForOverrideAndMerge(curNodeImportReference, &parent.ParentOverrides)
+		if err != nil {
+			return nil, err
+		}
+		flattenedParent, err = apiOverride.OverrideDevWorkspaceTemplateSpec(parentWorkspaceContent, parent.ParentOverrides)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	klog.V(4).Infof("adding data of devfile with URI: %v", parent.Uri)
+	return flattenedParent, nil
+}
+
+// mergeParentContent merges the content of a parent into the content of the previous parents, the elements of the
+// parent replace the elements of the previous parents with the same name or id
+func mergeParentContent(content *v1.DevWorkspaceTemplateSpecContent, parentContent *v1.DevWorkspaceTemplateSpecContent) {
+	for _, component := range parentContent.Components {
+		replaced := false
+		for i := range content.Components {
+			if content.Components[i].Name == component.Name {
+				content.Components[i] = component
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			content.Components = append(content.Components, component)
+		}
+	}
+	for _, command := range parentContent.Commands {
+		replaced := false
+		for i := range content.Commands {
+			if strings.EqualFold(content.Commands[i].Id, command.Id) {
+				content.Commands[i] = command
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			content.Commands = append(content.Commands, command)
+		}
+	}
+	for _, project := range parentContent.Projects {
+		replaced := false
+		for i := range content.Projects {
+			if content.Projects[i].Name == project.Name {
+				content.Projects[i] = project
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			content.Projects = append(content.Projects, project)
+		}
+	}
+	for _, starterProject := range parentContent.StarterProjects {
+		replaced := false
+		for i := range content.StarterProjects {
+			if content.StarterProjects[i].Name == starterProject.Name {
+				content.StarterProjects[i] = starterProject
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			content.StarterProjects = append(content.StarterProjects, starterProject)
+		}
+	}
+	if parentContent.Events != nil {
+		if content.Events == nil {
+			content.Events = &v1.Events{}
+		}
+		content.Events.PreStart = mergeEventCommands(content.Events.PreStart, parentContent.Events.PreStart)
+		content.Events.PostStart = mergeEventCommands(content.Events.PostStart, parentContent.Events.PostStart)
+		content.Events.PreStop = mergeEventCommands(content.Events.PreStop, parentContent.Events.PreStop)
+		content.Events.PostStop = mergeEventCommands(content.Events.PostStop, parentContent.Events.PostStop)
+	}
+	for key, value := range parentContent.Variables {
+		if content.Variables == nil {
+			content.Variables = map[string]string{}
+		}
+		content.Variables[key] = value
+	}
+	for key, value := range parentContent.Attributes {
+		if content.Attributes == nil {
+			content.Attributes = attributes.Attributes{}
+		}
+		content.Attributes[key] = value
+	}
+}
+
+// mergeEventCommands appends the event commands which are not already bound to the event
+func mergeEventCommands(commands []string, parentCommands []string) []string {
+	for _, parentCommand := range parentCommands {
+		found := false
+		for _, command := range commands {
+			if strings.EqualFold(command, parentCommand) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			commands = append(commands, parentCommand)
+		}
+	}
+	return commands
+}
+
+// importUnavailableError is returned when the devfile or the resources of an import reference cannot be fetched,
+// e.g. the URL, the registry or the cluster is unavailable or does not serve the devfile
+type importUnavailableError struct {
+	// importingNode is the node of the devfile holding the import reference
+	importingNode *resolutionContextTree
+	err           error
+}
+
+func (e *importUnavailableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *importUnavailableError) Unwrap() error {
+	return e.err
+}
+
+func (e *importUnavailableError) Cause() error {
+	return e.err
+}
+
+// newImportUnavailableError wraps the error of a failed fetch of an import reference of the devfile of importingNode.
+// The errors which must abort the parsing are returned unchanged: the budget and size limits, the content not matching
+// its digest, the import cycles and the cancellation of the parse.
+func newImportUnavailableError(importingNode *resolutionContextTree, err error) error {
+	var (
+		budgetErr   *BudgetExceededError
+		tooLargeErr *util.ContentTooLargeError
+		checksumErr *util.ChecksumMismatchError
+		digestErr   *registryDigestMismatchError
+		cycleErr    *ImportCycleError
+	)
+	if errors.As(err, &budgetErr) || errors.As(err, &tooLargeErr) || errors.As(err, &checksumErr) || errors.As(err, &digestErr) ||
+		errors.As(err, &cycleErr) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return &importUnavailableError{importingNode: importingNode, err: err}
+}
+
 func parseFromURI(importReference v1.ImportReference, curDevfileCtx devfileCtx.DevfileCtx, resolveCtx *resolutionContextTree, tool resolverTools) (DevfileObj, error) {
 	uri := importReference.Uri
 	// validate URI
@@ -397,12 +773,16 @@ func parseFromURI(importReference v1.ImportReference, curDevfileCtx devfileCtx.D
 	if !absoluteURL && curDevfileCtx.GetAbsPath() != "" {
 		newUri = path.Join(path.Dir(curDevfileCtx.GetAbsPath()), uri)
 		d.Ctx = devfileCtx.NewDevfileCtx(newUri)
-		if util.ValidateFile(newUri) != nil {
-			return DevfileObj{}, fmt.Errorf("the provided path is not a valid filepath %s", newUri)
+		// the parent is read from the filesystem of the devfile referencing it
+		fs := curDevfileCtx.GetFs()
+		d.Ctx.SetFilesystem(fs)
+		if info, err := fs.Stat(newUri); err != nil || info.IsDir() {
+			return DevfileObj{}, newImportUnavailableError(resolveCtx, fmt.Errorf("the provided path is not a valid filepath %s", newUri))
 		}
 		srcDir := path.Dir(newUri)
 		destDir := path.Dir(curDevfileCtx.GetAbsPath())
-		if srcDir != destDir {
+		// the resources of the parent are only copied next to the devfile on the OS filesystem
+		if _, isDefaultFs := fs.(filesystem.DefaultFs); isDefaultFs && srcDir != destDir {
 			err := util.CopyAllDirFiles(srcDir, destDir)
 			if err != nil {
 				return DevfileObj{}, err
@@ -433,7 +813,7 @@ func parseFromURI(importReference v1.ImportReference, curDevfileCtx devfileCtx.D
 			destDir := path.Dir(curDevfileCtx.GetAbsPath())
 			err = getResourcesFromGit(urlComponents, destDir)
 			if err != nil {
-				return DevfileObj{}, err
+				return DevfileObj{}, newImportUnavailableError(resolveCtx, err)
 			}
 		}
 	}
@@ -464,14 +844,27 @@ func getResourcesFromGit(gitUrlComponents map[string]string, destDir string) err
 	return nil
 }
 
+// parseFromRegistry resolves the import reference from a devfile registry. The id can be pinned to the content digest
+// of the devfile, e.g. nodejs@sha256:<hex>, the devfile and the resources of the stack are then fetched by digest from
+// the same manifest of the stack.
 func parseFromRegistry(importReference v1.ImportReference, resolveCtx *resolutionContextTree, tool resolverTools) (d DevfileObj, err error) {
-	id := importReference.Id
+	id, digest, err := splitRegistryDigest(importReference.Id)
+	if err != nil {
+		return DevfileObj{}, err
+	}
 	registryURL := importReference.RegistryUrl
 	destDir := path.Dir(d.Ctx.GetAbsPath())
 
 	if registryURL != "" {
-		devfileContent, err := getDevfileFromRegistry(id, registryURL, importReference.Version, tool.httpTimeout)
+		fetchURL, err := tool.urlRewriter.Rewrite(registryURL)
+		if err != nil {
+			return DevfileObj{}, err
+		}
+		devfileContent, pinnedStack, err := fetchDevfileFromRegistry(tool.context, id, fetchURL, importReference.Version, digest, tool.httpTimeout)
 		if err != nil {
+			return DevfileObj{}, newImportUnavailableError(resolveCtx, err)
+		}
+		if err = tool.budget.addDownloadedBytes(len(devfileContent)); err != nil {
 			return DevfileObj{}, err
 		}
 		d.Ctx, err = devfileCtx.NewByteContentDevfileCtx(devfileContent)
@@ -480,63 +873,148 @@ func parseFromRegistry(importReference v1.ImportReference, resolveCtx *resolutio
 		}
 		newResolveCtx := resolveCtx.appendNode(importReference)
 
-		err = getResourcesFromRegistry(id, registryURL, destDir)
+		err = pullResourcesFromRegistry(id, fetchURL, pinnedStack, destDir)
 		if err != nil {
-			return DevfileObj{}, err
+			return DevfileObj{}, newImportUnavailableError(resolveCtx, err)
 		}
 
 		return populateAndParseDevfile(d, newResolveCtx, tool, true)
 
-	} else if tool.registryURLs != nil {
-		for _, registryURL := range tool.registryURLs {
-			devfileContent, err := getDevfileFromRegistry(id, registryURL, importReference.Version, tool.httpTimeout)
-			if devfileContent != nil && err == nil {
-				d.Ctx, err = devfileCtx.NewByteContentDevfileCtx(devfileContent)
-				if err != nil {
-					return d, errors.Wrap(err, "failed to set devfile content from bytes")
-				}
-				importReference.RegistryUrl = registryURL
-				newResolveCtx := resolveCtx.appendNode(importReference)
+	} else if len(tool.registryURLs) > 0 {
+		devfileContent, pinnedStack, registryURL, fetchURL, err := getDevfileFromRegistries(id, importReference.Version, digest, tool)
+		if err != nil {
+			return DevfileObj{}, newImportUnavailableError(resolveCtx, err)
+		}
+		if err = tool.budget.addDownloadedBytes(len(devfileContent)); err != nil {
+			return DevfileObj{}, err
+		}
+		d.Ctx, err = devfileCtx.NewByteContentDevfileCtx(devfileContent)
+		if err != nil {
+			return d, errors.Wrap(err, "failed to set devfile content from bytes")
+		}
+		importReference.RegistryUrl = registryURL
+		newResolveCtx := resolveCtx.appendNode(importReference)
 
-				err := getResourcesFromRegistry(id, registryURL, destDir)
-				if err != nil {
-					return DevfileObj{}, err
-				}
+		err = pullResourcesFromRegistry(id, fetchURL, pinnedStack, destDir)
+		if err != nil {
+			return DevfileObj{}, newImportUnavailableError(resolveCtx, err)
+		}
 
-				return populateAndParseDevfile(d, newResolveCtx, tool, true)
-			}
+		return populateAndParseDevfile(d, newResolveCtx, tool, true)
+	}
+
+	return DevfileObj{}, fmt.Errorf("failed to fetch from registry, registry URL is not provided")
+}
+
+// getDevfileFromRegistries downloads the devfile of the id from the registry URLs of the resolver tools. The registries
+// are tried in order and the devfile is downloaded from the first registry serving it, a registry which is down or
+// does not serve the devfile is skipped. It returns the devfile content and the pinned stack it is downloaded from if
+// digest is set, with the registry URL the devfile is downloaded from and the URL it is fetched from, or an error
+// aggregating the error of every registry if none serves the devfile. A devfile which does not match the pinned digest
+// fails the resolution without trying the next registries.
+func getDevfileFromRegistries(id, version, digest string, tool resolverTools) ([]byte, *pinnedRegistryStack, string, string, error) {
+	var registryErrors []string
+	for _, registryURL := range tool.registryURLs {
+		fetchURL, err := tool.urlRewriter.Rewrite(registryURL)
+		if err != nil {
+			return nil, nil, "", "", err
 		}
-	} else {
-		return DevfileObj{}, fmt.Errorf("failed to fetch from registry, registry URL is not provided")
+		devfileContent, pinnedStack, err := fetchDevfileFromRegistry(tool.context, id, fetchURL, version, digest, tool.httpTimeout)
+		if _, ok := err.(*registryDigestMismatchError); ok {
+			return nil, nil, "", "", err
+		}
+		if tool.context != nil && tool.context.Err() != nil {
+			return nil, nil, "", "", tool.context.Err()
+		}
+		if err != nil {
+			registryErrors = append(registryErrors, fmt.Sprintf("- %s: %v", registryURL, err))
+			continue
+		}
+		return devfileContent, pinnedStack, registryURL, fetchURL, nil
+	}
+	return nil, nil, "", "", fmt.Errorf("failed to get id: %s from registry URLs provided:\n%s", id, strings.Join(registryErrors, "\n"))
+}
+
+// addWarning records a warning raised while resolving the devfile of the context, the values of the redacted env vars
+// of the devfile are replaced in the warning before it is logged or recorded
+func (tool resolverTools) addWarning(ctx *devfileCtx.DevfileCtx, warning string) {
+	warning = ctx.RedactEnvValues(warning)
+	klog.Warning(warning)
+	if tool.warnings != nil {
+		*tool.warnings = append(*tool.warnings, warning)
 	}
+}
 
-	return DevfileObj{}, fmt.Errorf("failed to get id: %s from registry URLs provided", id)
+// getDevfileFromRegistry downloads the devfile of the id from the registry. If digest is set, the devfile is fetched
+// from the OCI repository of the stack by the digest and must match it. ctx is optional, the download is aborted when
+// it is done.
+func getDevfileFromRegistry(ctx context.Context, id, registryURL, version, digest string, httpTimeout *int) ([]byte, error) {
+	devfileContent, _, err := fetchDevfileFromRegistry(ctx, id, registryURL, version, digest, httpTimeout)
+	return devfileContent, err
 }
 
-func getDevfileFromRegistry(id, registryURL, version string, httpTimeout *int) ([]byte, error) {
+// fetchDevfileFromRegistry downloads the devfile of the id from the registry like getDevfileFromRegistry. If digest is
+// set, it also returns the pinned stack the devfile is fetched from, so the resources are pulled from the same manifest.
+func fetchDevfileFromRegistry(ctx context.Context, id, registryURL, version, digest string, httpTimeout *int) ([]byte, *pinnedRegistryStack, error) {
 	if !strings.HasPrefix(registryURL, "http://") && !strings.HasPrefix(registryURL, "https://") {
-		return nil, fmt.Errorf("the provided registryURL: %s is not a valid URL", registryURL)
+		return nil, nil, fmt.Errorf("the provided registryURL: %s is not a valid URL", registryURL)
+	}
+	if digest != "" {
+		stack, err := getPinnedRegistryStack(ctx, id, registryURL, version, digest, httpTimeout)
+		if err != nil {
+			return nil, nil, err
+		}
+		devfileContent, err := stack.getDevfile(id, registryURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		return devfileContent, stack, nil
 	}
 	param := util.HTTPRequestParams{
 		URL: fmt.Sprintf("%s/devfiles/%s/%s", registryURL, id, version),
 	}
 
 	param.Timeout = httpTimeout
+	param.Context = ctx
 	//suppress telemetry for parent uri references
 	param.TelemetryClientName = util.TelemetryIndirectDevfileCall
-	return util.HTTPGetRequest(param, 0)
+	devfileContent, err := util.HTTPGetRequest(param, 0)
+	return devfileContent, nil, err
+}
+
+// getResourcesFromRegistry pulls the resources of the stack of the id from the registry to destDir. If digest is set,
+// the resources are the layers of the stack whose devfile matches the digest and every layer is verified against its
+// own digest. ctx is optional, the download is aborted when it is done.
+func getResourcesFromRegistry(ctx context.Context, id, registryURL, version, digest, destDir string, httpTimeout *int) error {
+	var pinnedStack *pinnedRegistryStack
+	if digest != "" {
+		var err error
+		pinnedStack, err = getPinnedRegistryStack(ctx, id, registryURL, version, digest, httpTimeout)
+		if err != nil {
+			return err
+		}
+	}
+	return pullResourcesFromRegistry(id, registryURL, pinnedStack, destDir)
 }
 
-func getResourcesFromRegistry(id, registryURL, destDir string) error {
+// pullResourcesFromRegistry pulls the resources of the stack of the id from the registry to destDir. If pinnedStack is
+// set, the resources are the layers of the pinned stack and every layer is verified against its own digest.
+func pullResourcesFromRegistry(id, registryURL string, pinnedStack *pinnedRegistryStack, destDir string) error {
 	stackDir, err := ioutil.TempDir(os.TempDir(), fmt.Sprintf("registry-resources-%s", id))
 	if err != nil {
 		return fmt.Errorf("failed to create dir: %s, error: %v", stackDir, err)
 	}
 	defer os.RemoveAll(stackDir)
-	//suppress telemetry for downloading resources from parent reference
-	err = registryLibrary.PullStackFromRegistry(registryURL, id, stackDir, registryLibrary.RegistryOptions{Telemetry: registryLibrary.TelemetryData{Client: util.TelemetryIndirectDevfileCall}})
-	if err != nil {
-		return fmt.Errorf("failed to pull stack from registry %s", registryURL)
+	if pinnedStack != nil {
+		if err = pinnedStack.pullResources(stackDir); err != nil {
+			return errors.Wrapf(err, "failed to pull stack from registry %s", registryURL)
+		}
+	} else {
+		//suppress telemetry for downloading resources from parent reference
+		err = registryLibrary.PullStackFromRegistry(registryURL, id, stackDir, registryLibrary.RegistryOptions{Telemetry: registryLibrary.TelemetryData{Client: util.TelemetryIndirectDevfileCall}})
+		if err != nil {
+			return fmt.Errorf("failed to pull stack from registry %s", registryURL)
+		}
 	}
 
 	err = util.CopyAllDirFiles(stackDir, destDir)
@@ -550,39 +1028,19 @@ func getResourcesFromRegistry(id, registryURL, destDir string) error {
 func parseFromKubeCRD(importReference v1.ImportReference, resolveCtx *resolutionContextTree, tool resolverTools) (d DevfileObj, err error) {
 
 	if tool.k8sClient == nil || tool.context == nil {
-		return DevfileObj{}, fmt.Errorf("Kubernetes client and context are required to parse from Kubernetes CRD")
-	}
-	namespace := importReference.Kubernetes.Namespace
-
-	if namespace == "" {
-		// if namespace is not set in devfile, use default namespace provided in by consumer
-		if tool.defaultNamespace != "" {
-			namespace = tool.defaultNamespace
-		} else {
-			// use current namespace if namespace is not set in devfile and not provided by consumer
-			loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-			configOverrides := &clientcmd.ConfigOverrides{}
-			config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
-			namespace, _, err = config.Namespace()
-			if err != nil {
-				return DevfileObj{}, fmt.Errorf("kubernetes namespace is not provided, and cannot get current running cluster's namespace: %v", err)
-			}
-		}
-	}
-
-	var dwTemplate v1.DevWorkspaceTemplate
-	namespacedName := types.NamespacedName{
-		Name:      importReference.Kubernetes.Name,
-		Namespace: namespace,
+		return DevfileObj{}, ErrKubernetesClientNotConfigured
 	}
-	err = tool.k8sClient.Get(tool.context, namespacedName, &dwTemplate)
+	namespace, err := getKubernetesImportNamespace(*importReference.Kubernetes, tool.defaultNamespace)
 	if err != nil {
 		return DevfileObj{}, err
 	}
 
-	d, err = convertDevWorskapceTemplateToDevObj(dwTemplate)
+	d, err = ResolveKubernetesImport(tool.context, tool.k8sClient, v1.KubernetesCustomResourceImportReference{
+		Name:      importReference.Kubernetes.Name,
+		Namespace: namespace,
+	}, "")
 	if err != nil {
-		return DevfileObj{}, err
+		return DevfileObj{}, newImportUnavailableError(resolveCtx, err)
 	}
 
 	importReference.Kubernetes.Namespace = namespace
@@ -593,6 +1051,72 @@ func parseFromKubeCRD(importReference v1.ImportReference, resolveCtx *resolution
 
 }
 
+var (
+	// ErrKubernetesClientNotConfigured is returned when a kubernetes import reference is resolved without a kubernetes client
+	ErrKubernetesClientNotConfigured = errors.New("Kubernetes client and context are required to parse from Kubernetes CRD")
+	// ErrKubernetesImportNotFound is returned when the DevWorkspaceTemplate of a kubernetes import reference is not found
+	ErrKubernetesImportNotFound = errors.New("kubernetes import reference is not found")
+	// ErrKubernetesImportForbidden is returned when the kubernetes client is not allowed to get the DevWorkspaceTemplate
+	// of a kubernetes import reference
+	ErrKubernetesImportForbidden = errors.New("kubernetes import reference is forbidden")
+)
+
+// ResolveKubernetesImport fetches the DevWorkspaceTemplate of the kubernetes import reference with the kubernetes client
+// and returns its devfile content, the parent and plugins of the devfile content are not resolved. If the reference
+// does not set a namespace, defaultNamespace is used, or the namespace of the current kubernetes context if it is empty.
+// The error wraps ErrKubernetesClientNotConfigured, ErrKubernetesImportNotFound or ErrKubernetesImportForbidden when
+// the client is not set, the DevWorkspaceTemplate is not found or the client is not allowed to get it.
+func ResolveKubernetesImport(ctx context.Context, k8sClient client.Client, reference v1.KubernetesCustomResourceImportReference, defaultNamespace string) (DevfileObj, error) {
+	if k8sClient == nil {
+		return DevfileObj{}, ErrKubernetesClientNotConfigured
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	namespace, err := getKubernetesImportNamespace(reference, defaultNamespace)
+	if err != nil {
+		return DevfileObj{}, err
+	}
+
+	var dwTemplate v1.DevWorkspaceTemplate
+	namespacedName := types.NamespacedName{
+		Name:      reference.Name,
+		Namespace: namespace,
+	}
+	err = k8sClient.Get(ctx, namespacedName, &dwTemplate)
+	switch {
+	case kerrors.IsNotFound(err):
+		return DevfileObj{}, fmt.Errorf("%w: DevWorkspaceTemplate %s in the namespace %s: %v", ErrKubernetesImportNotFound, reference.Name, namespace, err)
+	case kerrors.IsForbidden(err):
+		return DevfileObj{}, fmt.Errorf("%w: DevWorkspaceTemplate %s in the namespace %s: %v", ErrKubernetesImportForbidden, reference.Name, namespace, err)
+	case err != nil:
+		return DevfileObj{}, err
+	}
+
+	return convertDevWorskapceTemplateToDevObj(dwTemplate)
+}
+
+// getKubernetesImportNamespace returns the namespace of the kubernetes import reference. If the reference does not set
+// a namespace, defaultNamespace is used, or the namespace of the current kubernetes context if it is empty.
+func getKubernetesImportNamespace(reference v1.KubernetesCustomResourceImportReference, defaultNamespace string) (string, error) {
+	if reference.Namespace != "" {
+		return reference.Namespace, nil
+	}
+	// if namespace is not set in devfile, use default namespace provided in by consumer
+	if defaultNamespace != "" {
+		return defaultNamespace, nil
+	}
+	// use current namespace if namespace is not set in devfile and not provided by consumer
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	configOverrides := &clientcmd.ConfigOverrides{}
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
+	namespace, _, err := config.Namespace()
+	if err != nil {
+		return "", fmt.Errorf("kubernetes namespace is not provided, and cannot get current running cluster's namespace: %v", err)
+	}
+	return namespace, nil
+}
+
 func convertDevWorskapceTemplateToDevObj(dwTemplate v1.DevWorkspaceTemplate) (d DevfileObj, err error) {
 	// APIVersion: group/version
 	// for example: APIVersion: "workspace.devfile.io/v1alpha2" uses api version v1alpha2, and match to v2 schemas
@@ -831,8 +1355,12 @@ func getKubernetesDefinitionFromUri(uri string, d devfileCtx.DevfileCtx) ([]byte
 			// absolute URL address
 			newUri = uri
 		}
-		params := util.HTTPRequestParams{URL: newUri}
-		data, err = util.DownloadInMemory(params)
+		fetchURL, err := d.RewriteURL(newUri)
+		if err != nil {
+			return nil, err
+		}
+		params := util.HTTPRequestParams{URL: fetchURL}
+		data, err = d.DownloadInMemory(params)
 		if err != nil {
 			return nil, errors.Wrapf(err, "error getting kubernetes resources definition info from url '%s'", newUri)
 		}
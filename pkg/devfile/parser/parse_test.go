@@ -17,6 +17,7 @@ package parser
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -26,7 +27,9 @@ import (
 	"path"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/api/v2/pkg/attributes"
@@ -36,9 +39,15 @@ import (
 	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
 	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
 	"github.com/devfile/library/v2/pkg/testingutil"
+	"github.com/devfile/library/v2/pkg/testingutil/filesystem"
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	kubev1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/yaml"
 )
 
@@ -4056,6 +4065,75 @@ func Test_parseFromRegistry(t *testing.T) {
 	}
 }
 
+func Test_getDevfileFromRegistries(t *testing.T) {
+	const registryDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+`
+	servingRegistry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/devfiles/nodejs/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := w.Write([]byte(registryDevfile)); err != nil {
+			t.Errorf("unexpected error while writing yaml: %v", err)
+		}
+	}))
+	defer servingRegistry.Close()
+	emptyRegistry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer emptyRegistry.Close()
+	downRegistry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	downRegistry.Close()
+
+	allRegistriesFailedErr := fmt.Sprintf("failed to get id: nodejs from registry URLs provided:\n- %s: .*connection refused\n- %s: failed to retrieve .*, 404: Not Found", downRegistry.URL, emptyRegistry.URL)
+
+	tests := []struct {
+		name            string
+		registryURLs    []string
+		wantRegistryURL string
+		wantErr         *string
+	}{
+		{
+			name:            "first registry serves the devfile",
+			registryURLs:    []string{servingRegistry.URL, emptyRegistry.URL},
+			wantRegistryURL: servingRegistry.URL,
+		},
+		{
+			name:            "first registry is down",
+			registryURLs:    []string{downRegistry.URL, servingRegistry.URL},
+			wantRegistryURL: servingRegistry.URL,
+		},
+		{
+			name:            "first registries are down or do not serve the devfile",
+			registryURLs:    []string{downRegistry.URL, emptyRegistry.URL, servingRegistry.URL},
+			wantRegistryURL: servingRegistry.URL,
+		},
+		{
+			name:         "no registry serves the devfile",
+			registryURLs: []string{downRegistry.URL, emptyRegistry.URL},
+			wantErr:      &allRegistriesFailedErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := resolverTools{
+				registryURLs: tt.registryURLs,
+			}
+			content, _, registryURL, _, err := getDevfileFromRegistries("nodejs", "", "", tool)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Test_getDevfileFromRegistries() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, tt.wantRegistryURL, registryURL, "Test_getDevfileFromRegistries(): The two values should be the same.")
+				assert.Equal(t, []byte(registryDevfile), content, "Test_getDevfileFromRegistries(): The two values should be the same.")
+			} else {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "Test_getDevfileFromRegistries(): Error message should match")
+			}
+		})
+	}
+}
+
 func Test_parseFromKubeCRD(t *testing.T) {
 	const (
 		namespace  = "default"
@@ -4158,6 +4236,111 @@ func Test_parseFromKubeCRD(t *testing.T) {
 	}
 }
 
+// forbiddenK8sClient is a kubernetes client which is not allowed to get any object
+type forbiddenK8sClient struct {
+	client.Client
+}
+
+func (forbiddenK8sClient) Get(_ context.Context, key client.ObjectKey, _ client.Object) error {
+	return kerrors.NewForbidden(schema.GroupResource{Group: "workspace.devfile.io", Resource: "devworkspacetemplates"}, key.Name, fmt.Errorf("access denied"))
+}
+
+func Test_resolveKubernetesImport(t *testing.T) {
+	const name = "test-parent-k8s"
+	parentSpec := v1.DevWorkspaceTemplateSpec{
+		DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+			Components: []v1.Component{
+				{
+					Name: "runtime",
+					ComponentUnion: v1.ComponentUnion{
+						Volume: &v1.VolumeComponent{
+							Volume: v1.Volume{
+								Size: "500Mi",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := v1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Test_resolveKubernetesImport() unexpected error: %v", err)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&v1.DevWorkspaceTemplate{
+		ObjectMeta: kubev1.ObjectMeta{
+			Name:      name,
+			Namespace: "devfiles",
+		},
+		Spec: parentSpec,
+	}).Build()
+
+	tests := []struct {
+		name             string
+		k8sClient        client.Client
+		reference        v1.KubernetesCustomResourceImportReference
+		defaultNamespace string
+		wantErr          error
+	}{
+		{
+			name:      "DevWorkspaceTemplate in the namespace of the reference",
+			k8sClient: fakeClient,
+			reference: v1.KubernetesCustomResourceImportReference{
+				Name:      name,
+				Namespace: "devfiles",
+			},
+			defaultNamespace: "default",
+		},
+		{
+			name:      "DevWorkspaceTemplate in the default namespace",
+			k8sClient: fakeClient,
+			reference: v1.KubernetesCustomResourceImportReference{
+				Name: name,
+			},
+			defaultNamespace: "devfiles",
+		},
+		{
+			name:      "DevWorkspaceTemplate not found",
+			k8sClient: fakeClient,
+			reference: v1.KubernetesCustomResourceImportReference{
+				Name: name,
+			},
+			defaultNamespace: "default",
+			wantErr:          ErrKubernetesImportNotFound,
+		},
+		{
+			name:      "DevWorkspaceTemplate forbidden",
+			k8sClient: forbiddenK8sClient{},
+			reference: v1.KubernetesCustomResourceImportReference{
+				Name:      name,
+				Namespace: "devfiles",
+			},
+			wantErr: ErrKubernetesImportForbidden,
+		},
+		{
+			name: "no kubernetes client",
+			reference: v1.KubernetesCustomResourceImportReference{
+				Name:      name,
+				Namespace: "devfiles",
+			},
+			wantErr: ErrKubernetesClientNotConfigured,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveKubernetesImport(context.Background(), tt.k8sClient, tt.reference, tt.defaultNamespace)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Test_resolveKubernetesImport() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				assert.Equal(t, parentSpec, *got.Data.GetDevfileWorkspaceSpec(), "Test_resolveKubernetesImport(): The two values should be the same.")
+			} else {
+				assert.True(t, errors.Is(err, tt.wantErr), "Test_resolveKubernetesImport(): The error %v should wrap %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_getResourcesFromGit(t *testing.T) {
 	destDir, err := ioutil.TempDir("", "")
 	if err != nil {
@@ -4208,6 +4391,988 @@ func Test_getResourcesFromGit(t *testing.T) {
 	}
 }
 
+func Test_parseDevfileRequireComponents(t *testing.T) {
+	devfileWithComponents := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+`
+	devfileWithoutComponents := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+`
+	noComponentsErr := "the devfile has no components, at least one component is required"
+
+	tests := []struct {
+		name              string
+		devfileContent    string
+		requireComponents *bool
+		wantErr           *string
+	}{
+		{
+			name:           "devfile without components is allowed by default",
+			devfileContent: devfileWithoutComponents,
+		},
+		{
+			name:              "devfile without components is allowed if components are not required",
+			devfileContent:    devfileWithoutComponents,
+			requireComponents: &isFalse,
+		},
+		{
+			name:              "devfile with components is allowed if components are required",
+			devfileContent:    devfileWithComponents,
+			requireComponents: &isTrue,
+		},
+		{
+			name:              "should fail if components are required and the devfile has no components",
+			devfileContent:    devfileWithoutComponents,
+			requireComponents: &isTrue,
+			wantErr:           &noComponentsErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDevfile(ParserArgs{
+				Data:              []byte(tt.devfileContent),
+				RequireComponents: tt.requireComponents,
+			})
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Test_parseDevfileRequireComponents() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "Test_parseDevfileRequireComponents(): Error message should match")
+			}
+		})
+	}
+}
+
+func Test_parseRawBytes(t *testing.T) {
+	const parentDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: parent
+components:
+- name: parent-runtime
+  container:
+    image: quay.io/nodejs-16
+`
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte(parentDevfile)); err != nil {
+			t.Errorf("unexpected error while writing yaml: %v", err)
+		}
+	}))
+	defer testServer.Close()
+
+	devfileWithParent := func(parentUri string) string {
+		return fmt.Sprintf(`schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+parent:
+  uri: %s
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+`, parentUri)
+	}
+
+	emptyContentErr := "the devfile content is empty"
+	invalidYamlErr := "failed to set the content of the devfile stored/devfile.yaml"
+	missingSchemaVersionErr := "schemaVersion not present in devfile: stored/devfile.yaml"
+	relativeParentErr := "failed to resolve parent uri, devfile context is missing absolute url and path to devfile"
+
+	tests := []struct {
+		name           string
+		devfileContent string
+		wantComponents []string
+		wantErr        *string
+	}{
+		{
+			name:           "devfile with a parent of an absolute uri",
+			devfileContent: devfileWithParent(testServer.URL + "/parent.yaml"),
+			wantComponents: []string{"parent-runtime", "runtime"},
+		},
+		{
+			name:           "devfile with a parent of a relative uri",
+			devfileContent: devfileWithParent("parent.yaml"),
+			wantErr:        &relativeParentErr,
+		},
+		{
+			name:    "empty devfile content",
+			wantErr: &emptyContentErr,
+		},
+		{
+			name:           "invalid yaml",
+			devfileContent: "schemaVersion: [2.2.0",
+			wantErr:        &invalidYamlErr,
+		},
+		{
+			name:           "devfile without schemaVersion",
+			devfileContent: "metadata:\n  name: nodejs\n",
+			wantErr:        &missingSchemaVersionErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseRawBytes([]byte(tt.devfileContent), "stored/devfile.yaml")
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Test_parseRawBytes() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				components, err := d.Data.GetComponents(common.DevfileOptions{})
+				if err != nil {
+					t.Errorf("Test_parseRawBytes() unexpected error: %v", err)
+					return
+				}
+				var componentNames []string
+				for _, component := range components {
+					componentNames = append(componentNames, component.Name)
+				}
+				assert.Equal(t, tt.wantComponents, componentNames, "Test_parseRawBytes(): The two values should be the same.")
+			} else {
+				assert.Contains(t, err.Error(), *tt.wantErr, "Test_parseRawBytes(): Error message should match")
+			}
+		})
+	}
+}
+
+func Test_parseDevfileTypedAttributes(t *testing.T) {
+	type toolExtension struct {
+		Name string `json:"name"`
+	}
+	devfileContent := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+attributes:
+  tool.io/extension:
+    name: my-tool
+`
+
+	d, err := ParseDevfile(ParserArgs{
+		Data:           []byte(devfileContent),
+		AttributeTypes: map[string]interface{}{"tool.io/extension": toolExtension{}},
+	})
+	if err != nil {
+		t.Errorf("Test_parseDevfileTypedAttributes() unexpected error: %v", err)
+		return
+	}
+
+	var extension toolExtension
+	err = d.Ctx.GetTypedAttribute("tool.io/extension", &extension)
+	if err != nil {
+		t.Errorf("Test_parseDevfileTypedAttributes() unexpected error: %v", err)
+	} else {
+		assert.Equal(t, toolExtension{Name: "my-tool"}, extension, "Test_parseDevfileTypedAttributes(): The two values should be the same.")
+	}
+}
+
+func Test_parseDevfileResourceBudget(t *testing.T) {
+	const grandParentDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: grandparent
+components:
+- name: grandparent-runtime
+  container:
+    image: quay.io/nodejs-16
+`
+	const parentDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: parent
+parent:
+  uri: /grandparent.yaml
+components:
+- name: parent-runtime
+  container:
+    image: quay.io/nodejs-16
+`
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch r.URL.Path {
+		case "/parent.yaml":
+			_, err = w.Write([]byte(parentDevfile))
+		case "/parent.yaml/grandparent.yaml":
+			_, err = w.Write([]byte(grandParentDevfile))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		if err != nil {
+			t.Errorf("unexpected error while writing yaml: %v", err)
+		}
+	}))
+	defer testServer.Close()
+
+	devfileContent := fmt.Sprintf(`schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+parent:
+  uri: %s/parent.yaml
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+commands:
+- id: run
+  exec:
+    component: runtime
+    commandLine: npm start
+- id: debug
+  exec:
+    component: runtime
+    commandLine: npm run debug
+`, testServer.URL)
+
+	tests := []struct {
+		name      string
+		budget    *ResourceBudget
+		wantLimit BudgetLimit
+	}{
+		{
+			name:   "no budget",
+			budget: nil,
+		},
+		{
+			name: "devfile within the budget",
+			budget: &ResourceBudget{
+				MaxDecodedSize:     4096,
+				MaxImportDepth:     2,
+				MaxComponents:      3,
+				MaxCommands:        2,
+				MaxDownloadedBytes: 4096,
+				MaxImports:         2,
+			},
+		},
+		{
+			name:      "devfile exceeding the decoded size",
+			budget:    &ResourceBudget{MaxDecodedSize: 100},
+			wantLimit: MaxDecodedSizeLimit,
+		},
+		{
+			name:      "devfile exceeding the import depth",
+			budget:    &ResourceBudget{MaxImportDepth: 1},
+			wantLimit: MaxImportDepthLimit,
+		},
+		{
+			name:      "flattened devfile exceeding the components",
+			budget:    &ResourceBudget{MaxComponents: 2},
+			wantLimit: MaxComponentsLimit,
+		},
+		{
+			name:      "devfile exceeding the commands",
+			budget:    &ResourceBudget{MaxCommands: 1},
+			wantLimit: MaxCommandsLimit,
+		},
+		{
+			name:      "devfile exceeding the downloaded bytes",
+			budget:    &ResourceBudget{MaxDownloadedBytes: 200},
+			wantLimit: MaxDownloadedBytesLimit,
+		},
+		{
+			name:      "devfile exceeding the imports",
+			budget:    &ResourceBudget{MaxImports: 1},
+			wantLimit: MaxImportsLimit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDevfile(ParserArgs{
+				Data:           []byte(devfileContent),
+				ResourceBudget: tt.budget,
+			})
+			if (err != nil) != (tt.wantLimit != "") {
+				t.Errorf("Test_parseDevfileResourceBudget() unexpected error: %v, wantLimit %v", err, tt.wantLimit)
+			} else if err != nil {
+				var budgetErr *BudgetExceededError
+				if assert.True(t, errors.As(err, &budgetErr), "Test_parseDevfileResourceBudget(): error should be a BudgetExceededError") {
+					assert.Equal(t, tt.wantLimit, budgetErr.Limit, "Test_parseDevfileResourceBudget(): The two values should be the same.")
+				}
+			}
+		})
+	}
+}
+
+func Test_parseDevfileMaxImports(t *testing.T) {
+	const pluginCount = 4
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// every plugin has a parent, the parents have no import
+		content := fmt.Sprintf(`schemaVersion: 2.0.0
+metadata:
+  name: plugin
+parent:
+  uri: /parent.yaml
+components:
+- name: %s-runtime
+  container:
+    image: quay.io/nodejs-16
+`, strings.TrimSuffix(path.Base(r.URL.Path), ".yaml"))
+		if strings.HasSuffix(r.URL.Path, "/parent.yaml") {
+			content = fmt.Sprintf(`schemaVersion: 2.0.0
+metadata:
+  name: parent
+components:
+- name: %s-parent-runtime
+  container:
+    image: quay.io/nodejs-16
+`, strings.TrimSuffix(path.Base(path.Dir(r.URL.Path)), ".yaml"))
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Errorf("unexpected error while writing yaml: %v", err)
+		}
+	}))
+	defer testServer.Close()
+
+	devfileContent := `schemaVersion: 2.0.0
+metadata:
+  name: nodejs
+components:
+`
+	for i := 0; i < pluginCount; i++ {
+		devfileContent += fmt.Sprintf(`- name: plugin%d
+  plugin:
+    uri: %s/plugin%d.yaml
+`, i, testServer.URL, i)
+	}
+
+	maxImportsErr := "resource budget exceeded: MaxImports is 6, it should not exceed 5"
+
+	tests := []struct {
+		name       string
+		maxImports int64
+		wantErr    *string
+	}{
+		{
+			name:       "imports within the limit",
+			maxImports: 2 * pluginCount,
+		},
+		{
+			name:       "imports exceeding the limit",
+			maxImports: 5,
+			wantErr:    &maxImportsErr,
+		},
+		{
+			name:       "disabled limit",
+			maxImports: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDevfile(ParserArgs{
+				Data:           []byte(devfileContent),
+				ResourceBudget: &ResourceBudget{MaxImports: tt.maxImports},
+			})
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Test_parseDevfileMaxImports() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				components, err := d.Data.GetComponents(common.DevfileOptions{})
+				if err != nil {
+					t.Errorf("Test_parseDevfileMaxImports() unexpected error: %v", err)
+					return
+				}
+				assert.Equal(t, 2*pluginCount, len(components), "Test_parseDevfileMaxImports(): The two values should be the same.")
+			} else {
+				assert.Contains(t, err.Error(), *tt.wantErr, "Test_parseDevfileMaxImports(): Error message should match")
+			}
+		})
+	}
+}
+
+func Test_parseDevfileURLRewriter(t *testing.T) {
+	const originalHost = "https://github.example.com"
+	const parentDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: parent
+components:
+- name: parent-runtime
+  container:
+    image: quay.io/nodejs-16
+`
+	cyclicParentDevfile := fmt.Sprintf(`schemaVersion: 2.2.0
+metadata:
+  name: cyclic-parent
+parent:
+  uri: %s/org/cyclic-parent.yaml
+`, originalHost)
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch r.URL.Path {
+		case "/mirror/org/parent.yaml":
+			_, err = w.Write([]byte(parentDevfile))
+		case "/mirror/org/cyclic-parent.yaml":
+			_, err = w.Write([]byte(cyclicParentDevfile))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		if err != nil {
+			t.Errorf("unexpected error while writing yaml: %v", err)
+		}
+	}))
+	defer testServer.Close()
+
+	mirrorRewriter := func(url string) (string, error) {
+		return strings.Replace(url, originalHost, testServer.URL+"/mirror", 1), nil
+	}
+	failingRewriter := func(url string) (string, error) {
+		return "", fmt.Errorf("no mirror configured")
+	}
+
+	devfileContent := func(parentName string) string {
+		return fmt.Sprintf(`schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+parent:
+  uri: %s/org/%s.yaml
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+`, originalHost, parentName)
+	}
+
+	cycleErr := "devfile has an cycle in references: main devfile -> uri: https://github.example.com/org/cyclic-parent.yaml -> uri: https://github.example.com/org/cyclic-parent.yaml"
+	rewriteErr := "failed to rewrite url https://github.example.com/org/parent.yaml: no mirror configured"
+
+	tests := []struct {
+		name           string
+		parentName     string
+		rewriter       devfileCtx.URLRewriter
+		wantComponents []string
+		wantErr        *string
+	}{
+		{
+			name:           "parent uri is fetched from the rewritten url",
+			parentName:     "parent",
+			rewriter:       mirrorRewriter,
+			wantComponents: []string{"parent-runtime", "runtime"},
+		},
+		{
+			name:       "cycle is detected on the original urls",
+			parentName: "cyclic-parent",
+			rewriter:   mirrorRewriter,
+			wantErr:    &cycleErr,
+		},
+		{
+			name:       "url rewriter error is returned",
+			parentName: "parent",
+			rewriter:   failingRewriter,
+			wantErr:    &rewriteErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDevfile(ParserArgs{
+				Data:        []byte(devfileContent(tt.parentName)),
+				URLRewriter: tt.rewriter,
+			})
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Test_parseDevfileURLRewriter() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				components, err := d.Data.GetComponents(common.DevfileOptions{})
+				if err != nil {
+					t.Errorf("Test_parseDevfileURLRewriter() unexpected error: %v", err)
+					return
+				}
+				var componentNames []string
+				for _, component := range components {
+					componentNames = append(componentNames, component.Name)
+				}
+				assert.Equal(t, tt.wantComponents, componentNames, "Test_parseDevfileURLRewriter(): The two values should be the same.")
+			} else {
+				assert.Contains(t, err.Error(), *tt.wantErr, "Test_parseDevfileURLRewriter(): Error message should match")
+			}
+		})
+	}
+}
+
+func Test_parseDevfileContext(t *testing.T) {
+	release := make(chan struct{})
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// hold the download of the parent until the test is done
+		<-release
+	}))
+	defer testServer.Close()
+	defer close(release)
+
+	devfileContent := fmt.Sprintf(`schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+parent:
+  uri: %s/parent.yaml
+`, testServer.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := ParseDevfile(ParserArgs{
+		Data:    []byte(devfileContent),
+		Context: ctx,
+	})
+	if err == nil {
+		t.Fatalf("Test_parseDevfileContext() expected an error, didn't get one")
+	}
+	assert.Contains(t, err.Error(), context.Canceled.Error(), "Test_parseDevfileContext(): Error message should match")
+	assert.Less(t, int64(time.Since(start)), int64(time.Second), "Test_parseDevfileContext(): The parse should be aborted when the context is cancelled.")
+}
+
+func Test_parseDevfileParentOptional(t *testing.T) {
+	const parentDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: parent
+components:
+- name: parent-runtime
+  container:
+    image: quay.io/nodejs-16
+`
+	const invalidParentDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: parent
+components:
+- name: parent-runtime
+`
+	var testServer *httptest.Server
+	testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var content string
+		switch r.URL.Path {
+		case "/parent.yaml":
+			content = parentDevfile
+		case "/invalid.yaml":
+			content = invalidParentDevfile
+		case "/cycle.yaml":
+			content = fmt.Sprintf("schemaVersion: 2.2.0\nmetadata:\n  name: cycle\nparent:\n  uri: %s/cycle.yaml\n", testServer.URL)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Errorf("unexpected error while writing yaml: %v", err)
+		}
+	}))
+	defer testServer.Close()
+
+	devfileContent := func(parentPath string) string {
+		return fmt.Sprintf(`schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+parent:
+  uri: %s%s
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+    env:
+    - name: PARENT_FILE
+      value: missing.yaml
+`, testServer.URL, parentPath)
+	}
+
+	missingParentErr := "failed to populateAndParseDevfile: error getting devfile info from url"
+	missingParentWarning := fmt.Sprintf("failed to resolve the optional parent uri: %s/missing.yaml, the devfile is parsed without it", testServer.URL)
+	redactedParentWarning := fmt.Sprintf("failed to resolve the optional parent uri: %s/***, the devfile is parsed without it", testServer.URL)
+	budgetErr := "resource budget exceeded: MaxDownloadedBytes is"
+	cycleErr := "devfile has an cycle in references"
+	invalidParentErr := "invalid devfile schema"
+
+	tests := []struct {
+		name             string
+		parentPath       string
+		parentOptional   *bool
+		redactedEnvNames []string
+		budget           *ResourceBudget
+		wantComponents   []string
+		wantWarnings     []string
+		wantErr          *string
+	}{
+		{
+			name:           "resolved optional parent",
+			parentPath:     "/parent.yaml",
+			parentOptional: &isTrue,
+			wantComponents: []string{"parent-runtime", "runtime"},
+		},
+		{
+			name:           "missing optional parent",
+			parentPath:     "/missing.yaml",
+			parentOptional: &isTrue,
+			wantComponents: []string{"runtime"},
+			wantWarnings:   []string{missingParentWarning},
+		},
+		{
+			name:             "missing optional parent with redacted env values",
+			parentPath:       "/missing.yaml",
+			parentOptional:   &isTrue,
+			redactedEnvNames: []string{"PARENT_FILE"},
+			wantComponents:   []string{"runtime"},
+			wantWarnings:     []string{redactedParentWarning},
+		},
+		{
+			name:       "missing parent",
+			parentPath: "/missing.yaml",
+			wantErr:    &missingParentErr,
+		},
+		{
+			name:           "optional parent exceeding the resource budget",
+			parentPath:     "/parent.yaml",
+			parentOptional: &isTrue,
+			budget:         &ResourceBudget{MaxDownloadedBytes: 10},
+			wantErr:        &budgetErr,
+		},
+		{
+			name:           "optional parent with an import cycle",
+			parentPath:     "/cycle.yaml",
+			parentOptional: &isTrue,
+			wantErr:        &cycleErr,
+		},
+		{
+			name:           "invalid optional parent",
+			parentPath:     "/invalid.yaml",
+			parentOptional: &isTrue,
+			wantErr:        &invalidParentErr,
+		},
+		{
+			name:           "missing parent which is not optional",
+			parentPath:     "/missing.yaml",
+			parentOptional: &isFalse,
+			wantErr:        &missingParentErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDevfile(ParserArgs{
+				Data:             []byte(devfileContent(tt.parentPath)),
+				ParentOptional:   tt.parentOptional,
+				RedactedEnvNames: tt.redactedEnvNames,
+				ResourceBudget:   tt.budget,
+			})
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Test_parseDevfileParentOptional() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				components, err := d.Data.GetComponents(common.DevfileOptions{})
+				if err != nil {
+					t.Errorf("Test_parseDevfileParentOptional() unexpected error: %v", err)
+					return
+				}
+				var componentNames []string
+				for _, component := range components {
+					componentNames = append(componentNames, component.Name)
+				}
+				assert.Equal(t, tt.wantComponents, componentNames, "Test_parseDevfileParentOptional(): The two values should be the same.")
+				assert.Nil(t, d.Data.GetParent(), "Test_parseDevfileParentOptional(): The parent should be removed from the flattened devfile.")
+
+				warnings := d.Ctx.GetWarnings()
+				if assert.Equal(t, len(tt.wantWarnings), len(warnings), "Test_parseDevfileParentOptional(): The two values should be the same.") {
+					for i := range warnings {
+						assert.Contains(t, warnings[i], tt.wantWarnings[i], "Test_parseDevfileParentOptional(): Warning message should match")
+					}
+				}
+			} else {
+				assert.Contains(t, err.Error(), *tt.wantErr, "Test_parseDevfileParentOptional(): Error message should match")
+			}
+		})
+	}
+}
+
+func Test_parseDevfileAdditionalParents(t *testing.T) {
+	const baseParent = `schemaVersion: 2.2.0
+metadata:
+  name: base
+variables:
+  version: "14"
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-14
+- name: cache
+  volume:
+    size: 1Gi
+`
+	const overrideParent = `schemaVersion: 2.2.0
+metadata:
+  name: override
+variables:
+  version: "16"
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+- name: tools
+  container:
+    image: quay.io/tools
+`
+	var testServer *httptest.Server
+	testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var content string
+		switch r.URL.Path {
+		case "/base.yaml":
+			content = baseParent
+		case "/override.yaml":
+			content = overrideParent
+		case "/cycle-a.yaml", "/cycle-b.yaml":
+			next := "/cycle-b.yaml"
+			if r.URL.Path == "/cycle-b.yaml" {
+				next = "/cycle-a.yaml"
+			}
+			content = fmt.Sprintf("schemaVersion: 2.2.0\nparent:\n  uri: %s%s\n", testServer.URL, next)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Errorf("unexpected error while writing yaml: %v", err)
+		}
+	}))
+	defer testServer.Close()
+
+	const devfileContent = `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+components:
+- name: app
+  container:
+    image: quay.io/app
+`
+	cycleErr := "devfile has an cycle in references"
+
+	tests := []struct {
+		name              string
+		additionalParents []string
+		wantComponents    map[string]string
+		wantVersion       string
+		wantErr           *string
+	}{
+		{
+			name:              "single additional parent",
+			additionalParents: []string{"/base.yaml"},
+			wantComponents:    map[string]string{"runtime": "quay.io/nodejs-14", "app": "quay.io/app"},
+			wantVersion:       "14",
+		},
+		{
+			name:              "later parent overrides the earlier parent",
+			additionalParents: []string{"/base.yaml", "/override.yaml"},
+			wantComponents:    map[string]string{"runtime": "quay.io/nodejs-16", "tools": "quay.io/tools", "app": "quay.io/app"},
+			wantVersion:       "16",
+		},
+		{
+			name:              "cycle across the additional parents",
+			additionalParents: []string{"/base.yaml", "/cycle-a.yaml"},
+			wantErr:           &cycleErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parents []v1.Parent
+			for _, path := range tt.additionalParents {
+				parents = append(parents, v1.Parent{
+					ImportReference: v1.ImportReference{
+						ImportReferenceUnion: v1.ImportReferenceUnion{
+							Uri: testServer.URL + path,
+						},
+					},
+				})
+			}
+			d, err := ParseDevfile(ParserArgs{
+				Data:              []byte(devfileContent),
+				AdditionalParents: parents,
+			})
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Test_parseDevfileAdditionalParents() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil {
+				components, err := d.Data.GetComponents(common.DevfileOptions{
+					ComponentOptions: common.ComponentOptions{
+						ComponentType: v1.ContainerComponentType,
+					},
+				})
+				if err != nil {
+					t.Errorf("Test_parseDevfileAdditionalParents() unexpected error: %v", err)
+					return
+				}
+				gotComponents := map[string]string{}
+				for _, component := range components {
+					gotComponents[component.Name] = component.Container.Image
+				}
+				assert.Equal(t, tt.wantComponents, gotComponents, "Test_parseDevfileAdditionalParents(): The two values should be the same.")
+				assert.Equal(t, tt.wantVersion, d.Data.GetDevfileWorkspaceSpecContent().Variables["version"], "Test_parseDevfileAdditionalParents(): The two values should be the same.")
+			} else {
+				assert.Contains(t, err.Error(), *tt.wantErr, "Test_parseDevfileAdditionalParents(): Error message should match")
+			}
+		})
+	}
+}
+
+func Test_parseDevfileImportCycleChain(t *testing.T) {
+	var testServer *httptest.Server
+	testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parents := map[string]string{
+			"/a.yaml": "/b.yaml",
+			"/b.yaml": "/c.yaml",
+			"/c.yaml": "/a.yaml",
+		}
+		parent, ok := parents[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := w.Write([]byte(fmt.Sprintf("schemaVersion: 2.2.0\nparent:\n  uri: %s%s\n", testServer.URL, parent))); err != nil {
+			t.Errorf("unexpected error while writing yaml: %v", err)
+		}
+	}))
+	defer testServer.Close()
+
+	devfileContent := fmt.Sprintf(`schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+parent:
+  uri: %s/a.yaml
+`, testServer.URL)
+
+	_, err := ParseDevfile(ParserArgs{
+		Data: []byte(devfileContent),
+	})
+	if err == nil {
+		t.Fatalf("Test_parseDevfileImportCycleChain() expected an error, didn't get one")
+	}
+
+	wantChain := []string{
+		"main devfile",
+		fmt.Sprintf("uri: %s/a.yaml", testServer.URL),
+		fmt.Sprintf("uri: %s/b.yaml", testServer.URL),
+		fmt.Sprintf("uri: %s/c.yaml", testServer.URL),
+		fmt.Sprintf("uri: %s/a.yaml", testServer.URL),
+	}
+	var cycleErr *ImportCycleError
+	if assert.True(t, errors.As(err, &cycleErr), "Test_parseDevfileImportCycleChain(): error should be an ImportCycleError") {
+		assert.Equal(t, wantChain, cycleErr.Chain, "Test_parseDevfileImportCycleChain(): The two values should be the same.")
+		assert.Equal(t, 4, cycleErr.Depth(), "Test_parseDevfileImportCycleChain(): The two values should be the same.")
+	}
+	assert.Contains(t, err.Error(), "devfile has an cycle in references: "+strings.Join(wantChain, " -> "), "Test_parseDevfileImportCycleChain(): Error message should match")
+}
+
+func Test_parseDevfileContentCache(t *testing.T) {
+	const deployment = `kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: my-deployment
+`
+	var hits int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/deploy.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		atomic.AddInt32(&hits, 1)
+		if _, err := w.Write([]byte(deployment)); err != nil {
+			t.Errorf("unexpected error while writing yaml: %v", err)
+		}
+	}))
+	defer testServer.Close()
+
+	const imports = 3
+	devfileContent := "schemaVersion: 2.2.0\nmetadata:\n  name: nodejs\ncomponents:\n"
+	for i := 0; i < imports; i++ {
+		devfileContent += fmt.Sprintf("- name: deploy-%d\n  kubernetes:\n    uri: %s/deploy.yaml\n", i, testServer.URL)
+	}
+
+	tests := []struct {
+		name                string
+		contentCache        *devfileCtx.ContentCache
+		disableContentCache *bool
+		wantHits            int32
+	}{
+		{
+			name:     "identical imports are downloaded once per parse",
+			wantHits: 1,
+		},
+		{
+			name:                "identical imports are downloaded each time if the cache is disabled",
+			disableContentCache: &isTrue,
+			wantHits:            imports,
+		},
+		{
+			name:         "identical imports are read from the shared cache",
+			contentCache: devfileCtx.NewContentCache(),
+			wantHits:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&hits, 0)
+			for parse := 0; parse < 2; parse++ {
+				d, err := ParseDevfile(ParserArgs{
+					Data:                []byte(devfileContent),
+					ContentCache:        tt.contentCache,
+					DisableContentCache: tt.disableContentCache,
+				})
+				if err != nil {
+					t.Fatalf("Test_parseDevfileContentCache() unexpected error: %v", err)
+				}
+				components, err := d.Data.GetComponents(common.DevfileOptions{})
+				if err != nil {
+					t.Fatalf("Test_parseDevfileContentCache() unexpected error: %v", err)
+				}
+				for _, component := range components {
+					assert.Equal(t, deployment, component.Kubernetes.Inlined, "Test_parseDevfileContentCache(): The two values should be the same.")
+				}
+			}
+			// the devfile is parsed twice, only a shared cache is reused by the second parse
+			wantHits := tt.wantHits * 2
+			if tt.contentCache != nil {
+				wantHits = tt.wantHits
+			}
+			assert.Equal(t, wantHits, atomic.LoadInt32(&hits), "Test_parseDevfileContentCache(): The two values should be the same.")
+		})
+	}
+}
+
+func Test_parseDevfileFilesystem(t *testing.T) {
+	// the devfiles only exist on the fake filesystem, parsing them fails if the OS filesystem is used
+	const devfileDir = "/devfile-library-fake-fs/nodejs"
+	fs := filesystem.NewFakeFs()
+	files := map[string]string{
+		devfileDir + "/devfile.yaml": `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+parent:
+  uri: parent/devfile.yaml
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+`,
+		devfileDir + "/parent/devfile.yaml": `schemaVersion: 2.2.0
+metadata:
+  name: parent
+components:
+- name: parent-runtime
+  container:
+    image: quay.io/nodejs-16
+`,
+	}
+	for name, content := range files {
+		if err := fs.MkdirAll(path.Dir(name), 0755); err != nil {
+			t.Fatalf("Test_parseDevfileFilesystem() unexpected error: %v", err)
+		}
+		if err := fs.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatalf("Test_parseDevfileFilesystem() unexpected error: %v", err)
+		}
+	}
+
+	d := DevfileObj{Ctx: devfileCtx.NewDevfileCtx(devfileDir + "/devfile.yaml")}
+	d.Ctx.SetFilesystem(fs)
+	d, err := populateAndParseDevfile(d, &resolutionContextTree{}, newResolverTools(ParserArgs{}), true)
+	if err != nil {
+		t.Fatalf("Test_parseDevfileFilesystem() unexpected error: %v", err)
+	}
+	components, err := d.Data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		t.Fatalf("Test_parseDevfileFilesystem() unexpected error: %v", err)
+	}
+	var names []string
+	for _, component := range components {
+		names = append(names, component.Name)
+	}
+	assert.ElementsMatch(t, []string{"runtime", "parent-runtime"}, names, "Test_parseDevfileFilesystem(): The two values should be the same.")
+	if _, err := os.Stat(devfileDir); !os.IsNotExist(err) {
+		t.Errorf("Test_parseDevfileFilesystem() the OS filesystem should not be written to, got: %v", err)
+	}
+}
+
 func Test_setDefaults(t *testing.T) {
 	type testType struct {
 		name        string
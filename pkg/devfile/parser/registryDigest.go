@@ -0,0 +1,278 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/devfile/library/v2/pkg/util"
+	registryLibrary "github.com/devfile/registry-support/registry-library/library"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ociImageManifestMediaType is the media type of the OCI manifest of a registry stack
+	ociImageManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	// ociImageTitleAnnotation is the layer annotation holding the file name of the layer
+	ociImageTitleAnnotation = "org.opencontainers.image.title"
+	// registryArchiveFile is the layer of a registry stack holding the archived resources of the stack
+	registryArchiveFile = "archive.tar"
+)
+
+// registryDigestPattern is the pattern of the digest a registry id is pinned to
+var registryDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// splitRegistryDigest splits a registry id pinned to a content digest, e.g. nodejs@sha256:<hex>, into the id and the
+// digest. The digest is empty if the id is not pinned.
+func splitRegistryDigest(id string) (string, string, error) {
+	i := strings.LastIndex(id, "@")
+	if i == -1 {
+		return id, "", nil
+	}
+	digest := id[i+1:]
+	if !registryDigestPattern.MatchString(digest) {
+		return "", "", fmt.Errorf("invalid digest %s of the registry id %s, it should be sha256: followed by 64 lowercase hexadecimal characters", digest, id)
+	}
+	return id[:i], digest, nil
+}
+
+// registryDigestMismatchError is returned when the devfile served by a registry does not match the pinned digest
+type registryDigestMismatchError struct {
+	id           string
+	registryURL  string
+	digest       string
+	servedDigest string
+}
+
+func (e *registryDigestMismatchError) Error() string {
+	return fmt.Sprintf("the devfile of the id %s served by the registry %s has the digest %s, which does not match the pinned digest %s", e.id, e.registryURL, e.servedDigest, e.digest)
+}
+
+// registryStackManifest is the OCI manifest of a registry stack, only its layers are read
+type registryStackManifest struct {
+	Layers []registryStackLayer `json:"layers"`
+}
+
+// registryStackLayer is a layer of a registry stack, the file name of the layer is set in its title annotation
+type registryStackLayer struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// pinnedRegistryStack is the stack of a registry id pinned to the digest of its devfile
+type pinnedRegistryStack struct {
+	// blobsURL is the URL of the blobs of the OCI repository of the stack
+	blobsURL    string
+	manifest    registryStackManifest
+	httpTimeout *int
+	ctx         context.Context
+}
+
+// getPinnedRegistryStack looks up the OCI manifest of the stack of the id in the registry. The devfile layer of the
+// manifest must be the pinned digest, the layers of the stack are then fetched by their digest and verified against it.
+// ctx is optional, the downloads are aborted when it is done.
+func getPinnedRegistryStack(ctx context.Context, id, registryURL, version, digest string, httpTimeout *int) (*pinnedRegistryStack, error) {
+	stack := id
+	//suppress telemetry for parent uri references
+	options := registryLibrary.RegistryOptions{Telemetry: registryLibrary.TelemetryData{Client: util.TelemetryIndirectDevfileCall}}
+	if version != "" {
+		stack = fmt.Sprintf("%s:%s", id, version)
+		options.NewIndexSchema = true
+	}
+	stackLink, err := registryLibrary.GetStackLink(registryURL, stack, options)
+	if err != nil {
+		return nil, err
+	}
+	repository, tag := stackLink, "latest"
+	if i := strings.LastIndex(stackLink, ":"); i > strings.LastIndex(stackLink, "/") {
+		repository, tag = stackLink[:i], stackLink[i+1:]
+	}
+	urlObj, err := url.Parse(registryURL)
+	if err != nil {
+		return nil, err
+	}
+	repositoryURL := fmt.Sprintf("%s://%s/v2/%s", urlObj.Scheme, urlObj.Host, repository)
+
+	manifestContent, err := util.HTTPGetRequest(util.HTTPRequestParams{
+		URL:                 fmt.Sprintf("%s/manifests/%s", repositoryURL, tag),
+		Headers:             map[string]string{"Accept": ociImageManifestMediaType},
+		Timeout:             httpTimeout,
+		Context:             ctx,
+		TelemetryClientName: util.TelemetryIndirectDevfileCall,
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	pinned := &pinnedRegistryStack{blobsURL: repositoryURL + "/blobs", httpTimeout: httpTimeout, ctx: ctx}
+	if err = json.Unmarshal(manifestContent, &pinned.manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the manifest of the stack %s of the registry %s", stackLink, registryURL)
+	}
+	devfileLayer, err := pinned.devfileLayer()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid manifest of the stack %s of the registry %s", stackLink, registryURL)
+	}
+	if devfileLayer.Digest != digest {
+		return nil, &registryDigestMismatchError{id: id, registryURL: registryURL, digest: digest, servedDigest: devfileLayer.Digest}
+	}
+	return pinned, nil
+}
+
+// devfileLayer returns the devfile layer of the stack
+func (s *pinnedRegistryStack) devfileLayer() (registryStackLayer, error) {
+	for _, layer := range s.manifest.Layers {
+		if layer.MediaType == registryLibrary.DevfileMediaType {
+			return layer, nil
+		}
+	}
+	return registryStackLayer{}, fmt.Errorf("no layer of the media type %s found", registryLibrary.DevfileMediaType)
+}
+
+// getLayer downloads the blob of the layer, the content must match the digest of the layer
+func (s *pinnedRegistryStack) getLayer(layer registryStackLayer) ([]byte, error) {
+	if !registryDigestPattern.MatchString(layer.Digest) {
+		return nil, fmt.Errorf("invalid digest %s of the layer %s of the stack", layer.Digest, layer.Annotations[ociImageTitleAnnotation])
+	}
+	return util.DownloadInMemoryWithChecksum(util.HTTPRequestParams{
+		URL:                 fmt.Sprintf("%s/%s", s.blobsURL, layer.Digest),
+		Timeout:             s.httpTimeout,
+		Context:             s.ctx,
+		TelemetryClientName: util.TelemetryIndirectDevfileCall,
+	}, layer.Digest)
+}
+
+// getDevfile downloads the devfile of the stack by its pinned digest
+func (s *pinnedRegistryStack) getDevfile(id, registryURL string) ([]byte, error) {
+	devfileLayer, err := s.devfileLayer()
+	if err != nil {
+		return nil, err
+	}
+	devfileContent, err := s.getLayer(devfileLayer)
+	var checksumErr *util.ChecksumMismatchError
+	if errors.As(err, &checksumErr) {
+		return nil, &registryDigestMismatchError{id: id, registryURL: registryURL, digest: devfileLayer.Digest, servedDigest: "sha256:" + checksumErr.Actual}
+	}
+	return devfileContent, err
+}
+
+// pullResources downloads the resource layers of the stack to destDir, every layer is verified against its digest.
+// The archived resources of the stack are extracted, excluding the OWNERS files like the registry library does.
+func (s *pinnedRegistryStack) pullResources(destDir string) error {
+	for _, layer := range s.manifest.Layers {
+		if !isAllowedRegistryMediaType(layer.MediaType) {
+			continue
+		}
+		name := layer.Annotations[ociImageTitleAnnotation]
+		if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+			return fmt.Errorf("invalid file name %q of the layer %s of the stack", name, layer.Digest)
+		}
+		if isExcludedRegistryFile(name) {
+			continue
+		}
+		content, err := s.getLayer(layer)
+		if err != nil {
+			return err
+		}
+		if name == registryArchiveFile {
+			err = extractRegistryArchive(content, destDir)
+		} else {
+			err = ioutil.WriteFile(filepath.Join(destDir, name), content, 0600)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractRegistryArchive extracts the gzipped tar archive of the resources of a stack to destDir
+func extractRegistryArchive(content []byte, destDir string) error {
+	gzReader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", registryArchiveFile)
+	}
+	defer gzReader.Close()
+
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrapf(err, "failed to read %s", registryArchiveFile)
+		}
+		if isExcludedRegistryFile(header.Name) {
+			continue
+		}
+		target := filepath.Join(destDir, filepath.Clean(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("%s: illegal file path in %s", header.Name, registryArchiveFile)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, os.ModePerm); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+				return err
+			}
+			/* #nosec G304 -- target is checked to be within destDir */
+			w, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR|os.O_TRUNC, os.FileMode(header.Mode).Perm())
+			if err != nil {
+				return err
+			}
+			/* #nosec G110 -- the archive is verified against the digest of its layer */
+			_, err = io.Copy(w, tarReader)
+			if closeErr := w.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func isAllowedRegistryMediaType(mediaType string) bool {
+	for _, allowed := range registryLibrary.DevfileAllMediaTypesList {
+		if mediaType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func isExcludedRegistryFile(name string) bool {
+	for _, excluded := range registryLibrary.ExcludedFiles {
+		if filepath.Base(name) == excluded {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,313 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	registryLibrary "github.com/devfile/registry-support/registry-library/library"
+	"github.com/stretchr/testify/assert"
+)
+
+const registryDigestDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+`
+
+func contentDigest(content []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+}
+
+// testRegistryArchive returns a gzipped tar archive of the files
+func testRegistryArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gzWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzWriter)
+	for name, content := range files {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("unexpected error while writing the archive: %v", err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatalf("unexpected error while writing the archive: %v", err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatalf("unexpected error while writing the archive: %v", err)
+	}
+	if err := gzWriter.Close(); err != nil {
+		t.Fatalf("unexpected error while writing the archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// newTestOCIRegistry serves the stack nodejs, tagged 1.0.0, with the layers. The blobs are served by digest.
+func newTestOCIRegistry(t *testing.T, layers []registryStackLayer, blobs map[string][]byte) *httptest.Server {
+	index := `[{"name":"nodejs","links":{"self":"devfile-catalog/nodejs:1.0.0"},"versions":[{"version":"1.0.0","default":true,"links":{"self":"devfile-catalog/nodejs:1.0.0"}}]}]`
+	manifest, err := json.Marshal(registryStackManifest{Layers: layers})
+	if err != nil {
+		t.Fatalf("unexpected error while writing the manifest: %v", err)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var content []byte
+		switch {
+		case r.URL.Path == "/index" || r.URL.Path == "/v2index":
+			content = []byte(index)
+		case r.URL.Path == "/devfiles/nodejs/latest":
+			content = []byte(registryDigestDevfile)
+		case r.URL.Path == "/v2/devfile-catalog/nodejs/manifests/1.0.0" && r.Header.Get("Accept") == ociImageManifestMediaType:
+			content = manifest
+		case strings.HasPrefix(r.URL.Path, "/v2/devfile-catalog/nodejs/blobs/"):
+			blob, ok := blobs[strings.TrimPrefix(r.URL.Path, "/v2/devfile-catalog/nodejs/blobs/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			content = blob
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := w.Write(content); err != nil {
+			t.Errorf("unexpected error while writing the response: %v", err)
+		}
+	}))
+}
+
+func Test_parseFromRegistryDigest(t *testing.T) {
+	servedDigest := contentDigest([]byte(registryDigestDevfile))
+	otherDigest := contentDigest([]byte("other content"))
+	layers := []registryStackLayer{
+		{
+			MediaType:   registryLibrary.DevfileMediaType,
+			Digest:      servedDigest,
+			Annotations: map[string]string{ociImageTitleAnnotation: "devfile.yaml"},
+		},
+	}
+
+	testServer := newTestOCIRegistry(t, layers, map[string][]byte{servedDigest: []byte(registryDigestDevfile)})
+	defer testServer.Close()
+	tamperedServer := newTestOCIRegistry(t, layers, map[string][]byte{servedDigest: []byte("other content")})
+	defer tamperedServer.Close()
+
+	mismatchErr := fmt.Sprintf("the devfile of the id nodejs served by the registry %s has the digest %s, which does not match the pinned digest %s", testServer.URL, servedDigest, otherDigest)
+	tamperedErr := fmt.Sprintf("the devfile of the id nodejs served by the registry %s has the digest %s, which does not match the pinned digest %s", tamperedServer.URL, otherDigest, servedDigest)
+	invalidDigestErr := "invalid digest sha256:1234 of the registry id nodejs@sha256:1234, it should be sha256: followed by 64 lowercase hexadecimal characters"
+
+	tests := []struct {
+		name        string
+		id          string
+		registryURL string
+		wantContent []byte
+		wantErr     *string
+	}{
+		{
+			name:        "registry id which is not pinned",
+			id:          "nodejs",
+			registryURL: testServer.URL,
+			wantContent: []byte(registryDigestDevfile),
+		},
+		{
+			name:        "registry id pinned to the served digest",
+			id:          "nodejs@" + servedDigest,
+			registryURL: testServer.URL,
+			wantContent: []byte(registryDigestDevfile),
+		},
+		{
+			name:        "registry id pinned to another digest",
+			id:          "nodejs@" + otherDigest,
+			registryURL: testServer.URL,
+			wantErr:     &mismatchErr,
+		},
+		{
+			name:        "registry serving a devfile which does not match the digest of its layer",
+			id:          "nodejs@" + servedDigest,
+			registryURL: tamperedServer.URL,
+			wantErr:     &tamperedErr,
+		},
+		{
+			name:        "registry id pinned to an invalid digest",
+			id:          "nodejs@sha256:1234",
+			registryURL: testServer.URL,
+			wantErr:     &invalidDigestErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, digest, err := splitRegistryDigest(tt.id)
+			if err == nil {
+				var content []byte
+				content, err = getDevfileFromRegistry(nil, id, tt.registryURL, "latest", digest, nil)
+				if err == nil {
+					assert.Equal(t, tt.wantContent, content, "Test_parseFromRegistryDigest(): The two values should be the same.")
+				}
+			}
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Test_parseFromRegistryDigest() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				assert.Equal(t, *tt.wantErr, err.Error(), "Test_parseFromRegistryDigest(): Error message should match")
+			}
+		})
+	}
+
+	t.Run("mismatching digest is not resolved from the next registry", func(t *testing.T) {
+		importReference := v1.ImportReference{
+			ImportReferenceUnion: v1.ImportReferenceUnion{
+				Id: "nodejs@" + otherDigest,
+			},
+			Version: "latest",
+		}
+		tool := resolverTools{
+			registryURLs: []string{testServer.URL, testServer.URL},
+		}
+		_, err := parseFromRegistry(importReference, &resolutionContextTree{}, tool)
+		if err == nil {
+			t.Errorf("Test_parseFromRegistryDigest() expected an error, didn't get one")
+		} else {
+			assert.Equal(t, mismatchErr, err.Error(), "Test_parseFromRegistryDigest(): Error message should match")
+		}
+	})
+
+	t.Run("devfile and resources are pulled from the same manifest", func(t *testing.T) {
+		manifestRequests := 0
+		countingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.URL.Path, "/manifests/") {
+				manifestRequests++
+			}
+			testServer.Config.Handler.ServeHTTP(w, r)
+		}))
+		defer countingServer.Close()
+
+		importReference := v1.ImportReference{
+			ImportReferenceUnion: v1.ImportReferenceUnion{
+				Id: "nodejs@" + servedDigest,
+			},
+			RegistryUrl: countingServer.URL,
+		}
+		_, err := parseFromRegistry(importReference, &resolutionContextTree{}, resolverTools{})
+		if err != nil {
+			t.Errorf("Test_parseFromRegistryDigest() unexpected error: %v", err)
+		}
+		assert.Equal(t, 1, manifestRequests, "Test_parseFromRegistryDigest(): The manifest should be fetched once.")
+	})
+}
+
+func Test_getResourcesFromRegistryDigest(t *testing.T) {
+	devfileDigest := contentDigest([]byte(registryDigestDevfile))
+	archive := testRegistryArchive(t, map[string]string{
+		"src/index.js": "console.log('hello')",
+		"OWNERS":       "approvers: []",
+	})
+	archiveDigest := contentDigest(archive)
+	devfileLayer := registryStackLayer{
+		MediaType:   registryLibrary.DevfileMediaType,
+		Digest:      devfileDigest,
+		Annotations: map[string]string{ociImageTitleAnnotation: "devfile.yaml"},
+	}
+	archiveLayer := registryStackLayer{
+		MediaType:   registryLibrary.DevfileArchiveMediaType,
+		Digest:      archiveDigest,
+		Annotations: map[string]string{ociImageTitleAnnotation: registryArchiveFile},
+	}
+	escapingLayer := registryStackLayer{
+		MediaType:   registryLibrary.DevfileArchiveMediaType,
+		Digest:      archiveDigest,
+		Annotations: map[string]string{ociImageTitleAnnotation: "../archive.tar"},
+	}
+
+	tamperedErr := ".*does not match the expected checksum " + strings.TrimPrefix(archiveDigest, "sha256:")
+	escapingErr := `.*invalid file name "\.\./archive\.tar" of the layer .*`
+
+	tests := []struct {
+		name      string
+		layers    []registryStackLayer
+		blobs     map[string][]byte
+		wantFiles map[string]string
+		wantErr   *string
+	}{
+		{
+			name:   "resources matching the digest of their layer",
+			layers: []registryStackLayer{devfileLayer, archiveLayer},
+			blobs: map[string][]byte{
+				devfileDigest: []byte(registryDigestDevfile),
+				archiveDigest: archive,
+			},
+			wantFiles: map[string]string{
+				"src/index.js": "console.log('hello')",
+			},
+		},
+		{
+			name:   "resources which do not match the digest of their layer",
+			layers: []registryStackLayer{devfileLayer, archiveLayer},
+			blobs: map[string][]byte{
+				devfileDigest: []byte(registryDigestDevfile),
+				archiveDigest: testRegistryArchive(t, map[string]string{"src/index.js": "tampered"}),
+			},
+			wantErr: &tamperedErr,
+		},
+		{
+			name:   "layer whose file name escapes the stack directory",
+			layers: []registryStackLayer{devfileLayer, escapingLayer},
+			blobs: map[string][]byte{
+				devfileDigest: []byte(registryDigestDevfile),
+				archiveDigest: archive,
+			},
+			wantErr: &escapingErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testServer := newTestOCIRegistry(t, tt.layers, tt.blobs)
+			defer testServer.Close()
+			destDir, err := ioutil.TempDir("", "registry-digest")
+			if err != nil {
+				t.Fatalf("Test_getResourcesFromRegistryDigest(): unexpected error: %v", err)
+			}
+			defer os.RemoveAll(destDir)
+
+			err = getResourcesFromRegistry(nil, "nodejs", testServer.URL, "", devfileDigest, destDir, nil)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Test_getResourcesFromRegistryDigest() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				assert.Regexp(t, *tt.wantErr, err.Error(), "Test_getResourcesFromRegistryDigest(): Error message should match")
+			} else {
+				for name, wantContent := range tt.wantFiles {
+					content, err := ioutil.ReadFile(filepath.Join(destDir, name))
+					if err != nil {
+						t.Errorf("Test_getResourcesFromRegistryDigest(): unexpected error: %v", err)
+						continue
+					}
+					assert.Equal(t, wantContent, string(content), "Test_getResourcesFromRegistryDigest(): The two values should be the same.")
+				}
+				if _, err := os.Stat(filepath.Join(destDir, "OWNERS")); !os.IsNotExist(err) {
+					t.Errorf("Test_getResourcesFromRegistryDigest(): the OWNERS file should not be pulled")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+)
+
+// RemoteRefKind describes the devfile element holding a remote reference
+type RemoteRefKind string
+
+const (
+	// ParentRemoteRef is the reference of the devfile parent
+	ParentRemoteRef RemoteRefKind = "parent"
+	// PluginRemoteRef is the reference of a plugin component
+	PluginRemoteRef RemoteRefKind = "plugin"
+	// KubernetesRemoteRef is the uri of a kubernetes component
+	KubernetesRemoteRef RemoteRefKind = "kubernetes"
+	// OpenshiftRemoteRef is the uri of an openshift component
+	OpenshiftRemoteRef RemoteRefKind = "openshift"
+	// DockerfileRemoteRef is the dockerfile source of an image component
+	DockerfileRemoteRef RemoteRefKind = "dockerfile"
+	// BuildContextRemoteRef is the build context of an image component
+	BuildContextRemoteRef RemoteRefKind = "buildContext"
+)
+
+// RemoteRef is a reference of the devfile which requires network access to be resolved
+type RemoteRef struct {
+	// Kind is the kind of devfile element holding the reference
+	Kind RemoteRefKind
+	// Name is the name of the component holding the reference, it is empty for the parent
+	Name string
+	// Uri is the absolute URL of the reference, relative uris are resolved against the devfile URL
+	Uri string
+	// Id is the id of the reference in a devfile registry
+	Id string
+	// RegistryUrl is the devfile registry URL of the reference
+	RegistryUrl string
+	// Kubernetes is the reference of a DevWorkspaceTemplate custom resource
+	Kubernetes *v1.KubernetesCustomResourceImportReference
+}
+
+// GetRemoteReferences returns the references of the devfile which require network access to be resolved:
+// the parent and plugin uris, ids and kubernetes references, the kubernetes and openshift component uris
+// and the dockerfile sources and remote build contexts of the image components. The devfile should be parsed without being flattened
+// for the parent and plugin references to be returned. No network access is made.
+func (d DevfileObj) GetRemoteReferences() []RemoteRef {
+	var remoteRefs []RemoteRef
+
+	if parent := d.Data.GetParent(); parent != nil {
+		remoteRefs = append(remoteRefs, d.getImportReferenceRemoteRefs(ParentRemoteRef, "", parent.ImportReference)...)
+	}
+
+	components, err := d.Data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return remoteRefs
+	}
+	for _, component := range components {
+		switch {
+		case component.Plugin != nil:
+			remoteRefs = append(remoteRefs, d.getImportReferenceRemoteRefs(PluginRemoteRef, component.Name, component.Plugin.ImportReference)...)
+		case component.Kubernetes != nil:
+			if uri, ok := d.getRemoteUri(component.Kubernetes.Uri); ok {
+				remoteRefs = append(remoteRefs, RemoteRef{Kind: KubernetesRemoteRef, Name: component.Name, Uri: uri})
+			}
+		case component.Openshift != nil:
+			if uri, ok := d.getRemoteUri(component.Openshift.Uri); ok {
+				remoteRefs = append(remoteRefs, RemoteRef{Kind: OpenshiftRemoteRef, Name: component.Name, Uri: uri})
+			}
+		case component.Image != nil && component.Image.Dockerfile != nil:
+			dockerfileSrc := component.Image.Dockerfile.DockerfileSrc
+			switch {
+			case dockerfileSrc.DevfileRegistry != nil:
+				remoteRefs = append(remoteRefs, RemoteRef{Kind: DockerfileRemoteRef, Name: component.Name,
+					Id: dockerfileSrc.DevfileRegistry.Id, RegistryUrl: dockerfileSrc.DevfileRegistry.RegistryUrl})
+			case dockerfileSrc.Git != nil:
+				var remoteNames []string
+				for remoteName := range dockerfileSrc.Git.Remotes {
+					remoteNames = append(remoteNames, remoteName)
+				}
+				sort.Strings(remoteNames)
+				for _, remoteName := range remoteNames {
+					remoteRefs = append(remoteRefs, RemoteRef{Kind: DockerfileRemoteRef, Name: component.Name, Uri: dockerfileSrc.Git.Remotes[remoteName]})
+				}
+			default:
+				if uri, ok := d.getRemoteUri(dockerfileSrc.Uri); ok {
+					remoteRefs = append(remoteRefs, RemoteRef{Kind: DockerfileRemoteRef, Name: component.Name, Uri: uri})
+				}
+			}
+			// a relative build context is resolved on the filesystem of the build, it is not fetched from the devfile URL
+			if buildContext := component.Image.Dockerfile.BuildContext; isHTTPURL(buildContext) {
+				remoteRefs = append(remoteRefs, RemoteRef{Kind: BuildContextRemoteRef, Name: component.Name, Uri: buildContext})
+			}
+		}
+	}
+
+	return remoteRefs
+}
+
+// getImportReferenceRemoteRefs returns the remote reference of a parent or plugin import reference
+func (d DevfileObj) getImportReferenceRemoteRefs(kind RemoteRefKind, name string, importReference v1.ImportReference) []RemoteRef {
+	switch {
+	case importReference.Uri != "":
+		if uri, ok := d.getRemoteUri(importReference.Uri); ok {
+			return []RemoteRef{{Kind: kind, Name: name, Uri: uri}}
+		}
+	case importReference.Id != "":
+		return []RemoteRef{{Kind: kind, Name: name, Id: importReference.Id, RegistryUrl: importReference.RegistryUrl}}
+	case importReference.Kubernetes != nil:
+		return []RemoteRef{{Kind: kind, Name: name, Kubernetes: importReference.Kubernetes}}
+	}
+	return nil
+}
+
+// getRemoteUri returns the absolute URL of the uri and true if the uri requires network access,
+// a relative uri requires network access if the devfile is read from a URL
+func (d DevfileObj) getRemoteUri(uri string) (string, bool) {
+	if uri == "" {
+		return "", false
+	}
+	if isHTTPURL(uri) {
+		return uri, true
+	}
+	if d.Ctx.GetURL() == "" {
+		return "", false
+	}
+	u, err := url.Parse(d.Ctx.GetURL())
+	if err != nil {
+		return "", false
+	}
+	u.Path = path.Join(path.Dir(u.Path), uri)
+	return u.String(), true
+}
+
+// isHTTPURL returns true if the uri is an http or https URL
+func isHTTPURL(uri string) bool {
+	return strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://")
+}
@@ -0,0 +1,185 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	devfileCtx "github.com/devfile/library/v2/pkg/devfile/parser/context"
+	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRemoteReferences(t *testing.T) {
+
+	kubernetesRef := &v1.KubernetesCustomResourceImportReference{Name: "plugin", Namespace: "default"}
+
+	components := []v1.Component{
+		{
+			Name: "registry-plugin",
+			ComponentUnion: v1.ComponentUnion{
+				Plugin: &v1.PluginComponent{
+					ImportReference: v1.ImportReference{
+						ImportReferenceUnion: v1.ImportReferenceUnion{Id: "nodejs"},
+						RegistryUrl:          "https://registry.devfile.io",
+					},
+				},
+			},
+		},
+		{
+			Name: "kube-plugin",
+			ComponentUnion: v1.ComponentUnion{
+				Plugin: &v1.PluginComponent{
+					ImportReference: v1.ImportReference{
+						ImportReferenceUnion: v1.ImportReferenceUnion{Kubernetes: kubernetesRef},
+					},
+				},
+			},
+		},
+		{
+			Name: "deploy",
+			ComponentUnion: v1.ComponentUnion{
+				Kubernetes: &v1.KubernetesComponent{
+					K8sLikeComponent: v1.K8sLikeComponent{
+						K8sLikeComponentLocation: v1.K8sLikeComponentLocation{Uri: "kubernetes/deploy.yaml"},
+					},
+				},
+			},
+		},
+		{
+			Name: "remote-context",
+			ComponentUnion: v1.ComponentUnion{
+				Image: &v1.ImageComponent{
+					Image: v1.Image{
+						ImageName: "image:latest",
+						ImageUnion: v1.ImageUnion{
+							Dockerfile: &v1.DockerfileImage{
+								DockerfileSrc: v1.DockerfileSrc{Uri: "https://example.com/Dockerfile"},
+								Dockerfile:    v1.Dockerfile{BuildContext: "https://example.com/context.tar.gz"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "route",
+			ComponentUnion: v1.ComponentUnion{
+				Openshift: &v1.OpenshiftComponent{
+					K8sLikeComponent: v1.K8sLikeComponent{
+						K8sLikeComponentLocation: v1.K8sLikeComponentLocation{Uri: "https://example.com/route.yaml"},
+					},
+				},
+			},
+		},
+		{
+			Name: "image",
+			ComponentUnion: v1.ComponentUnion{
+				Image: &v1.ImageComponent{
+					Image: v1.Image{
+						ImageName: "image:latest",
+						ImageUnion: v1.ImageUnion{
+							Dockerfile: &v1.DockerfileImage{
+								DockerfileSrc: v1.DockerfileSrc{
+									Git: &v1.DockerfileGitProjectSource{
+										GitProjectSource: v1.GitProjectSource{
+											GitLikeProjectSource: v1.GitLikeProjectSource{
+												Remotes: map[string]string{"origin": "https://github.com/devfile/library.git"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "local-image",
+			ComponentUnion: v1.ComponentUnion{
+				Image: &v1.ImageComponent{
+					Image: v1.Image{
+						ImageName: "image:latest",
+						ImageUnion: v1.ImageUnion{
+							Dockerfile: &v1.DockerfileImage{
+								DockerfileSrc: v1.DockerfileSrc{Uri: "./Dockerfile"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		ctx            devfileCtx.DevfileCtx
+		wantRemoteRefs []RemoteRef
+	}{
+		{
+			name: "devfile read from the filesystem",
+			ctx:  devfileCtx.NewDevfileCtx(OutputDevfileYamlPath),
+			wantRemoteRefs: []RemoteRef{
+				{Kind: ParentRemoteRef, Uri: "https://example.com/parent/devfile.yaml"},
+				{Kind: PluginRemoteRef, Name: "registry-plugin", Id: "nodejs", RegistryUrl: "https://registry.devfile.io"},
+				{Kind: PluginRemoteRef, Name: "kube-plugin", Kubernetes: kubernetesRef},
+				{Kind: DockerfileRemoteRef, Name: "remote-context", Uri: "https://example.com/Dockerfile"},
+				{Kind: BuildContextRemoteRef, Name: "remote-context", Uri: "https://example.com/context.tar.gz"},
+				{Kind: OpenshiftRemoteRef, Name: "route", Uri: "https://example.com/route.yaml"},
+				{Kind: DockerfileRemoteRef, Name: "image", Uri: "https://github.com/devfile/library.git"},
+			},
+		},
+		{
+			name: "relative uris of a devfile read from a URL are remote",
+			ctx:  devfileCtx.NewURLDevfileCtx("https://example.com/stack/devfile.yaml"),
+			wantRemoteRefs: []RemoteRef{
+				{Kind: ParentRemoteRef, Uri: "https://example.com/parent/devfile.yaml"},
+				{Kind: PluginRemoteRef, Name: "registry-plugin", Id: "nodejs", RegistryUrl: "https://registry.devfile.io"},
+				{Kind: PluginRemoteRef, Name: "kube-plugin", Kubernetes: kubernetesRef},
+				{Kind: KubernetesRemoteRef, Name: "deploy", Uri: "https://example.com/stack/kubernetes/deploy.yaml"},
+				{Kind: DockerfileRemoteRef, Name: "remote-context", Uri: "https://example.com/Dockerfile"},
+				{Kind: BuildContextRemoteRef, Name: "remote-context", Uri: "https://example.com/context.tar.gz"},
+				{Kind: OpenshiftRemoteRef, Name: "route", Uri: "https://example.com/route.yaml"},
+				{Kind: DockerfileRemoteRef, Name: "image", Uri: "https://github.com/devfile/library.git"},
+				{Kind: DockerfileRemoteRef, Name: "local-image", Uri: "https://example.com/stack/Dockerfile"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DevfileObj{
+				Ctx: tt.ctx,
+				Data: &v2.DevfileV2{
+					Devfile: v1.Devfile{
+						DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+							Parent: &v1.Parent{
+								ImportReference: v1.ImportReference{
+									ImportReferenceUnion: v1.ImportReferenceUnion{Uri: "https://example.com/parent/devfile.yaml"},
+								},
+							},
+							DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+								Components: components,
+							},
+						},
+					},
+				},
+			}
+			assert.Equal(t, tt.wantRemoteRefs, d.GetRemoteReferences(), "TestGetRemoteReferences(): The two values should be the same.")
+		})
+	}
+}
@@ -18,6 +18,7 @@ package parser
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 )
@@ -38,12 +39,27 @@ func (t *resolutionContextTree) appendNode(importReference v1.ImportReference) *
 	return newNode
 }
 
-// hasCycle checks if the current resolutionContextTree has a cycle
+// ImportCycleError is returned if the devfile references itself through its parents and plugins
+type ImportCycleError struct {
+	// Chain is the ordered list of the references resolved from the main devfile up to the reference closing the cycle
+	Chain []string
+}
+
+func (e *ImportCycleError) Error() string {
+	return fmt.Sprintf("devfile has an cycle in references: %s", strings.Join(e.Chain, " -> "))
+}
+
+// Depth returns the import recursion depth the cycle is detected at, the main devfile being at depth 0
+func (e *ImportCycleError) Depth() int {
+	return len(e.Chain) - 1
+}
+
+// hasCycle checks if the current resolutionContextTree has a cycle, an ImportCycleError is returned if it has
 func (t *resolutionContextTree) hasCycle() error {
 	var seenRefs []v1.ImportReference
 	currNode := t
 	hasCycle := false
-	cycle := resolveImportReference(t.importReference)
+	chain := []string{resolveImportReference(t.importReference)}
 
 	for currNode.parentNode != nil {
 		for _, seenRef := range seenRefs {
@@ -53,11 +69,11 @@ func (t *resolutionContextTree) hasCycle() error {
 		}
 		seenRefs = append(seenRefs, currNode.importReference)
 		currNode = currNode.parentNode
-		cycle = fmt.Sprintf("%s -> %s", resolveImportReference(currNode.importReference), cycle)
+		chain = append([]string{resolveImportReference(currNode.importReference)}, chain...)
 	}
 
 	if hasCycle {
-		return fmt.Errorf("devfile has an cycle in references: %v", cycle)
+		return &ImportCycleError{Chain: chain}
 	}
 	return nil
 }
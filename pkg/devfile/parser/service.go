@@ -0,0 +1,139 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+
+	devfileCtx "github.com/devfile/library/v2/pkg/devfile/parser/context"
+)
+
+// Parser parses devfiles with shared settings, a shared content cache and a shared schema cache, and bounds
+// the number of devfiles parsed concurrently. It is meant to be created once by a long-lived service, its
+// Parse method is safe for concurrent use.
+type Parser struct {
+	// args holds the settings shared by all the parses
+	args ParserArgs
+	// semaphore bounds the number of concurrent parses, nil if unbounded
+	semaphore chan struct{}
+}
+
+// ParserOption configures a Parser
+type ParserOption func(p *Parser)
+
+// Input is the devfile parsed by Parser.Parse, one of Path, URL or Data must be set
+type Input struct {
+	// Path is a relative or absolute devfile path.
+	Path string
+	// URL is the URL address of the specific devfile.
+	URL string
+	// Data is the devfile content in []byte format.
+	Data []byte
+	// ExternalVariables override variables defined in the Devfile
+	ExternalVariables map[string]string
+}
+
+// ParseResult is the result of Parser.Parse
+type ParseResult struct {
+	// Devfile is the parsed devfile
+	Devfile DevfileObj
+	// Warnings are the warnings raised while parsing the devfile
+	Warnings []string
+}
+
+// WithParserArgs sets the settings of all the parses, the source of the devfile set in the args is ignored
+func WithParserArgs(args ParserArgs) ParserOption {
+	return func(p *Parser) {
+		contentCache, schemaCache := p.args.ContentCache, p.args.SchemaCache
+		p.args = args
+		if p.args.ContentCache == nil {
+			p.args.ContentCache = contentCache
+		}
+		if p.args.SchemaCache == nil {
+			p.args.SchemaCache = schemaCache
+		}
+	}
+}
+
+// WithMaxConcurrency bounds the number of devfiles parsed concurrently, a value lower than 1 means unbounded
+func WithMaxConcurrency(maxConcurrency int) ParserOption {
+	return func(p *Parser) {
+		if maxConcurrency < 1 {
+			p.semaphore = nil
+			return
+		}
+		p.semaphore = make(chan struct{}, maxConcurrency)
+	}
+}
+
+// WithContentCache sets the cache of the content downloaded from the remote URLs
+func WithContentCache(cache *devfileCtx.ContentCache) ParserOption {
+	return func(p *Parser) {
+		p.args.ContentCache = cache
+	}
+}
+
+// WithSchemaCache sets the cache of the compiled devfile json schemas
+func WithSchemaCache(cache *devfileCtx.SchemaCache) ParserOption {
+	return func(p *Parser) {
+		p.args.SchemaCache = cache
+	}
+}
+
+// NewParser returns a new Parser configured with the options. The parser has its own content and schema caches
+// unless other caches are set with WithContentCache or WithSchemaCache, and does not bound the number of
+// concurrent parses unless WithMaxConcurrency is set.
+func NewParser(opts ...ParserOption) *Parser {
+	p := &Parser{
+		args: ParserArgs{
+			ContentCache: devfileCtx.NewContentCache(),
+			SchemaCache:  devfileCtx.NewSchemaCache(),
+		},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Parse parses the devfile of the input with the settings of the parser. It waits for a parse to complete
+// if the maximum number of concurrent parses is reached.
+func (p *Parser) Parse(input Input) (ParseResult, error) {
+	if input.Path == "" && input.URL == "" && input.Data == nil {
+		return ParseResult{}, fmt.Errorf("the devfile source is not provided")
+	}
+	if p.semaphore != nil {
+		p.semaphore <- struct{}{}
+		defer func() { <-p.semaphore }()
+	}
+
+	args := p.args
+	args.Path = input.Path
+	args.URL = input.URL
+	args.Data = input.Data
+	if input.ExternalVariables != nil {
+		args.ExternalVariables = input.ExternalVariables
+	}
+
+	d, err := ParseDevfile(args)
+	if err != nil {
+		return ParseResult{}, err
+	}
+	return ParseResult{
+		Devfile:  d,
+		Warnings: d.Ctx.GetWarnings(),
+	}, nil
+}
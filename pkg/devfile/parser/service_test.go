@@ -0,0 +1,107 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_Parse(t *testing.T) {
+	const parentDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: parent
+components:
+- name: parent-runtime
+  container:
+    image: quay.io/nodejs-16
+`
+	var parentHits int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/parent.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		atomic.AddInt32(&parentHits, 1)
+		if _, err := w.Write([]byte(parentDevfile)); err != nil {
+			t.Errorf("unexpected error while writing yaml: %v", err)
+		}
+	}))
+	defer testServer.Close()
+
+	devfileContent := func(name string) []byte {
+		return []byte(fmt.Sprintf(`schemaVersion: 2.2.0
+metadata:
+  name: %s
+parent:
+  uri: %s/parent.yaml
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+`, name, testServer.URL))
+	}
+
+	p := NewParser(WithMaxConcurrency(2))
+
+	// the first parse downloads the parent, the next parses read it from the cache
+	result, err := p.Parse(Input{Data: devfileContent("nodejs-0")})
+	if err != nil {
+		t.Fatalf("TestParser_Parse() unexpected error: %v", err)
+	}
+	assert.Equal(t, "nodejs-0", result.Devfile.Data.GetMetadata().Name, "TestParser_Parse(): The two values should be the same.")
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	names := make([]string, 10)
+	componentCounts := make([]int, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := p.Parse(Input{Data: devfileContent(fmt.Sprintf("nodejs-%d", i+1))})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			names[i] = result.Devfile.Data.GetMetadata().Name
+			components, err := result.Devfile.Data.GetComponents(common.DevfileOptions{})
+			errs[i] = err
+			componentCounts[i] = len(components)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < 10; i++ {
+		if errs[i] != nil {
+			t.Errorf("TestParser_Parse() unexpected error: %v", errs[i])
+			continue
+		}
+		assert.Equal(t, fmt.Sprintf("nodejs-%d", i+1), names[i], "TestParser_Parse(): The two values should be the same.")
+		assert.Equal(t, 2, componentCounts[i], "TestParser_Parse(): The two values should be the same.")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&parentHits), "TestParser_Parse(): The parent should be downloaded once.")
+
+	_, err = p.Parse(Input{})
+	assert.Error(t, err, "TestParser_Parse(): Parsing an input without devfile source should fail.")
+}
@@ -20,6 +20,7 @@ import (
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/api/v2/pkg/attributes"
 	"github.com/devfile/api/v2/pkg/validation"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
 )
 
 const (
@@ -28,6 +29,20 @@ const (
 	pluginOverrideAttribute = validation.PluginOverrideAttribute
 )
 
+const (
+	// ProvenanceAttributePrefix is the reserved attribute namespace of the provenance annotations
+	ProvenanceAttributePrefix = "provenance.devfile.io/"
+	// ProvenanceOriginAttribute records the parent or plugin an element of the flattened devfile comes from,
+	// or "local" if it is defined in the devfile itself
+	ProvenanceOriginAttribute = ProvenanceAttributePrefix + "origin"
+	// ProvenanceOverriddenByAttribute records the devfile which overrides an element imported from a parent or plugin,
+	// "local" if the override is defined in the devfile itself
+	ProvenanceOverriddenByAttribute = ProvenanceAttributePrefix + "overridden-by"
+
+	// provenanceLocal is the provenance of the elements defined in the devfile itself
+	provenanceLocal = "local"
+)
+
 // addSourceAttributesForParentOverride adds an attribute 'api.devfile.io/imported-from=<source reference>'
 //  to all elements of template spec content that support attributes.
 func addSourceAttributesForTemplateSpecContent(sourceImportReference v1.ImportReference, template *v1.DevWorkspaceTemplateSpecContent) {
@@ -128,3 +143,113 @@ func addSourceAttributesForOverrideAndMerge(sourceImportReference v1.ImportRefer
 
 	return nil
 }
+
+// addProvenanceAttributes annotates all elements of the flattened template spec content that support attributes
+// with the provenance attributes, derived from the source attributes added while overriding and merging.
+func addProvenanceAttributes(template *v1.DevWorkspaceTemplateSpecContent) {
+	for idx := range template.Components {
+		template.Components[idx].Attributes = getProvenanceAttributes(template.Components[idx].Attributes)
+	}
+	for idx := range template.Commands {
+		template.Commands[idx].Attributes = getProvenanceAttributes(template.Commands[idx].Attributes)
+	}
+	for idx := range template.Projects {
+		template.Projects[idx].Attributes = getProvenanceAttributes(template.Projects[idx].Attributes)
+	}
+	for idx := range template.StarterProjects {
+		template.StarterProjects[idx].Attributes = getProvenanceAttributes(template.StarterProjects[idx].Attributes)
+	}
+}
+
+// getProvenanceAttributes returns the element attributes with the provenance attributes added
+func getProvenanceAttributes(elementAttributes attributes.Attributes) attributes.Attributes {
+	if elementAttributes == nil {
+		elementAttributes = attributes.Attributes{}
+	}
+
+	provenance := getElementProvenance(elementAttributes)
+	elementAttributes.PutString(ProvenanceOriginAttribute, provenance.Origin)
+	if provenance.OverriddenBy != "" {
+		elementAttributes.PutString(ProvenanceOverriddenByAttribute, provenance.OverriddenBy)
+	}
+
+	return elementAttributes
+}
+
+// Provenance is the provenance of an element of a flattened devfile
+type Provenance struct {
+	// Origin is the parent or plugin the element comes from, e.g. "uri: <uri>" or "id: <id>, registryURL: <registry URL>",
+	// or "local" if the element is defined in the devfile itself
+	Origin string
+	// OverriddenBy is the devfile which overrides the element imported from a parent or plugin, "local" if the override
+	// is defined in the devfile itself. It is empty if the element is not overridden.
+	OverriddenBy string
+}
+
+// DevfileProvenance is the provenance of the elements of a flattened devfile, keyed by the name of the components,
+// projects and starter projects and by the id of the commands
+type DevfileProvenance struct {
+	Components      map[string]Provenance
+	Commands        map[string]Provenance
+	Projects        map[string]Provenance
+	StarterProjects map[string]Provenance
+}
+
+// GetProvenance returns the provenance of the elements of the flattened devfile, derived from the source attributes
+// added to the elements while the parent and plugins are merged. The elements of a devfile which is not flattened
+// are all local.
+func (d DevfileObj) GetProvenance() (DevfileProvenance, error) {
+	provenance := DevfileProvenance{
+		Components:      map[string]Provenance{},
+		Commands:        map[string]Provenance{},
+		Projects:        map[string]Provenance{},
+		StarterProjects: map[string]Provenance{},
+	}
+	components, err := d.Data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return DevfileProvenance{}, err
+	}
+	for _, component := range components {
+		provenance.Components[component.Name] = getElementProvenance(component.Attributes)
+	}
+	commands, err := d.Data.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		return DevfileProvenance{}, err
+	}
+	for _, command := range commands {
+		provenance.Commands[command.Id] = getElementProvenance(command.Attributes)
+	}
+	projects, err := d.Data.GetProjects(common.DevfileOptions{})
+	if err != nil {
+		return DevfileProvenance{}, err
+	}
+	for _, project := range projects {
+		provenance.Projects[project.Name] = getElementProvenance(project.Attributes)
+	}
+	starterProjects, err := d.Data.GetStarterProjects(common.DevfileOptions{})
+	if err != nil {
+		return DevfileProvenance{}, err
+	}
+	for _, starterProject := range starterProjects {
+		provenance.StarterProjects[starterProject.Name] = getElementProvenance(starterProject.Attributes)
+	}
+	return provenance, nil
+}
+
+// getElementProvenance returns the provenance of an element of the flattened devfile from its source attributes
+func getElementProvenance(elementAttributes attributes.Attributes) Provenance {
+	provenance := Provenance{Origin: provenanceLocal}
+	if elementAttributes.Exists(importSourceAttribute) {
+		provenance.Origin = elementAttributes.GetString(importSourceAttribute, nil)
+	}
+
+	for _, overrideAttribute := range []string{parentOverrideAttribute, pluginOverrideAttribute} {
+		if elementAttributes.Exists(overrideAttribute) {
+			provenance.OverriddenBy = elementAttributes.GetString(overrideAttribute, nil)
+			if provenance.OverriddenBy == resolveImportReference(v1.ImportReference{}) {
+				provenance.OverriddenBy = provenanceLocal
+			}
+		}
+	}
+	return provenance
+}
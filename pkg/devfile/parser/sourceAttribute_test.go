@@ -16,6 +16,10 @@
 package parser
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/api/v2/pkg/attributes"
 	"github.com/kylelemons/godebug/pretty"
@@ -171,3 +175,181 @@ func TestAddSourceAttributesForOverrideAndMerge(t *testing.T) {
 	}
 
 }
+
+func TestAddProvenanceAttributes(t *testing.T) {
+	parentReference := resolveImportReference(v1.ImportReference{
+		ImportReferenceUnion: v1.ImportReferenceUnion{
+			Uri: "127.0.0.1:8080",
+		},
+	})
+	pluginReference := resolveImportReference(v1.ImportReference{
+		ImportReferenceUnion: v1.ImportReferenceUnion{
+			Id: "nodejs-plugin",
+		},
+	})
+
+	template := &v1.DevWorkspaceTemplateSpecContent{
+		Components: []v1.Component{
+			{
+				Name:       "parent-component",
+				Attributes: attributes.Attributes{}.PutString(importSourceAttribute, parentReference),
+			},
+			{
+				Name: "overridden-component",
+				Attributes: attributes.Attributes{}.PutString(importSourceAttribute, parentReference).
+					PutString(parentOverrideAttribute, "main devfile"),
+			},
+			{
+				Name:       "local-component",
+				Attributes: attributes.Attributes{}.PutString("user-attribute", "value"),
+			},
+		},
+		Commands: []v1.Command{
+			{
+				Id: "plugin-command",
+				Attributes: attributes.Attributes{}.PutString(importSourceAttribute, pluginReference).
+					PutString(pluginOverrideAttribute, "main devfile"),
+			},
+			{
+				Id: "local-command",
+			},
+		},
+		Projects: []v1.Project{
+			{
+				Name: "local-project",
+			},
+		},
+		StarterProjects: []v1.StarterProject{
+			{
+				Name:       "parent-starter-project",
+				Attributes: attributes.Attributes{}.PutString(importSourceAttribute, parentReference),
+			},
+		},
+	}
+
+	wantTemplate := &v1.DevWorkspaceTemplateSpecContent{
+		Components: []v1.Component{
+			{
+				Name: "parent-component",
+				Attributes: attributes.Attributes{}.PutString(importSourceAttribute, parentReference).
+					PutString(ProvenanceOriginAttribute, parentReference),
+			},
+			{
+				Name: "overridden-component",
+				Attributes: attributes.Attributes{}.PutString(importSourceAttribute, parentReference).
+					PutString(parentOverrideAttribute, "main devfile").
+					PutString(ProvenanceOriginAttribute, parentReference).
+					PutString(ProvenanceOverriddenByAttribute, "local"),
+			},
+			{
+				Name: "local-component",
+				Attributes: attributes.Attributes{}.PutString("user-attribute", "value").
+					PutString(ProvenanceOriginAttribute, "local"),
+			},
+		},
+		Commands: []v1.Command{
+			{
+				Id: "plugin-command",
+				Attributes: attributes.Attributes{}.PutString(importSourceAttribute, pluginReference).
+					PutString(pluginOverrideAttribute, "main devfile").
+					PutString(ProvenanceOriginAttribute, pluginReference).
+					PutString(ProvenanceOverriddenByAttribute, "local"),
+			},
+			{
+				Id:         "local-command",
+				Attributes: attributes.Attributes{}.PutString(ProvenanceOriginAttribute, "local"),
+			},
+		},
+		Projects: []v1.Project{
+			{
+				Name:       "local-project",
+				Attributes: attributes.Attributes{}.PutString(ProvenanceOriginAttribute, "local"),
+			},
+		},
+		StarterProjects: []v1.StarterProject{
+			{
+				Name: "parent-starter-project",
+				Attributes: attributes.Attributes{}.PutString(importSourceAttribute, parentReference).
+					PutString(ProvenanceOriginAttribute, parentReference),
+			},
+		},
+	}
+
+	addProvenanceAttributes(template)
+	if !reflect.DeepEqual(template, wantTemplate) {
+		t.Errorf("TestAddProvenanceAttributes() error: wanted: %v, got: %v, difference at %v", wantTemplate, template, pretty.Compare(template, wantTemplate))
+	}
+}
+
+func TestGetProvenance(t *testing.T) {
+	const parentDevfile = `schemaVersion: 2.2.0
+metadata:
+  name: parent
+components:
+- name: parent-runtime
+  container:
+    image: quay.io/nodejs-14
+- name: shared
+  container:
+    image: quay.io/nodejs-14
+commands:
+- id: build
+  exec:
+    commandLine: npm install
+    component: parent-runtime
+`
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte(parentDevfile)); err != nil {
+			t.Errorf("unexpected error while writing yaml: %v", err)
+		}
+	}))
+	defer testServer.Close()
+
+	parentURI := testServer.URL + "/parent.yaml"
+	devfileContent := fmt.Sprintf(`schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+parent:
+  uri: %s
+  components:
+  - name: shared
+    container:
+      image: quay.io/nodejs-16
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+commands:
+- id: run
+  exec:
+    commandLine: npm start
+    component: runtime
+`, parentURI)
+
+	d, err := ParseDevfile(ParserArgs{
+		Data: []byte(devfileContent),
+	})
+	if err != nil {
+		t.Fatalf("TestGetProvenance() unexpected error: %v", err)
+	}
+	got, err := d.GetProvenance()
+	if err != nil {
+		t.Fatalf("TestGetProvenance() unexpected error: %v", err)
+	}
+
+	parentOrigin := "uri: " + parentURI
+	want := DevfileProvenance{
+		Components: map[string]Provenance{
+			"parent-runtime": {Origin: parentOrigin},
+			"shared":         {Origin: parentOrigin, OverriddenBy: "local"},
+			"runtime":        {Origin: "local"},
+		},
+		Commands: map[string]Provenance{
+			"build": {Origin: parentOrigin},
+			"run":   {Origin: "local"},
+		},
+		Projects:        map[string]Provenance{},
+		StarterProjects: map[string]Provenance{},
+	}
+	assert.Equal(t, want, got, "TestGetProvenance(): The two values should be the same.")
+}
@@ -16,9 +16,15 @@
 package parser
 
 import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"strings"
+
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	apiAttributes "github.com/devfile/api/v2/pkg/attributes"
 	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	yaml3 "gopkg.in/yaml.v3"
 	"sigs.k8s.io/yaml"
 
 	"github.com/devfile/library/v2/pkg/testingutil/filesystem"
@@ -41,6 +47,12 @@ func (d *DevfileObj) WriteYamlDevfile() error {
 	if err != nil {
 		return errors.Wrapf(err, "failed to marshal devfile object into yaml")
 	}
+	if d.Ctx.GetPreserveAnchors() {
+		yamlData, err = preserveYamlAnchors(d.Ctx.GetDevfileSourceContent(), yamlData)
+		if err != nil {
+			return errors.Wrapf(err, "failed to preserve the yaml anchors of the devfile")
+		}
+	}
 	// Write to devfile.yaml
 	fs := d.Ctx.GetFs()
 	if fs == nil {
@@ -113,3 +125,100 @@ func restoreK8sCompURI(devObj *DevfileObj) error {
 	}
 	return nil
 }
+
+// preserveYamlAnchors restores the anchors and aliases of the source yaml into the marshalled yaml. An anchor is restored
+// on the first element of the marshalled yaml found at the path of the anchor or of one of its aliases in the source yaml,
+// and still holding the anchored value, the other elements become aliases of it. Merge keys are not restored.
+func preserveYamlAnchors(source []byte, marshalled []byte) ([]byte, error) {
+	var sourceDoc, marshalledDoc yaml3.Node
+	if err := yaml3.Unmarshal(source, &sourceDoc); err != nil {
+		return nil, err
+	}
+	if err := yaml3.Unmarshal(marshalled, &marshalledDoc); err != nil {
+		return nil, err
+	}
+	if len(sourceDoc.Content) == 0 || len(marshalledDoc.Content) == 0 {
+		return marshalled, nil
+	}
+
+	anchorValues := make(map[string]interface{})
+	anchorPaths := make(map[string]string)
+	var collectAnchors func(node *yaml3.Node, path string) error
+	collectAnchors = func(node *yaml3.Node, path string) error {
+		if node.Kind == yaml3.AliasNode {
+			anchorPaths[path] = node.Value
+			return nil
+		}
+		if node.Anchor != "" {
+			var value interface{}
+			if err := node.Decode(&value); err != nil {
+				return err
+			}
+			anchorValues[node.Anchor] = value
+			anchorPaths[path] = node.Anchor
+		}
+		return forEachYamlChild(node, path, collectAnchors)
+	}
+	if err := collectAnchors(sourceDoc.Content[0], ""); err != nil {
+		return nil, err
+	}
+	if len(anchorValues) == 0 {
+		return marshalled, nil
+	}
+
+	anchorNodes := make(map[string]*yaml3.Node)
+	var restoreAnchors func(node *yaml3.Node, path string) error
+	restoreAnchors = func(node *yaml3.Node, path string) error {
+		if anchor, ok := anchorPaths[path]; ok {
+			var value interface{}
+			if err := node.Decode(&value); err != nil {
+				return err
+			}
+			if reflect.DeepEqual(value, anchorValues[anchor]) {
+				if anchorNode, ok := anchorNodes[anchor]; ok {
+					*node = yaml3.Node{Kind: yaml3.AliasNode, Value: anchor, Alias: anchorNode}
+					return nil
+				}
+				node.Anchor = anchor
+				anchorNodes[anchor] = node
+			}
+		}
+		return forEachYamlChild(node, path, restoreAnchors)
+	}
+	if err := restoreAnchors(marshalledDoc.Content[0], ""); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml3.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&marshalledDoc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// forEachYamlChild calls fn on the mapping values and sequence items of the yaml node, merge keys are skipped
+func forEachYamlChild(node *yaml3.Node, path string, fn func(node *yaml3.Node, path string) error) error {
+	switch node.Kind {
+	case yaml3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "<<" {
+				continue
+			}
+			if err := fn(node.Content[i+1], path+"/"+strings.NewReplacer("~", "~0", "/", "~1").Replace(node.Content[i].Value)); err != nil {
+				return err
+			}
+		}
+	case yaml3.SequenceNode:
+		for i, item := range node.Content {
+			if err := fn(item, path+"/"+strconv.Itoa(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
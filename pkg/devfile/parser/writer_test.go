@@ -23,6 +23,8 @@ import (
 	devfileCtx "github.com/devfile/library/v2/pkg/devfile/parser/context"
 	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
 	"github.com/devfile/library/v2/pkg/testingutil/filesystem"
+	"reflect"
+	"sigs.k8s.io/yaml"
 	"strings"
 	"testing"
 )
@@ -118,3 +120,90 @@ func TestWriteYamlDevfile(t *testing.T) {
 		}
 	})
 }
+
+func TestWriteYamlDevfilePreserveAnchors(t *testing.T) {
+
+	devfileContent := `schemaVersion: 2.2.0
+metadata:
+  name: nodejs
+components:
+- name: runtime
+  container:
+    image: quay.io/nodejs-16
+    env: &commonEnv
+    - name: NODE_ENV
+      value: development
+- name: tools
+  container:
+    image: quay.io/tools
+    env: *commonEnv
+commands:
+- id: run
+  exec:
+    component: runtime
+    commandLine: npm start
+    env: *commonEnv
+`
+
+	tests := []struct {
+		name            string
+		preserveAnchors bool
+		wantAnchors     int
+		wantAliases     int
+	}{
+		{
+			name:            "anchors are expanded by default",
+			preserveAnchors: false,
+		},
+		{
+			name:            "anchors are preserved",
+			preserveAnchors: true,
+			wantAnchors:     1,
+			wantAliases:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := filesystem.NewFakeFs()
+			ctx := devfileCtx.FakeContext(fs, OutputDevfileYamlPath)
+			if err := ctx.SetDevfileContentFromBytes([]byte(devfileContent)); err != nil {
+				t.Fatalf("TestWriteYamlDevfilePreserveAnchors() unexpected error: '%v'", err)
+			}
+			ctx.SetPreserveAnchors(tt.preserveAnchors)
+			devfileData := &v2.DevfileV2{}
+			if err := yaml.Unmarshal([]byte(devfileContent), devfileData); err != nil {
+				t.Fatalf("TestWriteYamlDevfilePreserveAnchors() unexpected error: '%v'", err)
+			}
+			devfileObj := DevfileObj{
+				Ctx:  ctx,
+				Data: devfileData,
+			}
+
+			err := devfileObj.WriteYamlDevfile()
+			if err != nil {
+				t.Errorf("TestWriteYamlDevfilePreserveAnchors() unexpected error: '%v'", err)
+				return
+			}
+
+			data, err := fs.ReadFile(OutputDevfileYamlPath)
+			if err != nil {
+				t.Errorf("TestWriteYamlDevfilePreserveAnchors() unexpected error: '%v'", err)
+				return
+			}
+			content := string(data)
+			if got := strings.Count(content, "&commonEnv"); got != tt.wantAnchors {
+				t.Errorf("TestWriteYamlDevfilePreserveAnchors() error: expected %d anchors, got %d in\n%s", tt.wantAnchors, got, content)
+			}
+			if got := strings.Count(content, "*commonEnv"); got != tt.wantAliases {
+				t.Errorf("TestWriteYamlDevfilePreserveAnchors() error: expected %d aliases, got %d in\n%s", tt.wantAliases, got, content)
+			}
+
+			writtenData := &v2.DevfileV2{}
+			if err := yaml.Unmarshal(data, writtenData); err != nil {
+				t.Errorf("TestWriteYamlDevfilePreserveAnchors() unexpected error: '%v'", err)
+			} else if !reflect.DeepEqual(devfileData, writtenData) {
+				t.Errorf("TestWriteYamlDevfilePreserveAnchors() error: written devfile %v does not match %v", writtenData, devfileData)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	v2Validation "github.com/devfile/api/v2/pkg/validation"
+	devfileData "github.com/devfile/library/v2/pkg/devfile/parser/data"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidateCommandEndpointRefs checks that the endpoints referenced by the common.CommandEndpointAttribute
+// of the commands are declared by a component of the devfile
+func ValidateCommandEndpointRefs(data devfileData.DevfileData) error {
+	components, err := data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+	commands, err := data.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	endpointNames := make(map[string]bool)
+	for _, component := range components {
+		switch {
+		case component.Container != nil:
+			for _, endpoint := range component.Container.Endpoints {
+				endpointNames[endpoint.Name] = true
+			}
+		case component.Kubernetes != nil:
+			for _, endpoint := range component.Kubernetes.Endpoints {
+				endpointNames[endpoint.Name] = true
+			}
+		case component.Openshift != nil:
+			for _, endpoint := range component.Openshift.Endpoints {
+				endpointNames[endpoint.Name] = true
+			}
+		}
+	}
+
+	var returnedErr error
+	for _, command := range commands {
+		endpointRefs, err := common.GetCommandEndpointRefs(command)
+		if err != nil {
+			returnedErr = multierror.Append(returnedErr, err)
+			continue
+		}
+		for _, endpointRef := range endpointRefs {
+			if !endpointNames[endpointRef] {
+				returnedErr = multierror.Append(returnedErr, fmt.Errorf("command %s references the endpoint %s which is not declared by any component", command.Id, endpointRef))
+			}
+		}
+	}
+
+	return returnedErr
+}
+
+// ValidateCommandGroupDefaults checks that each command group kind has at most one default command, as reported by
+// the devfile/api command validation. If warnMissingDefault is true, a warning is returned for each group kind which
+// has several commands but no default command. The other command errors of the devfile/api validation are not reported.
+func ValidateCommandGroupDefaults(data devfileData.DevfileData, warnMissingDefault bool) ([]string, error) {
+	commands, err := data.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		return nil, err
+	}
+	components, err := data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	var defaultErrs []error
+	for _, err := range getValidationErrors(v2Validation.ValidateCommands(commands, components)) {
+		var multipleDefaultErr *v2Validation.MultipleDefaultCmdError
+		var missingDefaultWarning *v2Validation.MissingDefaultCmdWarning
+		switch {
+		case errors.As(err, &multipleDefaultErr):
+			defaultErrs = append(defaultErrs, err)
+		case errors.As(err, &missingDefaultWarning) && warnMissingDefault:
+			warnings = append(warnings, err.Error())
+		}
+	}
+	// the devfile/api validation reports the groups in no particular order
+	sort.Strings(warnings)
+	sort.Slice(defaultErrs, func(i, j int) bool {
+		return defaultErrs[i].Error() < defaultErrs[j].Error()
+	})
+
+	var returnedErr error
+	for _, err := range defaultErrs {
+		returnedErr = multierror.Append(returnedErr, err)
+	}
+
+	return warnings, returnedErr
+}
+
+// ValidateEventCommands checks that the commands referenced by the lifecycle events exist and are of a kind
+// valid for the event: preStart and postStop events run apply commands, postStart and preStop events run exec
+// commands, or composite commands of such commands. It runs the devfile/api event validation, which reports
+// the invalid commands of each event type.
+func ValidateEventCommands(data devfileData.DevfileData) error {
+	commands, err := data.GetCommands(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	return v2Validation.ValidateEvents(data.GetEvents(), commands)
+}
@@ -0,0 +1,325 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCommandEndpointRefs(t *testing.T) {
+
+	components := []v1.Component{
+		{
+			Name: "runtime",
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						Image: "image",
+					},
+					Endpoints: []v1.Endpoint{
+						{Name: "http", TargetPort: 8080},
+					},
+				},
+			},
+		},
+		{
+			Name: "deploy",
+			ComponentUnion: v1.ComponentUnion{
+				Kubernetes: &v1.KubernetesComponent{
+					K8sLikeComponent: v1.K8sLikeComponent{
+						Endpoints: []v1.Endpoint{
+							{Name: "metrics", TargetPort: 9090},
+						},
+					},
+				},
+			},
+		},
+	}
+	execCommand := func(id string, endpointRef interface{}) v1.Command {
+		command := v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					CommandLine: "curl localhost",
+					Component:   "runtime",
+				},
+			},
+		}
+		if endpointRef != nil {
+			command.Attributes = attributes.Attributes{}.Put(common.CommandEndpointAttribute, endpointRef, nil)
+		}
+		return command
+	}
+
+	danglingRefErr := "command readiness references the endpoint debug which is not declared by any component"
+	otherDanglingRefErr := "command metrics references the endpoint admin which is not declared by any component"
+	invalidRefErr := "attribute library.devfile.io/endpoint of command invalid should be an endpoint name or a list of endpoint names"
+
+	tests := []struct {
+		name     string
+		commands []v1.Command
+		wantErr  []string
+	}{
+		{
+			name: "endpoints referenced by commands are declared",
+			commands: []v1.Command{
+				execCommand("readiness", "http"),
+				execCommand("metrics", []string{"http", "metrics"}),
+				execCommand("build", nil),
+			},
+		},
+		{
+			name: "dangling endpoint references are aggregated",
+			commands: []v1.Command{
+				execCommand("readiness", "debug"),
+				execCommand("metrics", []string{"metrics", "admin"}),
+			},
+			wantErr: []string{danglingRefErr, otherDanglingRefErr},
+		},
+		{
+			name: "endpoint reference which is not a name",
+			commands: []v1.Command{
+				execCommand("invalid", 8080),
+			},
+			wantErr: []string{invalidRefErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: components,
+							Commands:   tt.commands,
+						},
+					},
+				},
+			}
+
+			err := ValidateCommandEndpointRefs(d)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateCommandEndpointRefs() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					assert.Contains(t, err.Error(), wantErr, "TestValidateCommandEndpointRefs(): Error message should match")
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCommandGroupDefaults(t *testing.T) {
+
+	isDefault := func(b bool) *bool {
+		return &b
+	}
+	execCommand := func(id string, kind v1.CommandGroupKind, def *bool) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Exec: &v1.ExecCommand{
+					LabeledCommand: v1.LabeledCommand{
+						BaseCommand: v1.BaseCommand{
+							Group: &v1.CommandGroup{
+								Kind:      kind,
+								IsDefault: def,
+							},
+						},
+					},
+					CommandLine: "echo " + id,
+					Component:   "runtime",
+				},
+			},
+		}
+	}
+
+	multipleBuildErr := "command group build error - there should be exactly one default command, currently there are multiple default commands; command: build1; command: build2"
+	multipleRunErr := "command group run error - there should be exactly one default command, currently there are multiple default commands; command: run1; command: run2; command: run3"
+	missingTestWarning := "command group test warning - there should be exactly one default command, currently there is no default command"
+
+	tests := []struct {
+		name               string
+		commands           []v1.Command
+		warnMissingDefault bool
+		wantWarnings       []string
+		wantErr            []string
+	}{
+		{
+			name: "no default command",
+			commands: []v1.Command{
+				execCommand("test1", v1.TestCommandGroupKind, nil),
+				execCommand("test2", v1.TestCommandGroupKind, isDefault(false)),
+			},
+		},
+		{
+			name: "no default command with warnings",
+			commands: []v1.Command{
+				execCommand("build1", v1.BuildCommandGroupKind, isDefault(true)),
+				execCommand("test1", v1.TestCommandGroupKind, nil),
+				execCommand("test2", v1.TestCommandGroupKind, isDefault(false)),
+			},
+			warnMissingDefault: true,
+			wantWarnings:       []string{missingTestWarning},
+		},
+		{
+			name: "one default command per group",
+			commands: []v1.Command{
+				execCommand("build1", v1.BuildCommandGroupKind, isDefault(true)),
+				execCommand("build2", v1.BuildCommandGroupKind, isDefault(false)),
+				execCommand("run1", v1.RunCommandGroupKind, isDefault(true)),
+			},
+			warnMissingDefault: true,
+		},
+		{
+			name: "multiple default commands per group",
+			commands: []v1.Command{
+				execCommand("build1", v1.BuildCommandGroupKind, isDefault(true)),
+				execCommand("build2", v1.BuildCommandGroupKind, isDefault(true)),
+				execCommand("run1", v1.RunCommandGroupKind, isDefault(true)),
+				execCommand("run2", v1.RunCommandGroupKind, isDefault(true)),
+				execCommand("run3", v1.RunCommandGroupKind, isDefault(true)),
+			},
+			wantErr: []string{multipleBuildErr, multipleRunErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Commands: tt.commands,
+						},
+					},
+				},
+			}
+
+			warnings, err := ValidateCommandGroupDefaults(d, tt.warnMissingDefault)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateCommandGroupDefaults() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					assert.Contains(t, err.Error(), wantErr, "TestValidateCommandGroupDefaults(): Error message should match")
+				}
+			}
+			assert.Equal(t, tt.wantWarnings, warnings, "TestValidateCommandGroupDefaults(): The two values should be the same.")
+		})
+	}
+}
+
+func TestValidateEventCommands(t *testing.T) {
+
+	execCommand := v1.Command{
+		Id: "install",
+		CommandUnion: v1.CommandUnion{
+			Exec: &v1.ExecCommand{
+				CommandLine: "npm install",
+				Component:   "runtime",
+			},
+		},
+	}
+	applyCommand := v1.Command{
+		Id: "deploy-db",
+		CommandUnion: v1.CommandUnion{
+			Apply: &v1.ApplyCommand{
+				Component: "db",
+			},
+		},
+	}
+	compositeCommand := func(id string, commands ...string) v1.Command {
+		return v1.Command{
+			Id: id,
+			CommandUnion: v1.CommandUnion{
+				Composite: &v1.CompositeCommand{
+					Commands: commands,
+				},
+			},
+		}
+	}
+
+	missingCommandErr := "postStart type events are invalid: \nstart does not map to a valid devfile command"
+	preStartMismatchErr := "preStart type events are invalid: \ninstall should either map to an apply command or a composite command with apply commands"
+	preStopMismatchErr := "preStop type events are invalid: \ndeploy-db should either map to an exec command or a composite command with exec commands"
+	compositeMismatchErr := "postStop type events are invalid: \nmixed should either map to an apply command or a composite command with apply commands"
+
+	tests := []struct {
+		name     string
+		commands []v1.Command
+		events   v1.DevWorkspaceEvents
+		wantErr  []string
+	}{
+		{
+			name:     "valid event commands",
+			commands: []v1.Command{execCommand, applyCommand, compositeCommand("deploy-all", "deploy-db")},
+			events: v1.DevWorkspaceEvents{
+				PreStart:  []string{"deploy-all"},
+				PostStart: []string{"Install"},
+				PreStop:   []string{"install"},
+				PostStop:  []string{"deploy-db"},
+			},
+		},
+		{
+			name:     "missing command",
+			commands: []v1.Command{execCommand},
+			events: v1.DevWorkspaceEvents{
+				PostStart: []string{"install", "start"},
+			},
+			wantErr: []string{missingCommandErr},
+		},
+		{
+			name:     "commands of the wrong kind",
+			commands: []v1.Command{execCommand, applyCommand, compositeCommand("mixed", "deploy-db", "install")},
+			events: v1.DevWorkspaceEvents{
+				PreStart: []string{"install"},
+				PreStop:  []string{"deploy-db"},
+				PostStop: []string{"mixed"},
+			},
+			wantErr: []string{preStartMismatchErr, preStopMismatchErr, compositeMismatchErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Commands: tt.commands,
+							Events: &v1.Events{
+								DevWorkspaceEvents: tt.events,
+							},
+						},
+					},
+				},
+			}
+
+			err := ValidateEventCommands(d)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateEventCommands() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					assert.Contains(t, err.Error(), wantErr, "TestValidateEventCommands(): Error message should match")
+				}
+			}
+		})
+	}
+}
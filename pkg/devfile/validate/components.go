@@ -0,0 +1,82 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	devfileData "github.com/devfile/library/v2/pkg/devfile/parser/data"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateComponentsWith calls the validator on each component of the devfile,
+// and returns a total error of the errors returned by the validator prefixed with the component name
+func ValidateComponentsWith(data devfileData.DevfileData, validator func(component v1.Component) error) error {
+	components, err := data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	var returnedErr error
+	for _, component := range components {
+		if err := validator(component); err != nil {
+			returnedErr = multierror.Append(returnedErr, fmt.Errorf("component %s: %v", component.Name, err))
+		}
+	}
+
+	return returnedErr
+}
+
+// ValidateKubernetesNames checks that the component names are not all numeric, which Kubernetes rejects for resource
+// names, and that the endpoint names are valid Kubernetes port names. The DNS-1123 label rule of the component names
+// is enforced by the devfile schema and checked for the container components by ValidateContainerNames. It is not part
+// of ValidateDevfileData, as the devfile specification allows names the Kubernetes objects generated from the devfile
+// would reject.
+func ValidateKubernetesNames(data devfileData.DevfileData) error {
+	components, err := data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	var returnedErr error
+	for _, component := range components {
+		if _, err := strconv.ParseFloat(component.Name, 64); err == nil {
+			returnedErr = multierror.Append(returnedErr, fmt.Errorf("component %s is not a valid Kubernetes resource name: must not contain all numeric values", component.Name))
+		}
+
+		var endpoints []v1.Endpoint
+		switch {
+		case component.Container != nil:
+			endpoints = component.Container.Endpoints
+		case component.Kubernetes != nil:
+			endpoints = component.Kubernetes.Endpoints
+		case component.Openshift != nil:
+			endpoints = component.Openshift.Endpoints
+		}
+		for _, endpoint := range endpoints {
+			if violations := validation.IsValidPortName(endpoint.Name); len(violations) > 0 {
+				returnedErr = multierror.Append(returnedErr, fmt.Errorf("endpoint %s of the component %s is not a valid Kubernetes port name: %s", endpoint.Name, component.Name, strings.Join(violations, "; ")))
+			}
+		}
+	}
+
+	return returnedErr
+}
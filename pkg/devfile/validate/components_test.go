@@ -0,0 +1,169 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
+	"github.com/devfile/library/v2/pkg/testingutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateComponentsWith(t *testing.T) {
+
+	requireImage := func(component v1.Component) error {
+		if component.Container != nil && component.Container.Image == "" {
+			return fmt.Errorf("an image is required")
+		}
+		return nil
+	}
+	containerComponent := func(name, image string) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						Image: image,
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		components []v1.Component
+		wantErr    []string
+	}{
+		{
+			name: "valid components",
+			components: []v1.Component{
+				containerComponent("runtime", "quay.io/nodejs-16"),
+				testingutil.GetFakeVolumeComponent("data", "1Gi"),
+			},
+		},
+		{
+			name: "validator errors are aggregated",
+			components: []v1.Component{
+				containerComponent("runtime", ""),
+				containerComponent("tools", ""),
+			},
+			wantErr: []string{"component runtime: an image is required", "component tools: an image is required"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: tt.components,
+						},
+					},
+				},
+			}
+
+			err := ValidateComponentsWith(d, requireImage)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateComponentsWith() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					assert.Contains(t, err.Error(), wantErr, "TestValidateComponentsWith(): Error message should match")
+				}
+			}
+		})
+	}
+}
+
+func TestValidateKubernetesNames(t *testing.T) {
+
+	container := func(name string, endpointNames ...string) v1.Component {
+		var endpoints []v1.Endpoint
+		for _, endpointName := range endpointNames {
+			endpoints = append(endpoints, v1.Endpoint{Name: endpointName, TargetPort: 8080})
+		}
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Endpoints: endpoints,
+				},
+			},
+		}
+	}
+	volume := func(name string) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Volume: &v1.VolumeComponent{},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		components []v1.Component
+		wantErr    []string
+	}{
+		{
+			name:       "valid names",
+			components: []v1.Component{container("runtime", "http", "debug-5858"), container("tools2")},
+		},
+		{
+			name:       "all numeric component names",
+			components: []v1.Component{container("1234"), volume("5678"), container("runtime2")},
+			wantErr: []string{
+				"component 1234 is not a valid Kubernetes resource name: must not contain all numeric values",
+				"component 5678 is not a valid Kubernetes resource name: must not contain all numeric values",
+			},
+		},
+		{
+			name:       "endpoint names violating the Kubernetes port naming rules",
+			components: []v1.Component{container("runtime", "http-endpoint-too-long", "HTTP", "8080", "http--debug")},
+			wantErr: []string{
+				"endpoint http-endpoint-too-long of the component runtime is not a valid Kubernetes port name: must be no more than 15 characters",
+				"endpoint HTTP of the component runtime is not a valid Kubernetes port name: must contain only alpha-numeric characters (a-z, 0-9), and hyphens (-)",
+				"endpoint 8080 of the component runtime is not a valid Kubernetes port name: must contain at least one letter or number (a-z, 0-9)",
+				"endpoint http--debug of the component runtime is not a valid Kubernetes port name: must not contain consecutive hyphens",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: tt.components,
+						},
+					},
+				},
+			}
+
+			err := ValidateKubernetesNames(d)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateKubernetesNames() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					assert.Contains(t, err.Error(), wantErr, "TestValidateKubernetesNames(): Error message should match")
+				}
+			}
+		})
+	}
+}
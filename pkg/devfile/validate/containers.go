@@ -0,0 +1,47 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	devfileData "github.com/devfile/library/v2/pkg/devfile/parser/data"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateContainerNames checks that the names of the container components are valid Kubernetes container names,
+// i.e. DNS-1123 labels of at most 63 lowercase alphanumeric characters or '-'.
+func ValidateContainerNames(data devfileData.DevfileData) error {
+	components, err := data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	var returnedErr error
+	for _, component := range components {
+		if component.Container == nil {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(component.Name); len(errs) > 0 {
+			returnedErr = multierror.Append(returnedErr, fmt.Errorf("container %s has an invalid Kubernetes container name: %s", component.Name, strings.Join(errs, ", ")))
+		}
+	}
+
+	return returnedErr
+}
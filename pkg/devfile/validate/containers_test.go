@@ -0,0 +1,93 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
+	"github.com/devfile/library/v2/pkg/testingutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateContainerNames(t *testing.T) {
+
+	containerComponent := func(name string) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						Image: "image",
+					},
+				},
+			},
+		}
+	}
+
+	longName := strings.Repeat("a", 64)
+	underscoreErr := "container node_runtime has an invalid Kubernetes container name"
+	uppercaseErr := "container Runtime has an invalid Kubernetes container name"
+	longNameErr := "container " + longName + " has an invalid Kubernetes container name: must be no more than 63 characters"
+
+	tests := []struct {
+		name       string
+		components []v1.Component
+		wantErr    []string
+	}{
+		{
+			name: "valid container names",
+			components: []v1.Component{
+				containerComponent("runtime"),
+				containerComponent("tools-2"),
+				testingutil.GetFakeVolumeComponent("Invalid_Volume", "1Gi"),
+			},
+		},
+		{
+			name: "invalid container names are aggregated",
+			components: []v1.Component{
+				containerComponent("node_runtime"),
+				containerComponent("Runtime"),
+				containerComponent(longName),
+			},
+			wantErr: []string{underscoreErr, uppercaseErr, longNameErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: tt.components,
+						},
+					},
+				},
+			}
+
+			err := ValidateContainerNames(d)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateContainerNames() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					assert.Contains(t, err.Error(), wantErr, "TestValidateContainerNames(): Error message should match")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	devfileData "github.com/devfile/library/v2/pkg/devfile/parser/data"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidateStarterProjectNamesAndSources checks that the starter projects of the devfile have unique names
+// and that each starter project has exactly one valid source, see ValidateProjectSources for the source rules.
+// The single git remote rule of the starter projects is checked by the devfile/api ValidateStarterProjects.
+func ValidateStarterProjectNamesAndSources(data devfileData.DevfileData) error {
+	starterProjects, err := data.GetStarterProjects(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	var returnedErr error
+	starterProjectNames := make(map[string]bool)
+	for _, starterProject := range starterProjects {
+		if starterProjectNames[starterProject.Name] {
+			returnedErr = multierror.Append(returnedErr, fmt.Errorf("duplicate starterProject name %s found in the devfile", starterProject.Name))
+		}
+		starterProjectNames[starterProject.Name] = true
+
+		if err := validateProjectSource("starterProject", starterProject.Name, starterProject.ProjectSource); err != nil {
+			returnedErr = multierror.Append(returnedErr, err)
+		}
+	}
+
+	return returnedErr
+}
+
+// ValidateProjectNames checks that the project names are unique and that the projects and the starter projects
+// of the devfile do not share names. The uniqueness of the component names and of the command ids is checked by
+// the devfile/api validation, which does not check the projects. This check is not part of ValidateDevfileData,
+// the devfiles whose projects and starter projects share names are still valid devfiles.
+func ValidateProjectNames(data devfileData.DevfileData) error {
+	projects, err := data.GetProjects(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+	starterProjects, err := data.GetStarterProjects(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	var returnedErr error
+	projectsByName := make(map[string]v1.Project)
+	for _, project := range projects {
+		if _, ok := projectsByName[project.Name]; ok {
+			returnedErr = multierror.Append(returnedErr, fmt.Errorf("duplicate project name %s found in the devfile", project.Name))
+			continue
+		}
+		projectsByName[project.Name] = project
+	}
+
+	for _, starterProject := range starterProjects {
+		if project, ok := projectsByName[starterProject.Name]; ok {
+			returnedErr = multierror.Append(returnedErr, fmt.Errorf("project %s and starterProject %s share the same name", project.Name, starterProject.Name))
+		}
+	}
+
+	return returnedErr
+}
+
+// ValidateProjectSources checks that each project and starter project of the devfile has exactly one source,
+// that a git source has at least one remote, that the checkoutFrom remote of a git source is one of its remotes
+// and that a zip source has a location
+func ValidateProjectSources(data devfileData.DevfileData) error {
+	projects, err := data.GetProjects(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+	starterProjects, err := data.GetStarterProjects(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	var returnedErr error
+	for _, project := range projects {
+		if err := validateProjectSource("project", project.Name, project.ProjectSource); err != nil {
+			returnedErr = multierror.Append(returnedErr, err)
+		}
+	}
+	for _, starterProject := range starterProjects {
+		if err := validateProjectSource("starterProject", starterProject.Name, starterProject.ProjectSource); err != nil {
+			returnedErr = multierror.Append(returnedErr, err)
+		}
+	}
+
+	return returnedErr
+}
+
+// validateProjectSource checks the source of the project or starter project with the given kind and name,
+// the same rules apply to the projects and to the starter projects
+func validateProjectSource(kind, name string, source v1.ProjectSource) error {
+	sources := 0
+	for _, isSet := range []bool{source.Git != nil, source.Zip != nil, source.Custom != nil} {
+		if isSet {
+			sources++
+		}
+	}
+	switch {
+	case sources == 0:
+		return fmt.Errorf("%s %s should have a git, zip or custom source", kind, name)
+	case sources > 1:
+		return fmt.Errorf("%s %s should have only one of git, zip or custom source", kind, name)
+	case source.Zip != nil && source.Zip.Location == "":
+		return fmt.Errorf("%s %s should have a zip location", kind, name)
+	case source.Git == nil:
+		return nil
+	}
+
+	if len(source.Git.Remotes) == 0 {
+		return fmt.Errorf("%s %s should have at least one git remote", kind, name)
+	}
+	if checkoutFrom := source.Git.CheckoutFrom; checkoutFrom != nil && checkoutFrom.Remote != "" {
+		if _, ok := source.Git.Remotes[checkoutFrom.Remote]; !ok {
+			return fmt.Errorf("%s %s checks out from the git remote %s which is not declared in its remotes", kind, name, checkoutFrom.Remote)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,293 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStarterProjectNamesAndSources(t *testing.T) {
+
+	gitSource := v1.ProjectSource{
+		Git: &v1.GitProjectSource{
+			GitLikeProjectSource: v1.GitLikeProjectSource{
+				Remotes: map[string]string{"origin": "https://github.com/devfile/library.git"},
+			},
+		},
+	}
+	zipSource := v1.ProjectSource{
+		Zip: &v1.ZipProjectSource{
+			Location: "https://github.com/devfile/library/archive/main.zip",
+		},
+	}
+
+	duplicateNameErr := "duplicate starterProject name starter1 found in the devfile"
+	missingSourceErr := "starterProject starter1 should have a git, zip or custom source"
+	multipleSourcesErr := "starterProject starter1 should have only one of git, zip or custom source"
+	missingRemoteErr := "starterProject starter1 should have at least one git remote"
+	missingLocationErr := "starterProject starter2 should have a zip location"
+
+	tests := []struct {
+		name            string
+		starterProjects []v1.StarterProject
+		wantErr         []string
+	}{
+		{
+			name: "valid starter projects",
+			starterProjects: []v1.StarterProject{
+				{Name: "starter1", ProjectSource: gitSource},
+				{Name: "starter2", ProjectSource: zipSource},
+				{Name: "starter3", ProjectSource: v1.ProjectSource{Custom: &v1.CustomProjectSource{ProjectSourceClass: "custom"}}},
+			},
+		},
+		{
+			name: "two starter projects sharing a name",
+			starterProjects: []v1.StarterProject{
+				{Name: "starter1", ProjectSource: gitSource},
+				{Name: "starter1", ProjectSource: zipSource},
+			},
+			wantErr: []string{duplicateNameErr},
+		},
+		{
+			name: "starter project without source",
+			starterProjects: []v1.StarterProject{
+				{Name: "starter1"},
+			},
+			wantErr: []string{missingSourceErr},
+		},
+		{
+			name: "starter project with both git and zip sources",
+			starterProjects: []v1.StarterProject{
+				{
+					Name: "starter1",
+					ProjectSource: v1.ProjectSource{
+						Git: gitSource.Git,
+						Zip: zipSource.Zip,
+					},
+				},
+			},
+			wantErr: []string{multipleSourcesErr},
+		},
+		{
+			name: "multiple invalid starter project sources are aggregated",
+			starterProjects: []v1.StarterProject{
+				{
+					Name: "starter1",
+					ProjectSource: v1.ProjectSource{
+						Git: &v1.GitProjectSource{},
+					},
+				},
+				{
+					Name: "starter2",
+					ProjectSource: v1.ProjectSource{
+						Zip: &v1.ZipProjectSource{},
+					},
+				},
+			},
+			wantErr: []string{missingRemoteErr, missingLocationErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							StarterProjects: tt.starterProjects,
+						},
+					},
+				},
+			}
+
+			err := ValidateStarterProjectNamesAndSources(d)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateStarterProjectNamesAndSources() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					assert.Regexp(t, wantErr, err.Error(), "TestValidateStarterProjectNamesAndSources(): Error message should match")
+				}
+			}
+		})
+	}
+}
+
+func TestValidateProjectNames(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		projects        []v1.Project
+		starterProjects []v1.StarterProject
+		wantErr         []string
+	}{
+		{
+			name:            "projects and starter projects with different names",
+			projects:        []v1.Project{{Name: "project1"}, {Name: "project2"}},
+			starterProjects: []v1.StarterProject{{Name: "starter1"}},
+		},
+		{
+			name:            "projects and starter projects sharing names",
+			projects:        []v1.Project{{Name: "nodejs"}, {Name: "python"}, {Name: "project"}},
+			starterProjects: []v1.StarterProject{{Name: "nodejs"}, {Name: "starter"}, {Name: "python"}},
+			wantErr: []string{
+				"project nodejs and starterProject nodejs share the same name",
+				"project python and starterProject python share the same name",
+			},
+		},
+		{
+			name:            "duplicate project names",
+			projects:        []v1.Project{{Name: "nodejs"}, {Name: "python"}, {Name: "nodejs"}},
+			starterProjects: []v1.StarterProject{{Name: "starter"}},
+			wantErr: []string{
+				"duplicate project name nodejs found in the devfile",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Projects:        tt.projects,
+							StarterProjects: tt.starterProjects,
+						},
+					},
+				},
+			}
+
+			err := ValidateProjectNames(d)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateProjectNames() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				var errMessages []string
+				for _, validationErr := range getValidationErrors(err) {
+					errMessages = append(errMessages, validationErr.Error())
+				}
+				assert.Equal(t, tt.wantErr, errMessages, "TestValidateProjectNames(): Error message should match")
+			}
+		})
+	}
+}
+
+func TestValidateProjectSources(t *testing.T) {
+
+	gitSource := func(checkoutRemote string, remotes map[string]string) v1.ProjectSource {
+		source := v1.ProjectSource{
+			Git: &v1.GitProjectSource{
+				GitLikeProjectSource: v1.GitLikeProjectSource{
+					Remotes: remotes,
+				},
+			},
+		}
+		if checkoutRemote != "" {
+			source.Git.CheckoutFrom = &v1.CheckoutFrom{Remote: checkoutRemote}
+		}
+		return source
+	}
+	remotes := map[string]string{"origin": "https://github.com/devfile/library.git"}
+
+	tests := []struct {
+		name            string
+		projects        []v1.Project
+		starterProjects []v1.StarterProject
+		wantErr         []string
+	}{
+		{
+			name: "valid sources",
+			projects: []v1.Project{
+				{Name: "git", ProjectSource: gitSource("origin", remotes)},
+				{Name: "zip", ProjectSource: v1.ProjectSource{Zip: &v1.ZipProjectSource{Location: "https://example.com/project.zip"}}},
+			},
+			starterProjects: []v1.StarterProject{
+				{Name: "starter", ProjectSource: gitSource("", remotes)},
+			},
+		},
+		{
+			name: "no source",
+			projects: []v1.Project{
+				{Name: "empty"},
+			},
+			starterProjects: []v1.StarterProject{
+				{Name: "empty-starter"},
+			},
+			wantErr: []string{
+				"project empty should have a git, zip or custom source",
+				"starterProject empty-starter should have a git, zip or custom source",
+			},
+		},
+		{
+			name: "multiple sources",
+			projects: []v1.Project{
+				{
+					Name: "multiple",
+					ProjectSource: v1.ProjectSource{
+						Git: gitSource("", remotes).Git,
+						Zip: &v1.ZipProjectSource{Location: "https://example.com/project.zip"},
+					},
+				},
+			},
+			wantErr: []string{"project multiple should have only one of git, zip or custom source"},
+		},
+		{
+			name: "git source without remotes",
+			projects: []v1.Project{
+				{Name: "no-remote", ProjectSource: gitSource("", nil)},
+			},
+			wantErr: []string{"project no-remote should have at least one git remote"},
+		},
+		{
+			name: "zip source without location",
+			projects: []v1.Project{
+				{Name: "no-location", ProjectSource: v1.ProjectSource{Zip: &v1.ZipProjectSource{}}},
+			},
+			wantErr: []string{"project no-location should have a zip location"},
+		},
+		{
+			name: "checkout from an undeclared remote",
+			starterProjects: []v1.StarterProject{
+				{Name: "upstream", ProjectSource: gitSource("upstream", remotes)},
+			},
+			wantErr: []string{"starterProject upstream checks out from the git remote upstream which is not declared in its remotes"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Projects:        tt.projects,
+							StarterProjects: tt.starterProjects,
+						},
+					},
+				},
+			}
+
+			err := ValidateProjectSources(d)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateProjectSources() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					assert.Contains(t, err.Error(), wantErr, "TestValidateProjectSources(): Error message should match")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+
+	devfileData "github.com/devfile/library/v2/pkg/devfile/parser/data"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidateRegistryReferences checks that the parent and the plugin components importing a devfile by id can be resolved
+// from a registry, either the registryUrl of the import reference or one of the registryURLs configured by the consumer.
+// The parent is removed from a flattened devfile, so the raw devfile should be validated.
+func ValidateRegistryReferences(data devfileData.DevfileData, registryURLs []string) error {
+	var returnedErr error
+
+	parent := data.GetParent()
+	if parent != nil && parent.Id != "" && parent.RegistryUrl == "" && len(registryURLs) == 0 {
+		returnedErr = multierror.Append(returnedErr, fmt.Errorf("parent %s does not define a registryUrl and no registry URLs are configured", parent.Id))
+	}
+
+	components, err := data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+	for _, component := range components {
+		plugin := component.Plugin
+		if plugin != nil && plugin.Id != "" && plugin.RegistryUrl == "" && len(registryURLs) == 0 {
+			returnedErr = multierror.Append(returnedErr, fmt.Errorf("plugin %s importing %s does not define a registryUrl and no registry URLs are configured", component.Name, plugin.Id))
+		}
+	}
+
+	return returnedErr
+}
@@ -0,0 +1,114 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRegistryReferences(t *testing.T) {
+
+	idImport := func(id, registryURL string) v1.ImportReference {
+		return v1.ImportReference{
+			ImportReferenceUnion: v1.ImportReferenceUnion{
+				Id: id,
+			},
+			RegistryUrl: registryURL,
+		}
+	}
+	pluginComponent := func(name string, importReference v1.ImportReference) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Plugin: &v1.PluginComponent{
+					ImportReference: importReference,
+				},
+			},
+		}
+	}
+
+	parentErr := "parent nodejs does not define a registryUrl and no registry URLs are configured"
+	pluginErr := "plugin tools importing java-maven does not define a registryUrl and no registry URLs are configured"
+
+	tests := []struct {
+		name         string
+		parent       *v1.Parent
+		components   []v1.Component
+		registryURLs []string
+		wantErr      []string
+	}{
+		{
+			name:   "id imports with a registryUrl",
+			parent: &v1.Parent{ImportReference: idImport("nodejs", "https://registry.devfile.io")},
+			components: []v1.Component{
+				pluginComponent("tools", idImport("java-maven", "https://registry.devfile.io")),
+			},
+		},
+		{
+			name:   "id imports resolved from the configured registries",
+			parent: &v1.Parent{ImportReference: idImport("nodejs", "")},
+			components: []v1.Component{
+				pluginComponent("tools", idImport("java-maven", "")),
+			},
+			registryURLs: []string{"https://registry.devfile.io"},
+		},
+		{
+			name: "uri imports without registry",
+			parent: &v1.Parent{
+				ImportReference: v1.ImportReference{
+					ImportReferenceUnion: v1.ImportReferenceUnion{
+						Uri: "https://example.com/devfile.yaml",
+					},
+				},
+			},
+		},
+		{
+			name:   "id imports without registry are aggregated",
+			parent: &v1.Parent{ImportReference: idImport("nodejs", "")},
+			components: []v1.Component{
+				pluginComponent("tools", idImport("java-maven", "")),
+			},
+			wantErr: []string{parentErr, pluginErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						Parent: tt.parent,
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: tt.components,
+						},
+					},
+				},
+			}
+
+			err := ValidateRegistryReferences(d, tt.registryURLs)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateRegistryReferences() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					assert.Contains(t, err.Error(), wantErr, "TestValidateRegistryReferences(): Error message should match")
+				}
+			}
+		})
+	}
+}
@@ -80,3 +80,14 @@ func ValidateDevfileData(data devfileData.DevfileData) error {
 		return fmt.Errorf("unknown devfile type %T", d)
 	}
 }
+
+// getValidationErrors returns the errors aggregated in the error returned by a devfile/api validation
+func getValidationErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if merr, ok := err.(*multierror.Error); ok {
+		return merr.Errors
+	}
+	return []error{err}
+}
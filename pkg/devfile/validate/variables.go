@@ -0,0 +1,73 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"sort"
+
+	"github.com/devfile/api/v2/pkg/validation/variables"
+	devfileData "github.com/devfile/library/v2/pkg/devfile/parser/data"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+)
+
+// VariableReport describes the top-level variables of a devfile and their references
+type VariableReport struct {
+	// Referenced are the sorted names of the variables referenced by the devfile
+	Referenced []string
+	// Defined are the sorted names of the variables defined by the devfile
+	Defined []string
+	// Unresolved are the sorted names of the referenced variables which are not defined
+	Unresolved []string
+	// Unused are the sorted names of the defined variables which are not referenced
+	Unused []string
+	// Warning stores the devfile objects holding unresolved references, as reported by the variable substitution
+	Warning variables.VariableWarning
+}
+
+// ReportVariables reports the variables referenced and defined by the devfile and the references the variable
+// substitution would leave unresolved. Like the substitution, it looks at the references in the components,
+// commands, projects and starter projects. The devfile is not modified.
+func ReportVariables(data devfileData.DevfileData) (VariableReport, error) {
+	var report VariableReport
+	workspaceSpec := data.GetDevfileWorkspaceSpec()
+	if workspaceSpec == nil {
+		return report, nil
+	}
+
+	referenced, err := common.GetVariableReferences(workspaceSpec.Components, workspaceSpec.Commands, workspaceSpec.Projects, workspaceSpec.StarterProjects)
+	if err != nil {
+		return report, err
+	}
+
+	for name := range referenced {
+		report.Referenced = append(report.Referenced, name)
+		if _, ok := workspaceSpec.Variables[name]; !ok {
+			report.Unresolved = append(report.Unresolved, name)
+		}
+	}
+	for name := range workspaceSpec.Variables {
+		report.Defined = append(report.Defined, name)
+		if !referenced[name] {
+			report.Unused = append(report.Unused, name)
+		}
+	}
+	for _, names := range [][]string{report.Referenced, report.Defined, report.Unresolved, report.Unused} {
+		sort.Strings(names)
+	}
+
+	report.Warning = variables.ValidateAndReplaceGlobalVariable(workspaceSpec.DeepCopy())
+	return report, nil
+}
@@ -0,0 +1,83 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportVariables(t *testing.T) {
+
+	d := &v2.DevfileV2{
+		Devfile: v1.Devfile{
+			DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+					Variables: map[string]string{
+						"image":   "quay.io/nodejs:12",
+						"port":    "3000",
+						"version": "1.0.0",
+					},
+					Components: []v1.Component{
+						{
+							Name: "runtime",
+							ComponentUnion: v1.ComponentUnion{
+								Container: &v1.ContainerComponent{
+									Container: v1.Container{
+										Image: "{{image}}",
+										Env: []v1.EnvVar{
+											{Name: "PORT", Value: "{{ port }}"},
+											{Name: "DEBUG_PORT", Value: "{{debugPort}}"},
+										},
+									},
+								},
+							},
+						},
+					},
+					Commands: []v1.Command{
+						{
+							Id: "run",
+							CommandUnion: v1.CommandUnion{
+								Exec: &v1.ExecCommand{
+									CommandLine: "npm start -- --mode={{mode}}",
+									Component:   "runtime",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	report, err := ReportVariables(d)
+	if err != nil {
+		t.Fatalf("TestReportVariables() unexpected error: %v", err)
+	}
+
+	assert.Equal(t, []string{"debugPort", "image", "mode", "port"}, report.Referenced, "TestReportVariables(): The referenced variables should be the same.")
+	assert.Equal(t, []string{"image", "port", "version"}, report.Defined, "TestReportVariables(): The defined variables should be the same.")
+	assert.Equal(t, []string{"debugPort", "mode"}, report.Unresolved, "TestReportVariables(): The unresolved variables should be the same.")
+	assert.Equal(t, []string{"version"}, report.Unused, "TestReportVariables(): The unused variables should be the same.")
+	assert.Equal(t, map[string][]string{"runtime": {"debugPort"}}, report.Warning.Components, "TestReportVariables(): The component warnings should be the same.")
+	assert.Equal(t, map[string][]string{"run": {"mode"}}, report.Warning.Commands, "TestReportVariables(): The command warnings should be the same.")
+
+	assert.Equal(t, "{{image}}", d.Components[0].Container.Image, "TestReportVariables(): The devfile should not be modified.")
+	assert.Equal(t, "npm start -- --mode={{mode}}", d.Commands[0].Exec.CommandLine, "TestReportVariables(): The devfile should not be modified.")
+}
@@ -0,0 +1,128 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	v2Validation "github.com/devfile/api/v2/pkg/validation"
+	devfileData "github.com/devfile/library/v2/pkg/devfile/parser/data"
+	"github.com/devfile/library/v2/pkg/devfile/parser/data/v2/common"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidateVolumeMountPaths checks that the volume mount paths of the container components are absolute
+// and that two volume mounts of a container are not mounted to the same path.
+// A volume mount without path is mounted to /<volume name> and is considered valid.
+func ValidateVolumeMountPaths(data devfileData.DevfileData) error {
+	components, err := data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	var returnedErr error
+	for _, component := range components {
+		if component.Container == nil {
+			continue
+		}
+		mountedPaths := make(map[string]string)
+		for _, volumeMount := range component.Container.VolumeMounts {
+			if volumeMount.Path == "" {
+				continue
+			}
+			if !path.IsAbs(volumeMount.Path) {
+				returnedErr = multierror.Append(returnedErr, fmt.Errorf("volume mount %s of container %s has a path %s which is not absolute", volumeMount.Name, component.Name, volumeMount.Path))
+			}
+			mountPath := path.Clean(volumeMount.Path)
+			if otherMount, ok := mountedPaths[mountPath]; ok {
+				returnedErr = multierror.Append(returnedErr, fmt.Errorf("volume mounts %s and %s of container %s are mounted to the same path %s", otherMount, volumeMount.Name, component.Name, volumeMount.Path))
+				continue
+			}
+			mountedPaths[mountPath] = volumeMount.Name
+		}
+	}
+
+	return returnedErr
+}
+
+// ValidatePodTopology checks that a volume is not mounted both by containers of the workspace pod
+// and by containers running in a dedicated pod, since a dedicated pod cannot share the volume of the workspace pod.
+func ValidatePodTopology(data devfileData.DevfileData) error {
+	components, err := data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	var volumeNames []string
+	sharedContainers := make(map[string][]string)
+	dedicatedContainers := make(map[string][]string)
+	for _, component := range components {
+		if component.Container == nil {
+			continue
+		}
+		mountedVolumes := make(map[string]bool)
+		for _, volumeMount := range component.Container.VolumeMounts {
+			if mountedVolumes[volumeMount.Name] {
+				continue
+			}
+			mountedVolumes[volumeMount.Name] = true
+			if _, ok := sharedContainers[volumeMount.Name]; !ok {
+				if _, ok := dedicatedContainers[volumeMount.Name]; !ok {
+					volumeNames = append(volumeNames, volumeMount.Name)
+				}
+			}
+			if component.Container.GetDedicatedPod() {
+				dedicatedContainers[volumeMount.Name] = append(dedicatedContainers[volumeMount.Name], component.Name)
+			} else {
+				sharedContainers[volumeMount.Name] = append(sharedContainers[volumeMount.Name], component.Name)
+			}
+		}
+	}
+
+	var returnedErr error
+	for _, volumeName := range volumeNames {
+		if len(sharedContainers[volumeName]) > 0 && len(dedicatedContainers[volumeName]) > 0 {
+			returnedErr = multierror.Append(returnedErr, fmt.Errorf("volume %s is mounted by the containers %s of the workspace pod and by the containers %s running in a dedicated pod",
+				volumeName, strings.Join(sharedContainers[volumeName], ", "), strings.Join(dedicatedContainers[volumeName], ", ")))
+		}
+	}
+
+	return returnedErr
+}
+
+// ValidateVolumeMountReferences checks that the volume mounts of the container components reference a volume
+// component of the devfile. The devfile schema has no implicitly created volumes, every mounted volume must be
+// defined as a volume component. It returns the missing volume mount error of the devfile/api component validation,
+// which names each dangling volume mount and its container, without the other component errors.
+func ValidateVolumeMountReferences(data devfileData.DevfileData) error {
+	components, err := data.GetComponents(common.DevfileOptions{})
+	if err != nil {
+		return err
+	}
+
+	var returnedErr error
+	for _, err := range getValidationErrors(v2Validation.ValidateComponents(components)) {
+		var missingVolumeMountErr *v2Validation.MissingVolumeMountError
+		if errors.As(err, &missingVolumeMountErr) {
+			returnedErr = multierror.Append(returnedErr, err)
+		}
+	}
+
+	return returnedErr
+}
@@ -0,0 +1,246 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	v2 "github.com/devfile/library/v2/pkg/devfile/parser/data/v2"
+	"github.com/devfile/library/v2/pkg/testingutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVolumeMountPaths(t *testing.T) {
+
+	containerComponent := func(name string, volumeMounts ...v1.VolumeMount) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						Image:        "image",
+						VolumeMounts: volumeMounts,
+					},
+				},
+			},
+		}
+	}
+
+	relativePathErr := "volume mount data of container runtime has a path data/files which is not absolute"
+	otherRelativePathErr := "volume mount cache of container tools has a path ./cache which is not absolute"
+	samePathErr := "volume mounts data and cache of container runtime are mounted to the same path /data/"
+
+	tests := []struct {
+		name       string
+		components []v1.Component
+		wantErr    []string
+	}{
+		{
+			name: "absolute mount paths",
+			components: []v1.Component{
+				containerComponent("runtime", testingutil.GetFakeVolumeMount("data", "/data"), testingutil.GetFakeVolumeMount("cache", "/cache")),
+				containerComponent("tools", testingutil.GetFakeVolumeMount("data", "/data"), v1.VolumeMount{Name: "cache"}),
+				testingutil.GetFakeVolumeComponent("data", "1Gi"),
+			},
+		},
+		{
+			name: "relative mount paths of several containers are aggregated",
+			components: []v1.Component{
+				containerComponent("runtime", testingutil.GetFakeVolumeMount("data", "data/files")),
+				containerComponent("tools", testingutil.GetFakeVolumeMount("cache", "./cache")),
+			},
+			wantErr: []string{relativePathErr, otherRelativePathErr},
+		},
+		{
+			name: "two volumes mounted to the same path",
+			components: []v1.Component{
+				containerComponent("runtime", testingutil.GetFakeVolumeMount("data", "/data"), testingutil.GetFakeVolumeMount("cache", "/data/")),
+			},
+			wantErr: []string{samePathErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: tt.components,
+						},
+					},
+				},
+			}
+
+			err := ValidateVolumeMountPaths(d)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateVolumeMountPaths() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					assert.Contains(t, err.Error(), wantErr, "TestValidateVolumeMountPaths(): Error message should match")
+				}
+			}
+		})
+	}
+}
+
+func TestValidatePodTopology(t *testing.T) {
+
+	dedicatedPod := true
+	containerComponent := func(name string, dedicated bool, volumeMounts ...v1.VolumeMount) v1.Component {
+		component := v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						Image:        "image",
+						VolumeMounts: volumeMounts,
+					},
+				},
+			},
+		}
+		if dedicated {
+			component.Container.DedicatedPod = &dedicatedPod
+		}
+		return component
+	}
+
+	dataErr := "volume data is mounted by the containers runtime, tools of the workspace pod and by the containers db running in a dedicated pod"
+	cacheErr := "volume cache is mounted by the containers tools of the workspace pod and by the containers db, build running in a dedicated pod"
+
+	tests := []struct {
+		name       string
+		components []v1.Component
+		wantErr    []string
+	}{
+		{
+			name: "volumes mounted by the workspace pod or by a dedicated pod",
+			components: []v1.Component{
+				containerComponent("runtime", false, testingutil.GetFakeVolumeMount("data", "/data")),
+				containerComponent("tools", false, testingutil.GetFakeVolumeMount("data", "/data")),
+				containerComponent("db", true, testingutil.GetFakeVolumeMount("db-data", "/var/lib/db")),
+				containerComponent("build", true, testingutil.GetFakeVolumeMount("db-data", "/db")),
+			},
+		},
+		{
+			name: "volumes mounted by the workspace pod and by a dedicated pod are aggregated",
+			components: []v1.Component{
+				containerComponent("runtime", false, testingutil.GetFakeVolumeMount("data", "/data")),
+				containerComponent("tools", false, testingutil.GetFakeVolumeMount("data", "/data"), testingutil.GetFakeVolumeMount("cache", "/cache")),
+				containerComponent("db", true, testingutil.GetFakeVolumeMount("data", "/data"), testingutil.GetFakeVolumeMount("cache", "/cache")),
+				containerComponent("build", true, testingutil.GetFakeVolumeMount("cache", "/cache"), testingutil.GetFakeVolumeMount("cache", "/tmp/cache")),
+			},
+			wantErr: []string{dataErr, cacheErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: tt.components,
+						},
+					},
+				},
+			}
+
+			err := ValidatePodTopology(d)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidatePodTopology() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					assert.Contains(t, err.Error(), wantErr, "TestValidatePodTopology(): Error message should match")
+				}
+			}
+		})
+	}
+}
+
+func TestValidateVolumeMountReferences(t *testing.T) {
+
+	containerComponent := func(name string, volumeMounts ...v1.VolumeMount) v1.Component {
+		return v1.Component{
+			Name: name,
+			ComponentUnion: v1.ComponentUnion{
+				Container: &v1.ContainerComponent{
+					Container: v1.Container{
+						Image:        "image",
+						VolumeMounts: volumeMounts,
+					},
+				},
+			},
+		}
+	}
+
+	danglingMountErr := "volume mount dat belonging to the container component runtime"
+	otherDanglingMountErr := "volume mount tools belonging to the container component tools"
+
+	tests := []struct {
+		name       string
+		components []v1.Component
+		wantErr    []string
+	}{
+		{
+			name: "volume mounts reference volume components",
+			components: []v1.Component{
+				containerComponent("runtime", testingutil.GetFakeVolumeMount("data", "/data"), testingutil.GetFakeVolumeMount("cache", "/cache")),
+				containerComponent("tools", testingutil.GetFakeVolumeMount("data", "/data")),
+				testingutil.GetFakeVolumeComponent("data", "1Gi"),
+				testingutil.GetFakeVolumeComponent("cache", "1Gi"),
+			},
+		},
+		{
+			name: "dangling volume mounts are aggregated",
+			components: []v1.Component{
+				containerComponent("runtime", testingutil.GetFakeVolumeMount("dat", "/data")),
+				containerComponent("tools", testingutil.GetFakeVolumeMount("data", "/data"), testingutil.GetFakeVolumeMount("tools", "/tools")),
+				testingutil.GetFakeVolumeComponent("data", "1Gi"),
+			},
+			wantErr: []string{danglingMountErr, otherDanglingMountErr},
+		},
+		{
+			name: "other component errors are not reported",
+			components: []v1.Component{
+				containerComponent("runtime", testingutil.GetFakeVolumeMount("data", "/data")),
+				testingutil.GetFakeVolumeComponent("data", "1Gi"),
+				testingutil.GetFakeVolumeComponent("data", "invalid"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &v2.DevfileV2{
+				Devfile: v1.Devfile{
+					DevWorkspaceTemplateSpec: v1.DevWorkspaceTemplateSpec{
+						DevWorkspaceTemplateSpecContent: v1.DevWorkspaceTemplateSpecContent{
+							Components: tt.components,
+						},
+					},
+				},
+			}
+
+			err := ValidateVolumeMountReferences(d)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("TestValidateVolumeMountReferences() unexpected error: %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				for _, wantErr := range tt.wantErr {
+					assert.Contains(t, err.Error(), wantErr, "TestValidateVolumeMountReferences(): Error message should match")
+				}
+			}
+		})
+	}
+}
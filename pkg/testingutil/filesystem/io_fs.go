@@ -0,0 +1,230 @@
+//
+// Copyright 2022 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrReadOnly is returned by the write operations of the Filesystem returned by NewIOFs
+var ErrReadOnly = errors.New("read-only filesystem")
+
+// ioFs implements a read-only Filesystem in terms of an fs.FS, e.g. an embed.FS or a fstest.MapFS
+type ioFs struct {
+	fsys fs.FS
+}
+
+var _ Filesystem = ioFs{}
+
+// NewIOFs returns a read-only Filesystem reading from the fs.FS, e.g. to parse a devfile bundled with embed.FS.
+// Absolute paths are resolved against the root of the fs.FS, relative paths as well since it has no working directory.
+// The write operations fail with ErrReadOnly.
+func NewIOFs(fsys fs.FS) Filesystem {
+	return ioFs{fsys: fsys}
+}
+
+// fsName converts the path to the unrooted, slash-separated name of the fs.FS
+func fsName(name string) string {
+	name = strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+	if name == "" {
+		return "."
+	}
+	return name
+}
+
+// readOnlyError returns the error of a write operation on the path
+func readOnlyError(op string, name string) error {
+	return &os.PathError{Op: op, Path: name, Err: ErrReadOnly}
+}
+
+// Stat via fs.Stat
+func (i ioFs) Stat(name string) (os.FileInfo, error) {
+	return fs.Stat(i.fsys, fsName(name))
+}
+
+// Create fails with ErrReadOnly
+func (ioFs) Create(name string) (File, error) {
+	return nil, readOnlyError("create", name)
+}
+
+// Open via fs.FS.Open
+func (i ioFs) Open(name string) (File, error) {
+	file, err := i.fsys.Open(fsName(name))
+	if err != nil {
+		return nil, err
+	}
+	return &ioFile{file: file, name: name}, nil
+}
+
+// OpenFile via fs.FS.Open, it fails with ErrReadOnly if the file is opened for writing
+func (i ioFs) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_APPEND|os.O_TRUNC) != 0 {
+		return nil, readOnlyError("open", name)
+	}
+	return i.Open(name)
+}
+
+// Rename fails with ErrReadOnly
+func (ioFs) Rename(oldpath, newpath string) error {
+	return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: ErrReadOnly}
+}
+
+// MkdirAll fails with ErrReadOnly
+func (ioFs) MkdirAll(path string, perm os.FileMode) error {
+	return readOnlyError("mkdir", path)
+}
+
+// Chtimes fails with ErrReadOnly
+func (ioFs) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	return readOnlyError("chtimes", name)
+}
+
+// RemoveAll fails with ErrReadOnly
+func (ioFs) RemoveAll(path string) error {
+	return readOnlyError("remove", path)
+}
+
+// Remove fails with ErrReadOnly
+func (ioFs) Remove(name string) error {
+	return readOnlyError("remove", name)
+}
+
+// Chmod fails with ErrReadOnly
+func (ioFs) Chmod(name string, mode os.FileMode) error {
+	return readOnlyError("chmod", name)
+}
+
+// Getwd returns the root of the fs.FS
+func (ioFs) Getwd() (dir string, err error) {
+	return string(filepath.Separator), nil
+}
+
+// ReadFile via fs.ReadFile
+func (i ioFs) ReadFile(filename string) ([]byte, error) {
+	return fs.ReadFile(i.fsys, fsName(filename))
+}
+
+// WriteFile fails with ErrReadOnly
+func (ioFs) WriteFile(filename string, data []byte, perm os.FileMode) error {
+	return readOnlyError("open", filename)
+}
+
+// TempDir fails with ErrReadOnly
+func (ioFs) TempDir(dir, prefix string) (string, error) {
+	return "", readOnlyError("mkdirtemp", filepath.Join(dir, prefix))
+}
+
+// TempFile fails with ErrReadOnly
+func (ioFs) TempFile(dir, prefix string) (File, error) {
+	return nil, readOnlyError("createtemp", filepath.Join(dir, prefix))
+}
+
+// ReadDir via fs.ReadDir
+func (i ioFs) ReadDir(dirname string) ([]os.FileInfo, error) {
+	entries, err := fs.ReadDir(i.fsys, fsName(dirname))
+	if err != nil {
+		return nil, err
+	}
+	return dirEntriesInfo(entries)
+}
+
+// Walk via fs.WalkDir, the paths passed to walkFn are joined to root like filepath.Walk does
+func (i ioFs) Walk(root string, walkFn filepath.WalkFunc) error {
+	rootName := fsName(root)
+	return fs.WalkDir(i.fsys, rootName, func(name string, d fs.DirEntry, err error) error {
+		rel := strings.TrimPrefix(strings.TrimPrefix(name, rootName), "/")
+		if rootName == "." {
+			rel = strings.TrimPrefix(name, ".")
+		}
+		walkPath := filepath.Join(root, filepath.FromSlash(rel))
+		if err != nil {
+			return walkFn(walkPath, nil, err)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return walkFn(walkPath, nil, err)
+		}
+		return walkFn(walkPath, info, nil)
+	})
+}
+
+// dirEntriesInfo returns the file info of the directory entries
+func dirEntriesInfo(entries []fs.DirEntry) ([]os.FileInfo, error) {
+	infos := make([]os.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// ioFile implements a read-only File in terms of an fs.File
+type ioFile struct {
+	file fs.File
+	name string
+}
+
+// Name returns the name the file was opened with
+func (file *ioFile) Name() string {
+	return file.name
+}
+
+// Write fails with ErrReadOnly
+func (file *ioFile) Write(b []byte) (n int, err error) {
+	return 0, readOnlyError("write", file.name)
+}
+
+// WriteString fails with ErrReadOnly
+func (file *ioFile) WriteString(s string) (n int, err error) {
+	return 0, readOnlyError("write", file.name)
+}
+
+// Sync is a no-op since the file is not written
+func (file *ioFile) Sync() error {
+	return nil
+}
+
+// Close via fs.File.Close
+func (file *ioFile) Close() error {
+	return file.file.Close()
+}
+
+// Read via fs.File.Read
+func (file *ioFile) Read(b []byte) (n int, err error) {
+	return file.file.Read(b)
+}
+
+// Readdir via fs.ReadDirFile.ReadDir
+func (file *ioFile) Readdir(n int) ([]os.FileInfo, error) {
+	dir, ok := file.file.(fs.ReadDirFile)
+	if !ok {
+		return nil, &os.PathError{Op: "readdir", Path: file.name, Err: errors.New("not a directory")}
+	}
+	entries, err := dir.ReadDir(n)
+	if err != nil {
+		return nil, err
+	}
+	return dirEntriesInfo(entries)
+}
@@ -19,7 +19,10 @@ import (
 	"archive/zip"
 	"bufio"
 	"bytes"
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -92,7 +95,13 @@ type HTTPRequestParams struct {
 	URL                 string
 	Token               string
 	Timeout             *int
-	TelemetryClientName string //optional client name for telemetry
+	TelemetryClientName string            //optional client name for telemetry
+	HTTPClient          *http.Client      //optional client sending the request, used by DownloadInMemory
+	Context             context.Context   //optional context of the request, the request is aborted when the context is done
+	Headers             map[string]string //optional headers of the request, e.g. an Authorization header for private endpoints, dropped on redirects to another host
+	Username            string            //optional username of the basic authentication of the request
+	Password            string            //optional password of the basic authentication of the request
+	MaxBytes            int64             //optional maximum size in bytes of the content downloaded by DownloadInMemory
 }
 
 // DownloadParams holds parameters of forming file download request
@@ -266,30 +275,51 @@ func TruncateString(str string, maxLen int) string {
 	return str
 }
 
-// GetAbsPath returns absolute path from passed file path resolving even ~ to user home dir and any other such symbols that are only
-// shell expanded can also be handled here
-func GetAbsPath(path string) (string, error) {
-	// Only shell resolves `~` to home so handle it specially
+// expandHomeDir expands a leading `~` of the path to the home dir of the current user, and a leading `~user` to the
+// home dir of the user
+func expandHomeDir(path string) (string, error) {
+	if !strings.HasPrefix(path, "~") {
+		return path, nil
+	}
+	name, rest := path[1:], ""
+	if i := strings.IndexAny(name, "/"+string(filepath.Separator)); i >= 0 {
+		name, rest = name[:i], name[i+1:]
+	}
+
 	var dir string
-	if strings.HasPrefix(path, "~") {
-		if len(customHomeDir) > 0 {
-			dir = customHomeDir
+	if name == "" && len(customHomeDir) > 0 {
+		dir = customHomeDir
+	} else {
+		var usr *user.User
+		var err error
+		if name == "" {
+			usr, err = user.Current()
 		} else {
-			usr, err := user.Current()
-			if err != nil {
-				return path, errors.Wrapf(err, "unable to resolve %s to absolute path", path)
-			}
-			dir = usr.HomeDir
+			usr, err = user.Lookup(name)
 		}
-
-		if len(path) > 1 {
-			path = filepath.Join(dir, path[1:])
-		} else {
-			path = dir
+		if err != nil {
+			return path, errors.Wrapf(err, "unable to resolve %s to absolute path", path)
 		}
+		dir = usr.HomeDir
+	}
+
+	if rest == "" {
+		return dir, nil
+	}
+	return filepath.Join(dir, rest), nil
+}
+
+// GetAbsPath returns absolute path from passed file path resolving even ~ to user home dir and any other such symbols that are only
+// shell expanded can also be handled here, e.g. environment variables like $HOME
+func GetAbsPath(path string) (string, error) {
+	// Only shell resolves `~` to home so handle it specially
+	path, err := expandHomeDir(path)
+	if err != nil {
+		return path, err
 	}
+	path = os.ExpandEnv(path)
 
-	path, err := filepath.Abs(path)
+	path, err = filepath.Abs(path)
 	if err != nil {
 		return path, errors.Wrapf(err, "unable to resolve %s to absolute path", path)
 	}
@@ -743,10 +773,10 @@ func HTTPGetRequest(request HTTPRequestParams, cacheFor int) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	if request.Token != "" {
-		bearer := "Bearer " + request.Token
-		req.Header.Add("Authorization", bearer)
+	if request.Context != nil {
+		req = req.WithContext(request.Context)
 	}
+	setRequestHeaders(req, request)
 
 	//add the telemetry client name
 	req.Header.Add("Client", request.TelemetryClientName)
@@ -770,7 +800,8 @@ func HTTPGetRequest(request HTTPRequestParams, cacheFor int) ([]byte, error) {
 			Proxy:                 http.ProxyFromEnvironment,
 			ResponseHeaderTimeout: overriddenTimeout,
 		},
-		Timeout: overriddenTimeout,
+		Timeout:       overriddenTimeout,
+		CheckRedirect: dropCredentialsOnCrossHostRedirect(request, nil),
 	}
 
 	klog.V(4).Infof("HTTPGetRequest: %s", req.URL.String())
@@ -1087,12 +1118,22 @@ func DownloadInMemory(params HTTPRequestParams) ([]byte, error) {
 	var httpClient = &http.Client{Transport: &http.Transport{
 		ResponseHeaderTimeout: HTTPRequestResponseTimeout,
 	}, Timeout: HTTPRequestResponseTimeout}
+	if params.HTTPClient != nil {
+		// copy the client so that the redirect policy of the caller's client is left untouched
+		client := *params.HTTPClient
+		httpClient = &client
+	}
+	httpClient.CheckRedirect = dropCredentialsOnCrossHostRedirect(params, httpClient.CheckRedirect)
 
 	url := params.URL
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
+	if params.Context != nil {
+		req = req.WithContext(params.Context)
+	}
+	setRequestHeaders(req, params)
 
 	//add the telemetry client name in the header
 	req.Header.Add("Client", params.TelemetryClientName)
@@ -1102,11 +1143,119 @@ func DownloadInMemory(params HTTPRequestParams) ([]byte, error) {
 	}
 	// We have a non 1xx / 2xx status, return an error
 	if (resp.StatusCode - 300) > 0 {
-		return nil, errors.Errorf("failed to retrieve %s, %v: %s", url, resp.StatusCode, http.StatusText(resp.StatusCode))
+		return nil, &HTTPStatusError{URL: url, StatusCode: resp.StatusCode}
 	}
 	defer resp.Body.Close()
 
-	return ioutil.ReadAll(resp.Body)
+	if params.MaxBytes <= 0 {
+		return ioutil.ReadAll(resp.Body)
+	}
+	if resp.ContentLength > params.MaxBytes {
+		return nil, &ContentTooLargeError{URL: url, MaxBytes: params.MaxBytes}
+	}
+	// read one more byte than the limit to detect a body exceeding it without reading it entirely
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, params.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > params.MaxBytes {
+		return nil, &ContentTooLargeError{URL: url, MaxBytes: params.MaxBytes}
+	}
+	return data, nil
+}
+
+// ContentTooLargeError is returned by DownloadInMemory when the downloaded content exceeds the maximum size of the params
+type ContentTooLargeError struct {
+	URL      string
+	MaxBytes int64
+}
+
+func (e *ContentTooLargeError) Error() string {
+	return fmt.Sprintf("failed to retrieve %s, the content exceeds the maximum size of %d bytes", e.URL, e.MaxBytes)
+}
+
+// DownloadFileInMemoryWithChecksum uses the url to download the file and return bytes, the SHA-256 checksum of the
+// content is verified against the expected one, given as a hex string with an optional "sha256:" prefix
+func DownloadFileInMemoryWithChecksum(url, expectedSHA256 string) ([]byte, error) {
+	return DownloadInMemoryWithChecksum(HTTPRequestParams{URL: url, TelemetryClientName: TelemetryClientName}, expectedSHA256)
+}
+
+// DownloadInMemoryWithChecksum downloads the file of the params like DownloadInMemory and verifies the SHA-256 checksum
+// of the content against the expected one, given as a hex string with an optional "sha256:" prefix
+func DownloadInMemoryWithChecksum(params HTTPRequestParams, expectedSHA256 string) ([]byte, error) {
+	expected := strings.ToLower(strings.TrimPrefix(expectedSHA256, "sha256:"))
+	if _, err := hex.DecodeString(expected); err != nil || len(expected) != sha256.Size*2 {
+		return nil, errors.Errorf("invalid expected sha256 checksum %q of the file %s", expectedSHA256, params.URL)
+	}
+	data, err := DownloadInMemory(params)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return nil, &ChecksumMismatchError{URL: params.URL, Expected: expected, Actual: actual}
+	}
+	return data, nil
+}
+
+// ChecksumMismatchError is returned by DownloadInMemoryWithChecksum when the checksum of the downloaded content
+// does not match the expected one
+type ChecksumMismatchError struct {
+	URL      string
+	Expected string
+	Actual   string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("the sha256 checksum %s of the file %s does not match the expected checksum %s", e.Actual, e.URL, e.Expected)
+}
+
+// setRequestHeaders adds the credentials and the headers of the params to the request.
+// The values of the headers are never logged since they may hold credentials.
+func setRequestHeaders(req *http.Request, params HTTPRequestParams) {
+	if params.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+params.Token)
+	}
+	if params.Username != "" || params.Password != "" {
+		req.SetBasicAuth(params.Username, params.Password)
+	}
+	for name, value := range params.Headers {
+		req.Header.Set(name, value)
+	}
+}
+
+// maxRedirects is the number of redirects followed before a request fails, it is the default of net/http
+const maxRedirects = 10
+
+// dropCredentialsOnCrossHostRedirect returns a redirect policy removing the Authorization header and the caller-supplied
+// headers of the params from the requests redirected to a host other than the one of the original request, so that the
+// credentials are not leaked to a third party. The redirect is then checked with the given policy, if any.
+func dropCredentialsOnCrossHostRedirect(params HTTPRequestParams, checkRedirect func(req *http.Request, via []*http.Request) error) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > 0 && req.URL.Host != via[0].URL.Host {
+			req.Header.Del("Authorization")
+			for name := range params.Headers {
+				req.Header.Del(name)
+			}
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= maxRedirects {
+			return errors.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+}
+
+// HTTPStatusError is returned by DownloadInMemory when the response has a non 1xx / 2xx status
+type HTTPStatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("failed to retrieve %s, %v: %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
 }
 
 // ValidateK8sResourceName sanitizes kubernetes resource name with the following requirements:
@@ -30,6 +30,7 @@ import (
 	"regexp"
 	"runtime"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -197,6 +198,9 @@ func TestGenerateRandomString(t *testing.T) {
 }
 
 func TestGetAbsPath(t *testing.T) {
+	os.Setenv("DEVFILE_TEST_DIR", "/tmp/devfile")
+	defer os.Unsetenv("DEVFILE_TEST_DIR")
+
 	tests := []struct {
 		name    string
 		path    string
@@ -213,30 +217,71 @@ func TestGetAbsPath(t *testing.T) {
 			path:    ".",
 			wantErr: false,
 		},
+		{
+			name:    "Case 3: Valid abs path resolution of `~/foo`",
+			path:    "~/foo",
+			wantErr: false,
+		},
+		{
+			name:    "Case 4: Valid abs path resolution of `$HOME/bar`",
+			path:    "$HOME/bar",
+			wantErr: false,
+		},
+		{
+			name:    "Case 5: Valid abs path resolution of environment variables",
+			path:    "${DEVFILE_TEST_DIR}/devfile.yaml",
+			absPath: "/tmp/devfile/devfile.yaml",
+			wantErr: false,
+		},
+		{
+			name:    "Case 6: Absolute path needs no expansion",
+			path:    "/tmp/devfile.yaml",
+			absPath: "/tmp/devfile.yaml",
+			wantErr: false,
+		},
+		{
+			name:    "Case 7: Relative path needs no expansion",
+			path:    "foo/devfile.yaml",
+			wantErr: false,
+		},
+		{
+			name:    "Case 8: Unknown user of `~user`",
+			path:    "~devfile-unknown-user/foo",
+			absPath: "~devfile-unknown-user/foo",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Log("Running test: ", tt.name)
 		t.Run(tt.name, func(t *testing.T) {
-			switch tt.path {
-			case "~":
-				if len(customHomeDir) > 0 {
-					tt.absPath = customHomeDir
-				} else {
-					usr, err := user.Current()
-					if err != nil {
-						t.Errorf("Failed to get absolute path corresponding to `~`. Error %v", err)
-						return
-					}
-					tt.absPath = usr.HomeDir
-				}
-
-			case ".":
-				absPath, err := os.Getwd()
+			var homeDir string
+			if len(customHomeDir) > 0 {
+				homeDir = customHomeDir
+			} else {
+				usr, err := user.Current()
 				if err != nil {
-					t.Errorf("Failed to get absolute path corresponding to `.`. Error %v", err)
+					t.Errorf("Failed to get absolute path corresponding to `~`. Error %v", err)
 					return
 				}
+				homeDir = usr.HomeDir
+			}
+			absPath, err := os.Getwd()
+			if err != nil {
+				t.Errorf("Failed to get absolute path corresponding to `.`. Error %v", err)
+				return
+			}
+
+			switch tt.path {
+			case "~":
+				tt.absPath = homeDir
+			case "~/foo":
+				tt.absPath = filepath.Join(homeDir, "foo")
+			case "$HOME/bar":
+				tt.absPath = filepath.Join(os.Getenv("HOME"), "bar")
+			case ".":
 				tt.absPath = absPath
+			case "foo/devfile.yaml":
+				tt.absPath = filepath.Join(absPath, "foo", "devfile.yaml")
 			}
 			result, err := GetAbsPath(tt.path)
 			if result != tt.absPath {
@@ -936,6 +981,265 @@ func TestDownloadFile(t *testing.T) {
 	}
 }
 
+func TestDownloadInMemoryHeaders(t *testing.T) {
+	var received http.Header
+	record := func(rw http.ResponseWriter, req *http.Request) {
+		received = req.Header.Clone()
+		_, err := rw.Write([]byte("OK"))
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	// otherHost serves the redirects to another host
+	otherHost := httptest.NewServer(http.HandlerFunc(record))
+	defer otherHost.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/cross-host":
+			http.Redirect(rw, req, otherHost.URL+"/devfile.yaml", http.StatusFound)
+		case "/same-host":
+			http.Redirect(rw, req, "/devfile.yaml", http.StatusFound)
+		default:
+			record(rw, req)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name              string
+		params            HTTPRequestParams
+		wantAuthorization string
+		wantHeaders       map[string]string
+	}{
+		{
+			name:              "Case 1: Token is sent as a bearer Authorization header",
+			params:            HTTPRequestParams{URL: server.URL + "/devfile.yaml", Token: "my-token"},
+			wantAuthorization: "Bearer my-token",
+		},
+		{
+			name:              "Case 2: Basic authentication",
+			params:            HTTPRequestParams{URL: server.URL + "/devfile.yaml", Username: "user", Password: "pass"},
+			wantAuthorization: "Basic dXNlcjpwYXNz",
+		},
+		{
+			name: "Case 3: Headers are sent",
+			params: HTTPRequestParams{
+				URL:     server.URL + "/devfile.yaml",
+				Headers: map[string]string{"Authorization": "token my-token", "X-Api-Key": "my-key"},
+			},
+			wantAuthorization: "token my-token",
+			wantHeaders:       map[string]string{"X-Api-Key": "my-key"},
+		},
+		{
+			name:              "Case 4: Authorization header is kept on a redirect to the same host",
+			params:            HTTPRequestParams{URL: server.URL + "/same-host", Token: "my-token"},
+			wantAuthorization: "Bearer my-token",
+		},
+		{
+			name: "Case 5: Authorization and caller-supplied headers are dropped on a redirect to another host",
+			params: HTTPRequestParams{
+				URL:     server.URL + "/cross-host",
+				Headers: map[string]string{"Authorization": "Bearer my-token", "Private-Token": "my-token", "X-Api-Key": "my-key"},
+			},
+			wantAuthorization: "",
+			wantHeaders:       map[string]string{"Private-Token": "", "X-Api-Key": ""},
+		},
+		{
+			name: "Case 6: Caller-supplied headers are kept on a redirect to the same host",
+			params: HTTPRequestParams{
+				URL:     server.URL + "/same-host",
+				Headers: map[string]string{"Private-Token": "my-token"},
+			},
+			wantHeaders: map[string]string{"Private-Token": "my-token"},
+		},
+		{
+			name: "Case 7: Authorization header is dropped on a redirect to another host with a custom client",
+			params: HTTPRequestParams{
+				URL:        server.URL + "/cross-host",
+				Token:      "my-token",
+				HTTPClient: &http.Client{},
+			},
+			wantAuthorization: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			got, err := DownloadInMemory(tt.params)
+			if err != nil {
+				t.Fatalf("Failed to download file with error %s", err)
+			}
+			if string(got) != "OK" {
+				t.Errorf("Got: %s, want: OK", got)
+			}
+			if auth := received.Get("Authorization"); auth != tt.wantAuthorization {
+				t.Errorf("Got Authorization header: %q, want: %q", auth, tt.wantAuthorization)
+			}
+			for name, value := range tt.wantHeaders {
+				if got := received.Get(name); got != value {
+					t.Errorf("Got %s header: %q, want: %q", name, got, value)
+				}
+			}
+			if tt.params.HTTPClient != nil && tt.params.HTTPClient.CheckRedirect != nil {
+				t.Errorf("The redirect policy of the client of the params should not be changed")
+			}
+		})
+	}
+}
+
+func TestDownloadFileInMemoryWithChecksum(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		content := "OK"
+		if req.Header.Get("X-Content") != "" {
+			content = req.Header.Get("X-Content")
+		}
+		_, err := rw.Write([]byte(content))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	// sha256 checksum of "OK"
+	const okChecksum = "565339bc4d33d72817b583024112eb7f5cdf3e5eef0252d6ec1b9c9a94e12bb3"
+	// sha256 checksum of "KO"
+	const koChecksum = "849af174c5b12dfa88182220b80a305693774a7d81561f83a11390184cc811c1"
+	const otherChecksum = "0000000000000000000000000000000000000000000000000000000000000000"
+
+	tests := []struct {
+		name     string
+		checksum string
+		headers  map[string]string
+		want     []byte
+		wantErr  string
+	}{
+		{
+			name:     "Case 1: Checksum matches",
+			checksum: okChecksum,
+			want:     []byte("OK"),
+		},
+		{
+			name:     "Case 2: Checksum with the sha256 prefix and in upper case matches",
+			checksum: "sha256:" + strings.ToUpper(okChecksum),
+			want:     []byte("OK"),
+		},
+		{
+			name:     "Case 3: Checksum does not match",
+			checksum: otherChecksum,
+			wantErr:  fmt.Sprintf("the sha256 checksum %s of the file %s does not match the expected checksum %s", okChecksum, server.URL, otherChecksum),
+		},
+		{
+			name:     "Case 4: Invalid checksum",
+			checksum: "not-a-checksum",
+			wantErr:  fmt.Sprintf("invalid expected sha256 checksum \"not-a-checksum\" of the file %s", server.URL),
+		},
+		{
+			name:     "Case 5: Checksum of the content downloaded with the headers of the params does not match",
+			checksum: okChecksum,
+			headers:  map[string]string{"X-Content": "KO"},
+			wantErr:  fmt.Sprintf("the sha256 checksum %s of the file %s does not match the expected checksum %s", koChecksum, server.URL, okChecksum),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []byte
+			var err error
+			if tt.headers == nil {
+				got, err = DownloadFileInMemoryWithChecksum(server.URL, tt.checksum)
+			} else {
+				got, err = DownloadInMemoryWithChecksum(HTTPRequestParams{URL: server.URL, Headers: tt.headers}, tt.checksum)
+			}
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Got error: %v, want: %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to download file with error %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownloadInMemoryMaxBytes(t *testing.T) {
+	// Start a local HTTP server
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/content-length" {
+			rw.Header().Set("Content-Length", "1048576")
+		}
+		// stream the content in chunks, the server would keep streaming if the client read the whole body
+		flusher, _ := rw.(http.Flusher)
+		chunk := []byte(strings.Repeat("a", 1024))
+		for i := 0; i < 1024; i++ {
+			if _, err := rw.Write(chunk); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		url      string
+		maxBytes int64
+		wantLen  int
+		wantErr  bool
+	}{
+		{
+			name:     "Case 1: Content streamed past the maximum size",
+			url:      server.URL + "/stream",
+			maxBytes: 4096,
+			wantErr:  true,
+		},
+		{
+			name:     "Case 2: Content-Length exceeds the maximum size",
+			url:      server.URL + "/content-length",
+			maxBytes: 4096,
+			wantErr:  true,
+		},
+		{
+			name:     "Case 3: Content within the maximum size",
+			url:      server.URL + "/stream",
+			maxBytes: 1024 * 1024,
+			wantLen:  1024 * 1024,
+		},
+		{
+			name:    "Case 4: No maximum size",
+			url:     server.URL + "/content-length",
+			wantLen: 1024 * 1024,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DownloadInMemory(HTTPRequestParams{URL: tt.url, MaxBytes: tt.maxBytes})
+			if tt.wantErr {
+				wantErr := fmt.Sprintf("failed to retrieve %s, the content exceeds the maximum size of %d bytes", tt.url, tt.maxBytes)
+				if err == nil || err.Error() != wantErr {
+					t.Errorf("Got error: %v, want: %s", err, wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to download file with error %s", err)
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("Got %d bytes, want: %d", len(got), tt.wantLen)
+			}
+		})
+	}
+}
+
 func TestValidateK8sResourceName(t *testing.T) {
 	tests := []struct {
 		name  string